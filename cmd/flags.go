@@ -69,7 +69,11 @@ namespaces are watched if this parameter is left empty.`)
 			`Enable profiling via web interface host:port/debug/pprof/`)
 
 		electionID = flags.String("election-id", "ingress-controller-leader",
-			`Election id to use for Ingress status updates.`)
+			`Election id of the ConfigMap used for leader election.`)
+
+		enableLeaderElection = flags.Bool("enable-leader-election", true,
+			`Only reconcile AWS resources and Ingress status in the replica elected leader, the other replicas
+stand by with their caches in sync. Requires the POD_NAME and POD_NAMESPACE environment variables.`)
 
 		showVersion = flags.Bool("version", false,
 			`Show release information about the AWS ALB Ingress controller and exit.`)
@@ -80,6 +84,10 @@ namespaces are watched if this parameter is left empty.`)
 		syncRateLimit = flags.Float32("sync-rate-limit", 0.3,
 			`Define the sync frequency upper limit`)
 
+		ingressWorkers = flags.Int("ingress-workers", cfg.IngressWorkers,
+			`Number of ingresses reconciled in parallel. Each ingress, or ingress group, is only reconciled
+when one of its ingresses or the Services they route to change.`)
+
 		clusterName = flags.String("cluster-name", "",
 			`Kubernetes cluster name (required)`)
 
@@ -92,6 +100,26 @@ namespaces are watched if this parameter is left empty.`)
 		targetType = flags.String("target-type", cfg.DefaultTargetType,
 			`Default target type to use for target groups, must be "instance" or "ip"`)
 
+		defaultShieldAdvancedProtection = flags.Bool("default-shield-advanced-protection", false,
+			`Protect internet-facing ALBs with AWS Shield Advanced, unless their shield-advanced-protection
+annotation is false. Requires a Shield Advanced subscription.`)
+
+		albReplacementGracePeriod = flags.Duration("alb-replacement-grace-period", cfg.ALBReplacementGracePeriod,
+			`Period an ALB keeps serving once the ALB replacing it is active. ALBs are replaced when a change,
+like their scheme, can't be applied in place.`)
+
+		nlbReplacementGracePeriod = flags.Duration("nlb-replacement-grace-period", cfg.NLBReplacementGracePeriod,
+			`Period the NLB of a Service keeps serving once the NLB replacing it is active. NLBs are replaced
+when their scheme changes.`)
+
+		route53PublicHostedZoneID = flags.String("route53-public-hosted-zone-id", "",
+			`Route53 hosted zone of the alias records of the Ingress hosts served by internet-facing ALBs.
+The records aren't managed if this parameter is left empty.`)
+
+		route53PrivateHostedZoneID = flags.String("route53-private-hosted-zone-id", "",
+			`Route53 hosted zone of the alias records of the Ingress hosts served by internal ALBs.
+The records aren't managed if this parameter is left empty.`)
+
 		restrictScheme = flags.Bool("restrict-scheme", false,
 			`Restrict the scheme to internal except for whitelisted namespaces`)
 
@@ -106,8 +134,28 @@ namespaces are watched if this parameter is left empty.`)
 
 		awsAPIDebug = flags.Bool("aws-api-debug", false,
 			`Enable debug logging of AWS API`)
+		enableTargetGroupBinding = flags.Bool("enable-target-group-binding", false,
+			`Register the endpoints of Services in existing target groups referenced by TargetGroupBinding resources.
+The TargetGroupBinding custom resource definition must be installed.`)
+
+		enableIngressClassParams = flags.Bool("enable-ingress-class-params", false,
+			`Enforce the IngressClassParams named after the ingress class on every Ingress of the class.
+The IngressClassParams custom resource definition must be installed.`)
+
+		dryRun = flags.Bool("dry-run", false,
+			`Record the changes to AWS resources instead of applying them. The planned changes are logged and served on the /plan endpoint of the healthz port.`)
+
 		healthzPort = flags.Int("healthz-port", cfg.HealthzPort, "Port to use for the healthz endpoint.")
 
+		webhookPort = flags.Int("webhook-port", cfg.WebhookPort,
+			`Port of the validating admission webhook of the Ingresses, served with TLS on the path /validate-ingress.`)
+
+		webhookCertFile = flags.String("webhook-cert-file", "",
+			`Certificate of the validating admission webhook. The webhook is disabled if this parameter is left empty.`)
+
+		webhookKeyFile = flags.String("webhook-key-file", "",
+			`Private key of the certificate of the validating admission webhook.`)
+
 		_ = flags.String("default-backend-service", "", `No longer used, will be removed in next release`)
 	)
 
@@ -145,6 +193,25 @@ namespaces are watched if this parameter is left empty.`)
 		return false, nil, fmt.Errorf("Port %v is already in use. Please check the flag --healthz-port", *healthzPort)
 	}
 
+	if *ingressWorkers < 1 {
+		return false, nil, fmt.Errorf("--ingress-workers must be at least 1")
+	}
+
+	if *albReplacementGracePeriod < 0 {
+		return false, nil, fmt.Errorf("--alb-replacement-grace-period must not be negative")
+	}
+
+	if *nlbReplacementGracePeriod < 0 {
+		return false, nil, fmt.Errorf("--nlb-replacement-grace-period must not be negative")
+	}
+
+	if (*webhookCertFile == "") != (*webhookKeyFile == "") {
+		return false, nil, fmt.Errorf("Both --webhook-cert-file and --webhook-key-file must be set to enable the webhook")
+	}
+	if *webhookCertFile != "" && !ing_net.IsPortAvailable(*webhookPort) {
+		return false, nil, fmt.Errorf("Port %v is already in use. Please check the flag --webhook-port", *webhookPort)
+	}
+
 	// Deal with legacy environment variable configuration options
 	if s, ok := os.LookupEnv("CLUSTER_NAME"); ok {
 		glog.Warningf("Environment variable configuration is deprecated, switch to the --cluster-name flag.")
@@ -201,15 +268,31 @@ namespaces are watched if this parameter is left empty.`)
 		DefaultTargetType:       *targetType,
 		DefaultBackendProtocol:  cfg.DefaultBackendProtocol,
 
-		APIServerHost:   *apiserverHost,
-		KubeConfigFile:  *kubeConfigFile,
-		ElectionID:      *electionID,
-		EnableProfiling: *profiling,
-		ResyncPeriod:    *resyncPeriod,
-		Namespace:       *watchNamespace,
+		DefaultShieldAdvancedProtection: *defaultShieldAdvancedProtection,
+		ALBReplacementGracePeriod:       *albReplacementGracePeriod,
+		NLBReplacementGracePeriod:       *nlbReplacementGracePeriod,
+		Route53PublicHostedZoneID:       *route53PublicHostedZoneID,
+		Route53PrivateHostedZoneID:      *route53PrivateHostedZoneID,
+
+		APIServerHost:        *apiserverHost,
+		KubeConfigFile:       *kubeConfigFile,
+		ElectionID:           *electionID,
+		EnableLeaderElection: *enableLeaderElection,
+		EnableProfiling:      *profiling,
+		ResyncPeriod:         *resyncPeriod,
+		Namespace:            *watchNamespace,
 		// ConfigMapName:           *configMap,
-		SyncRateLimit: *syncRateLimit,
-		HealthzPort:   *healthzPort,
+		SyncRateLimit:  *syncRateLimit,
+		IngressWorkers: *ingressWorkers,
+		HealthzPort:    *healthzPort,
+
+		WebhookPort:     *webhookPort,
+		WebhookCertFile: *webhookCertFile,
+		WebhookKeyFile:  *webhookKeyFile,
+
+		EnableTargetGroupBinding: *enableTargetGroupBinding,
+		EnableIngressClassParams: *enableIngressClassParams,
+		DryRun:                   *dryRun,
 	}
 
 	return false, config, nil
@@ -37,11 +37,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	discovery "k8s.io/apimachinery/pkg/version"
 	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/webhook"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/version"
 )
 
@@ -70,7 +73,7 @@ func main() {
 		glog.Fatal(err)
 	}
 
-	kubeClient, err := createApiserverClient(conf.APIServerHost, conf.KubeConfigFile)
+	kubeClient, dynamicClient, err := createApiserverClient(conf.APIServerHost, conf.KubeConfigFile)
 	if err != nil {
 		handleFatalInitError(err)
 	}
@@ -87,6 +90,8 @@ func main() {
 	}
 
 	conf.Client = kubeClient
+	conf.DynamicClient = dynamicClient
+	conf.IngressResource = k8s.IngressResource(kubeClient.Discovery())
 
 	cc := cache.NewConfig(5 * time.Minute)
 
@@ -116,6 +121,18 @@ func main() {
 	registerHealthz(c, mux)
 	registerMetrics(reg, mux)
 	registerHandlers(mux)
+	mux.HandleFunc("/debug/ingresses", c.ServeIngresses)
+	mux.HandleFunc("/reconcile", c.ServeReconcile)
+	if conf.DryRun {
+		mux.Handle("/plan", c.Plan())
+	}
+
+	if conf.WebhookCertFile != "" {
+		// the webhook port is exposed by a Service, it must only serve the webhook
+		webhookMux := http.NewServeMux()
+		webhookMux.Handle(webhook.Path, c.IngressValidator())
+		go startHTTPSServer(conf.WebhookPort, conf.WebhookCertFile, conf.WebhookKeyFile, webhookMux)
+	}
 
 	go startHTTPServer(conf.HealthzPort, mux)
 
@@ -151,10 +168,11 @@ func handleSigterm(c *controller.ALBController, exit exiter) {
 // If neither apiserverHost nor kubeConfig are passed in, we assume the
 // controller runs inside Kubernetes and fallback to the in-cluster config. If
 // the in-cluster config is missing or fails, we fallback to the default config.
-func createApiserverClient(apiserverHost, kubeConfig string) (*kubernetes.Clientset, error) {
+// A dynamic client is returned as well for the custom resources of the controller.
+func createApiserverClient(apiserverHost, kubeConfig string) (*kubernetes.Clientset, dynamic.Interface, error) {
 	cfg, err := clientcmd.BuildConfigFromFlags(apiserverHost, kubeConfig)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	cfg.QPS = defaultQPS
@@ -165,7 +183,13 @@ func createApiserverClient(apiserverHost, kubeConfig string) (*kubernetes.Client
 
 	client, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// custom resources don't support protobuf, the dynamic client always uses JSON
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	var v *discovery.Info
@@ -197,7 +221,7 @@ func createApiserverClient(apiserverHost, kubeConfig string) (*kubernetes.Client
 
 	// err is returned in case of timeout in the exponential backoff (ErrWaitTimeout)
 	if err != nil {
-		return nil, lastErr
+		return nil, nil, lastErr
 	}
 
 	// this should not happen, warn the user
@@ -208,7 +232,7 @@ func createApiserverClient(apiserverHost, kubeConfig string) (*kubernetes.Client
 	glog.Infof("Running in Kubernetes cluster version v%v.%v (%v) - git (%v) commit %v - platform %v",
 		v.Major, v.Minor, v.GitVersion, v.GitTreeState, v.GitCommit, v.Platform)
 
-	return client, nil
+	return client, dynamicClient, nil
 }
 
 // Handler for fatal init errors. Prints a verbose error message and exits.
@@ -278,3 +302,16 @@ func startHTTPServer(port int, mux *http.ServeMux) {
 	}
 	glog.Fatal(server.ListenAndServe())
 }
+
+// startHTTPSServer serves the mux with TLS, for the API server calling the validating admission webhook
+func startHTTPSServer(port int, certFile, keyFile string, mux *http.ServeMux) {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%v", port),
+		Handler:           mux,
+		ReadTimeout:       10 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      300 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	glog.Fatal(server.ListenAndServeTLS(certFile, keyFile))
+}
@@ -0,0 +1,348 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	api "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+)
+
+const (
+	// heritage identifies the ownership TXT records of the controller
+	heritage = "aws-alb-ingress-controller"
+
+	ownershipTTL = 300
+)
+
+// Records are the alias records of the hosts of an ingress in a Route53 hosted zone
+type Records struct {
+	// ZoneID is the ID of the hosted zone
+	ZoneID string
+
+	// Owner identifies the ingress owning the records in their ownership TXT record
+	Owner string
+
+	// Hosts are the hosts of the ingress, the hosts outside of the hosted zone are ignored
+	Hosts []string
+
+	// DNSName and CanonicalHostedZoneID are the alias target of the records, the load balancer of the ingress
+	DNSName               string
+	CanonicalHostedZoneID string
+
+	// IPv6 adds AAAA records along with the A records, for dualstack load balancers
+	IPv6 bool
+}
+
+// Controller manages the alias records of the ingress hosts in Route53 hosted zones. The records of a
+// host are owned by the ingress which created them, which is tracked by a TXT record of the host. The
+// records of a host owned by another ingress, or not created by the controller, are left alone.
+type Controller interface {
+	// Reconcile creates or updates the records of the hosts, and deletes the other records of the owner
+	Reconcile(context.Context, *Records) error
+
+	// Delete deletes every record of the owner in the hosted zone
+	Delete(context.Context, *Records) error
+
+	// Invalidate drops the cached records of the hosted zones, they are listed again by the next
+	// reconciliation, which restores the records modified outside of the controller
+	Invalidate()
+}
+
+// NewController constructs a new DNS controller. The ownership records hold the cluster name, so the
+// controllers of several clusters can share a hosted zone.
+func NewController(route53 route53iface.Route53API, clusterName string) Controller {
+	return &controller{
+		route53:     route53,
+		clusterName: clusterName,
+		zones:       make(map[string]*zone),
+	}
+}
+
+type controller struct {
+	route53     route53iface.Route53API
+	clusterName string
+
+	// zones caches the records of the hosted zones, it is shared by the ingresses reconciled in parallel
+	lock  sync.Mutex
+	zones map[string]*zone
+}
+
+// zone is the state of a hosted zone
+type zone struct {
+	name string
+
+	// owners maps the names with A, AAAA, CNAME or TXT records to the owner of their records, the owner
+	// is empty when the records aren't managed by the controller
+	owners map[string]string
+
+	// recordSets are the record sets of the names owned by the controller
+	recordSets map[string][]*route53.ResourceRecordSet
+}
+
+func (c *controller) Reconcile(ctx context.Context, desired *Records) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	z, err := c.zone(desired.ZoneID)
+	if err != nil {
+		return err
+	}
+
+	hosts := make(map[string]bool)
+	for _, host := range desired.Hosts {
+		name := fqdn(host)
+		if name != z.name && !strings.HasSuffix(name, "."+z.name) {
+			albctx.GetLogger(ctx).Debugf("Host %s is outside of hosted zone %s, skipping its records", host, z.name)
+			continue
+		}
+		if owner, ok := z.owners[name]; ok && owner != desired.Owner {
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepDNS.Reason(), "Records of %s in hosted zone %s are not owned by the ingress, leaving them alone", host, desired.ZoneID)
+			continue
+		}
+		hosts[name] = true
+		if err := c.apply(ctx, desired.ZoneID, z, name, desired.Owner, c.recordSets(name, desired)); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range z.ownedNames(desired.Owner) {
+		if hosts[name] {
+			continue
+		}
+		if err := c.apply(ctx, desired.ZoneID, z, name, desired.Owner, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) Delete(ctx context.Context, desired *Records) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	z, err := c.zone(desired.ZoneID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range z.ownedNames(desired.Owner) {
+		if err := c.apply(ctx, desired.ZoneID, z, name, desired.Owner, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) Invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.zones = make(map[string]*zone)
+}
+
+// zone returns the state of the hosted zone, its records are listed once and kept up to date with the
+// changes of the controller until the cache is invalidated
+func (c *controller) zone(zoneID string) (*zone, error) {
+	if z, ok := c.zones[zoneID]; ok {
+		return z, nil
+	}
+
+	o, err := c.route53.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting hosted zone %s: %s", zoneID, err.Error())
+	}
+
+	var sets []*route53.ResourceRecordSet
+	err = c.route53.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(p *route53.ListResourceRecordSetsOutput, _ bool) bool {
+			sets = append(sets, p.ResourceRecordSets...)
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing records of hosted zone %s: %s", zoneID, err.Error())
+	}
+
+	// a name is owned when its TXT record set is the single ownership record of the controller
+	owned := make(map[string]string)
+	for _, rs := range sets {
+		if aws.StringValue(rs.Type) != route53.RRTypeTxt || rs.SetIdentifier != nil || len(rs.ResourceRecords) != 1 {
+			continue
+		}
+		if owner, ok := c.owner(aws.StringValue(rs.ResourceRecords[0].Value)); ok {
+			owned[fqdn(aws.StringValue(rs.Name))] = owner
+		}
+	}
+
+	z := &zone{
+		name:       fqdn(aws.StringValue(o.HostedZone.Name)),
+		owners:     make(map[string]string),
+		recordSets: make(map[string][]*route53.ResourceRecordSet),
+	}
+	unmanaged := make(map[string]bool)
+	for _, rs := range sets {
+		switch aws.StringValue(rs.Type) {
+		case route53.RRTypeA, route53.RRTypeAaaa, route53.RRTypeCname, route53.RRTypeTxt:
+		default:
+			continue
+		}
+		name := fqdn(aws.StringValue(rs.Name))
+		owner, ok := owned[name]
+		if !ok || rs.SetIdentifier != nil {
+			unmanaged[name] = true
+			continue
+		}
+		z.owners[name] = owner
+		z.recordSets[name] = append(z.recordSets[name], rs)
+	}
+	for name := range unmanaged {
+		z.owners[name] = ""
+		delete(z.recordSets, name)
+	}
+
+	c.zones[zoneID] = z
+	return z, nil
+}
+
+// apply changes the record sets of the name to desired, the record sets are deleted when desired is empty
+func (c *controller) apply(ctx context.Context, zoneID string, z *zone, name string, owner string, desired []*route53.ResourceRecordSet) error {
+	current := z.recordSets[name]
+
+	var changes []*route53.Change
+	for _, rs := range desired {
+		if cur := findRecordSet(current, aws.StringValue(rs.Type)); cur == nil {
+			changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionCreate), ResourceRecordSet: rs})
+		} else if !recordSetEquals(cur, rs) {
+			changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: rs})
+		}
+	}
+	for _, rs := range current {
+		if findRecordSet(desired, aws.StringValue(rs.Type)) == nil {
+			changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: rs})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("Changing %d records of %s in hosted zone %s.", len(changes), name, zoneID)
+	_, err := c.route53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String(fmt.Sprintf("records of %s managed by %s", owner, heritage)),
+			Changes: changes,
+		},
+	})
+	if err != nil {
+		// the records may have been modified outside of the controller, they are listed again at the next attempt
+		delete(c.zones, zoneID)
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepDNS.Reason(), "Error changing records of %s in hosted zone %s: %s", name, zoneID, err.Error())
+		return fmt.Errorf("Failed changing records of %s in hosted zone %s: %s", name, zoneID, err.Error())
+	}
+
+	if len(desired) == 0 {
+		delete(z.owners, name)
+		delete(z.recordSets, name)
+		albctx.GetEventf(ctx)(api.EventTypeNormal, "DELETE", "Records of %s deleted", name)
+		return nil
+	}
+	z.owners[name] = owner
+	z.recordSets[name] = desired
+	albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "Records of %s updated", name)
+	return nil
+}
+
+// recordSets returns the alias records of the name pointing to the load balancer and its ownership record
+func (c *controller) recordSets(name string, r *Records) []*route53.ResourceRecordSet {
+	alias := &route53.AliasTarget{
+		DNSName:              aws.String(fqdn(r.DNSName)),
+		HostedZoneId:         aws.String(r.CanonicalHostedZoneID),
+		EvaluateTargetHealth: aws.Bool(true),
+	}
+	sets := []*route53.ResourceRecordSet{
+		{Name: aws.String(name), Type: aws.String(route53.RRTypeA), AliasTarget: alias},
+	}
+	if r.IPv6 {
+		sets = append(sets, &route53.ResourceRecordSet{Name: aws.String(name), Type: aws.String(route53.RRTypeAaaa), AliasTarget: alias})
+	}
+	return append(sets, &route53.ResourceRecordSet{
+		Name:            aws.String(name),
+		Type:            aws.String(route53.RRTypeTxt),
+		TTL:             aws.Int64(ownershipTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(c.ownership(r.Owner))}},
+	})
+}
+
+// ownership returns the value of the ownership TXT record of the owner
+func (c *controller) ownership(owner string) string {
+	return fmt.Sprintf(`"heritage=%s,cluster=%s,owner=%s"`, heritage, c.clusterName, owner)
+}
+
+// owner returns the owner of an ownership TXT record value of the controller, false for the other values
+func (c *controller) owner(value string) (string, bool) {
+	prefix := fmt.Sprintf(`"heritage=%s,cluster=%s,owner=`, heritage, c.clusterName)
+	if !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, `"`) {
+		return "", false
+	}
+	owner := strings.TrimSuffix(strings.TrimPrefix(value, prefix), `"`)
+	return owner, owner != ""
+}
+
+// ownedNames returns the names whose records are owned by the owner
+func (z *zone) ownedNames(owner string) []string {
+	var names []string
+	for name, o := range z.owners {
+		if o == owner {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func findRecordSet(sets []*route53.ResourceRecordSet, rrType string) *route53.ResourceRecordSet {
+	for _, rs := range sets {
+		if aws.StringValue(rs.Type) == rrType {
+			return rs
+		}
+	}
+	return nil
+}
+
+// recordSetEquals compares the record sets of the controller, Route53 returns the alias targets lower
+// cased and fully qualified
+func recordSetEquals(a *route53.ResourceRecordSet, b *route53.ResourceRecordSet) bool {
+	if (a.AliasTarget == nil) != (b.AliasTarget == nil) {
+		return false
+	}
+	if a.AliasTarget != nil {
+		return fqdn(aws.StringValue(a.AliasTarget.DNSName)) == fqdn(aws.StringValue(b.AliasTarget.DNSName)) &&
+			aws.StringValue(a.AliasTarget.HostedZoneId) == aws.StringValue(b.AliasTarget.HostedZoneId) &&
+			aws.BoolValue(a.AliasTarget.EvaluateTargetHealth) == aws.BoolValue(b.AliasTarget.EvaluateTargetHealth)
+	}
+	if aws.Int64Value(a.TTL) != aws.Int64Value(b.TTL) || len(a.ResourceRecords) != len(b.ResourceRecords) {
+		return false
+	}
+	for i := range a.ResourceRecords {
+		if aws.StringValue(a.ResourceRecords[i].Value) != aws.StringValue(b.ResourceRecords[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// fqdn returns the fully qualified, lower cased name. Route53 returns the wildcard of names escaped.
+func fqdn(name string) string {
+	name = strings.ToLower(strings.Replace(name, `\052`, "*", -1))
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
@@ -0,0 +1,178 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// fakeRoute53 serves a hosted zone from memory
+type fakeRoute53 struct {
+	route53iface.Route53API
+	name    string
+	sets    []*route53.ResourceRecordSet
+	changes []*route53.Change
+}
+
+func (f *fakeRoute53) GetHostedZone(in *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	return &route53.GetHostedZoneOutput{HostedZone: &route53.HostedZone{Id: in.Id, Name: aws.String(f.name)}}, nil
+}
+
+func (f *fakeRoute53) ListResourceRecordSetsPages(in *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: f.sets}, true)
+	return nil
+}
+
+func (f *fakeRoute53) ChangeResourceRecordSets(in *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	f.changes = append(f.changes, in.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+// reset returns the changes since the last reset, as "ACTION name TYPE"
+func (f *fakeRoute53) reset() []string {
+	var changes []string
+	for _, c := range f.changes {
+		changes = append(changes, *c.Action+" "+*c.ResourceRecordSet.Name+" "+*c.ResourceRecordSet.Type)
+	}
+	f.changes = nil
+	return changes
+}
+
+func assertChanges(t *testing.T, step string, expected []string, actual []string) {
+	if len(expected) != len(actual) {
+		t.Errorf("%s: expected changes %v, got %v", step, expected, actual)
+		return
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("%s: expected changes %v, got %v", step, expected, actual)
+			return
+		}
+	}
+}
+
+func TestController(t *testing.T) {
+	r53 := &fakeRoute53{
+		name: "example.com.",
+		sets: []*route53.ResourceRecordSet{
+			{
+				Name:            aws.String("foreign.example.com."),
+				Type:            aws.String(route53.RRTypeCname),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("elsewhere.example.org")}},
+			},
+			{
+				Name:            aws.String("other.example.com."),
+				Type:            aws.String(route53.RRTypeTxt),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(`"heritage=aws-alb-ingress-controller,cluster=cluster1,owner=default/other"`)}},
+			},
+			{
+				Name:            aws.String("\\052.example.com."),
+				Type:            aws.String(route53.RRTypeTxt),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(`"heritage=aws-alb-ingress-controller,cluster=cluster1,owner=default/ingress"`)}},
+			},
+			{
+				Name:        aws.String("\\052.example.com."),
+				Type:        aws.String(route53.RRTypeA),
+				AliasTarget: &route53.AliasTarget{DNSName: aws.String("old-alb.us-west-2.elb.amazonaws.com."), HostedZoneId: aws.String("Z1H1FL5HABSF5"), EvaluateTargetHealth: aws.Bool(true)},
+			},
+		},
+	}
+	c := NewController(r53, "cluster1")
+	records := &Records{
+		ZoneID:                "Z123",
+		Owner:                 "default/ingress",
+		Hosts:                 []string{"app.example.com", "foreign.example.com", "other.example.com", "app.example.org"},
+		DNSName:               "alb.us-west-2.elb.amazonaws.com",
+		CanonicalHostedZoneID: "Z1H1FL5HABSF5",
+	}
+
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "creation", []string{
+		"CREATE app.example.com. A",
+		"CREATE app.example.com. TXT",
+		"DELETE \\052.example.com. TXT",
+		"DELETE \\052.example.com. A",
+	}, r53.reset())
+
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "no change", nil, r53.reset())
+
+	records.IPv6 = true
+	records.DNSName = "replacement.us-west-2.elb.amazonaws.com"
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "new target", []string{
+		"UPSERT app.example.com. A",
+		"CREATE app.example.com. AAAA",
+	}, r53.reset())
+
+	if err := c.Delete(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "deletion", []string{
+		"DELETE app.example.com. A",
+		"DELETE app.example.com. AAAA",
+		"DELETE app.example.com. TXT",
+	}, r53.reset())
+}
+
+func TestControllerInvalidate(t *testing.T) {
+	records := &Records{
+		ZoneID:                "Z123",
+		Owner:                 "default/ingress",
+		Hosts:                 []string{"app.example.com"},
+		DNSName:               "alb.us-west-2.elb.amazonaws.com",
+		CanonicalHostedZoneID: "Z1H1FL5HABSF5",
+	}
+	existing := &controller{clusterName: "cluster1"}
+	r53 := &fakeRoute53{name: "example.com.", sets: existing.recordSets("app.example.com.", records)}
+	c := NewController(r53, "cluster1")
+
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "existing records", nil, r53.reset())
+
+	// the records are deleted outside of the controller
+	r53.sets = nil
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "cached records", nil, r53.reset())
+
+	c.Invalidate()
+	if err := c.Reconcile(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, "invalidated records", []string{
+		"CREATE app.example.com. A",
+		"CREATE app.example.com. TXT",
+	}, r53.reset())
+}
+
+func TestOwner(t *testing.T) {
+	c := &controller{clusterName: "cluster1"}
+	for _, tc := range []struct {
+		value string
+		owner string
+		ok    bool
+	}{
+		{value: c.ownership("default/ingress"), owner: "default/ingress", ok: true},
+		{value: `"heritage=aws-alb-ingress-controller,cluster=cluster2,owner=default/ingress"`},
+		{value: `"heritage=external-dns,external-dns/owner=default"`},
+		{value: `"heritage=aws-alb-ingress-controller,cluster=cluster1,owner="`},
+	} {
+		owner, ok := c.owner(tc.value)
+		if owner != tc.owner || ok != tc.ok {
+			t.Errorf("%s: expected owner %q %v, got %q %v", tc.value, tc.owner, tc.ok, owner, ok)
+		}
+	}
+}
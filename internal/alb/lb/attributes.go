@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	api "k8s.io/api/core/v1"
 )
@@ -147,7 +148,7 @@ func (c *attributesController) Reconcile(ctx context.Context, desired *Attribute
 			Attributes:      changeSet,
 		})
 		if err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", desired.LbArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepAttributes.Reason(), "%s attributes modification failed: %s", desired.LbArn, err.Error())
 			return fmt.Errorf("failed modifying attributes: %s", err)
 		}
 
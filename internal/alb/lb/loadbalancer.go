@@ -14,17 +14,22 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albshield"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafregional"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafv2"
 
 	extensions "k8s.io/api/extensions/v1beta1"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/replacement"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	api "k8s.io/api/core/v1"
@@ -35,11 +40,19 @@ type NewDesiredLoadBalancerOptions struct {
 	Store                store.Storer
 	Ingress              *extensions.Ingress
 	CommonTags           *tags.Tags
+	// GroupName and Ingresses are set when the load balancer is shared by an ingress group.
+	// Ingresses holds every member in group order, Ingress is the first of them and its
+	// annotations configure the load balancer itself.
+	GroupName string
+	Ingresses []*extensions.Ingress
 }
 
 // NewDesiredLoadBalancer returns a new loadbalancer.LoadBalancer based on the opts provided.
 func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *LoadBalancer, err error) {
 	name := createLBName(o.Ingress.Namespace, o.Ingress.Name, o.Store.GetConfig().ALBNamePrefix)
+	if o.GroupName != "" {
+		name = createGroupLBName(o.GroupName, o.Store.GetConfig().ALBNamePrefix)
+	}
 
 	lbTags := o.CommonTags.Copy()
 
@@ -53,12 +66,40 @@ func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *
 		return nil, err
 	}
 
+	// the default protection only applies to internet-facing load balancers, the protection of the others
+	// is left alone unless the annotation is set
+	shieldProtection := annos.LoadBalancer.ShieldAdvancedProtection
+	if shieldProtection == nil && o.Store.GetConfig().DefaultShieldAdvancedProtection &&
+		aws.StringValue(annos.LoadBalancer.Scheme) == elbv2.LoadBalancerSchemeEnumInternetFacing {
+		shieldProtection = aws.Bool(true)
+	}
+
+	// the scheme of a load balancer can't be modified, a new load balancer replaces it. A single
+	// replacement is in progress at a time, further ones wait for the replaced load balancer's deletion.
+	existinglb := o.ExistingLoadBalancer
+	var replaced *LoadBalancer
+	if existinglb != nil {
+		if existinglb.replaced == nil && existinglb.lb.current != nil &&
+			!util.DeepEqual(existinglb.lb.current.Scheme, annos.LoadBalancer.Scheme) {
+			name = replacement.Name(existinglb.id, name)
+			replaced = existinglb
+			replaced.StripDesiredState()
+			existinglb = nil
+		} else {
+			// the load balancer keeps its name, a replacement isn't named after the ingress
+			name = existinglb.id
+		}
+	}
+
 	newLoadBalancer = &LoadBalancer{
-		id:   name,
-		tags: lbTags,
+		id:       name,
+		tags:     lbTags,
+		replaced: replaced,
 		options: options{
 			desired: opts{
-				webACLId: annos.LoadBalancer.WebACLId,
+				webACLId:         annos.LoadBalancer.WebACLId,
+				wafv2ACLArn:      annos.LoadBalancer.WAFv2ACLArn,
+				shieldProtection: shieldProtection,
 			},
 		},
 		lb: lb{
@@ -74,13 +115,14 @@ func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *
 
 	var existingtgs tg.TargetGroups
 	var existingls ls.Listeners
-	existinglb := o.ExistingLoadBalancer
 
 	if existinglb != nil {
 		// we had an existing LoadBalancer in ingress, so just copy the desired state over
 		existinglb.lb.desired = newLoadBalancer.lb.desired
 		existinglb.tags = newLoadBalancer.tags
 		existinglb.options.desired.webACLId = newLoadBalancer.options.desired.webACLId
+		existinglb.options.desired.wafv2ACLArn = newLoadBalancer.options.desired.wafv2ACLArn
+		existinglb.options.desired.shieldProtection = newLoadBalancer.options.desired.shieldProtection
 
 		newLoadBalancer = existinglb
 		existingtgs = existinglb.targetgroups
@@ -92,6 +134,7 @@ func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *
 	// Assemble the target groups
 	newLoadBalancer.targetgroups, err = tg.NewDesiredTargetGroups(&tg.NewDesiredTargetGroupsOptions{
 		Ingress:              o.Ingress,
+		Ingresses:            o.Ingresses,
 		LoadBalancerID:       newLoadBalancer.id,
 		ExistingTargetGroups: existingtgs,
 		Store:                o.Store,
@@ -105,15 +148,16 @@ func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *
 	// Assemble the listeners
 	newLoadBalancer.listeners, err = ls.NewDesiredListeners(&ls.NewDesiredListenersOptions{
 		Ingress:           o.Ingress,
+		Ingresses:         o.Ingresses,
 		Store:             o.Store,
 		ExistingListeners: existingls,
 		TargetGroups:      newLoadBalancer.targetgroups,
 	})
 
 	// Assemble SecurityGroups
-	lbPorts := []int64{}
-	for _, port := range annos.LoadBalancer.Ports {
-		lbPorts = append(lbPorts, port.Port)
+	lbPorts, err := o.listenerPorts()
+	if err != nil {
+		return newLoadBalancer, err
 	}
 	newLoadBalancer.sgAssociation = sg.Association{
 		LbID:           name,
@@ -131,6 +175,30 @@ func NewDesiredLoadBalancer(o *NewDesiredLoadBalancerOptions) (newLoadBalancer *
 	return newLoadBalancer, err
 }
 
+// listenerPorts returns the ports the load balancer listens on, across every member of its ingress group.
+func (o *NewDesiredLoadBalancerOptions) listenerPorts() ([]int64, error) {
+	ingresses := o.Ingresses
+	if len(ingresses) == 0 {
+		ingresses = []*extensions.Ingress{o.Ingress}
+	}
+
+	lbPorts := []int64{}
+	seen := make(map[int64]bool)
+	for _, ing := range ingresses {
+		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
+		if err != nil {
+			return nil, err
+		}
+		for _, port := range annos.LoadBalancer.Ports {
+			if !seen[port.Port] {
+				seen[port.Port] = true
+				lbPorts = append(lbPorts, port.Port)
+			}
+		}
+	}
+	return lbPorts, nil
+}
+
 type NewCurrentLoadBalancerOptions struct {
 	LoadBalancer *elbv2.LoadBalancer
 	TargetGroups map[string][]*elbv2.TargetGroup
@@ -148,15 +216,15 @@ func NewCurrentLoadBalancer(o *NewCurrentLoadBalancerOptions) (newLoadBalancer *
 		webACLId = webACLResult.WebACLId
 	}
 
+	// the WAFv2 Web ACL association and the Shield Advanced protection are read by loadOptions once
+	// the desired state tells whether the controller manages them
 	newLoadBalancer = &LoadBalancer{
 		id:            *o.LoadBalancer.LoadBalancerName,
 		tags:          &tags.Tags{},
 		lb:            lb{current: o.LoadBalancer},
 		attributes:    &Attributes{},
 		sgAssociation: sg.Association{LbID: *o.LoadBalancer.LoadBalancerName},
-		options: options{current: opts{
-			webACLId: webACLId,
-		}},
+		options:       options{current: opts{webACLId: webACLId}},
 	}
 
 	// Assemble target groups
@@ -191,7 +259,7 @@ func NewCurrentLoadBalancer(o *NewCurrentLoadBalancerOptions) (newLoadBalancer *
 // results in no action, the creation, the deletion, or the modification of an AWS ELBV2 to
 // satisfy the ingress's current state.
 func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) []error {
-	var errors []error
+	var errs []error
 	lbc := l.lb.current
 	lbd := l.lb.desired
 
@@ -202,7 +270,7 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 		}
 		albctx.GetLogger(ctx).Infof("Start ELBV2 deletion.")
 		if err := l.delete(ctx, rOpts); err != nil {
-			errors = append(errors, err)
+			errs = append(errs, errors.NewReconcileError(errors.StepLoadBalancer, err))
 			break
 		}
 		albctx.GetEventf(ctx)(api.EventTypeNormal, "DELETE", "%s deleted", *lbc.LoadBalancerName)
@@ -211,10 +279,14 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 			*lbc.LoadBalancerArn)
 
 	case lbc == nil: // lb doesn't exist and should be created
-		albctx.GetLogger(ctx).Infof("Start ELBV2 creation.")
+		if l.replaced != nil {
+			albctx.GetLogger(ctx).Infof("Start ELBV2 creation, replacing %s.", *l.replaced.lb.current.LoadBalancerName)
+		} else {
+			albctx.GetLogger(ctx).Infof("Start ELBV2 creation.")
+		}
 		if err := l.create(ctx, rOpts); err != nil {
-			errors = append(errors, err)
-			return errors
+			errs = append(errs, errors.NewReconcileError(errors.StepLoadBalancer, err))
+			return errs
 		}
 		lbc = l.lb.current
 		albctx.GetEventf(ctx)(api.EventTypeNormal, "CREATE", "%s created", *lbc.LoadBalancerName)
@@ -224,7 +296,7 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 
 	default: // check for diff between lb current and desired, modify if necessary
 		if err := l.modify(ctx, rOpts); err != nil {
-			errors = append(errors, err)
+			errs = append(errs, errors.NewReconcileError(errors.StepLoadBalancer, err))
 			break
 		}
 	}
@@ -240,7 +312,7 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 	// Creates target groups
 	tgs, err := l.targetgroups.Reconcile(ctx, tgsOpts)
 	if err != nil {
-		errors = append(errors, err)
+		errs = append(errs, errors.NewReconcileError(errors.StepTargetGroup, err))
 	} else {
 		l.targetgroups = tgs
 	}
@@ -248,21 +320,21 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 	lsOpts := &ls.ReconcileOptions{
 		LoadBalancerArn: lbc.LoadBalancerArn,
 		TargetGroups:    l.targetgroups,
-		Ingress:         rOpts.Ingress,
 		Store:           rOpts.Store,
 	}
 	if ltnrs, err := l.listeners.Reconcile(ctx, lsOpts); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, errors.NewReconcileError(errors.StepListener, err))
 	} else {
 		l.listeners = ltnrs
 	}
 
-	// TODO: currently this works fine since every listener get same actions,
-	// when this precondition don't hold, we need to consider deletion at cross-listener level.
-
-	// Does not consider TG used for listener default action
-	for _, listener := range l.listeners {
-		unusedTGs := listener.GetRules().FindUnusedTGs(l.targetgroups, listener.DefaultActionArn())
+	// A target group is unused when no listener references it. Listeners of an ingress group
+	// do not share their rules, and weighted actions reference several target groups.
+	if len(l.listeners) > 0 {
+		unusedTGs := l.targetgroups
+		for _, listener := range l.listeners {
+			unusedTGs = listener.GetRules().FindUnusedTGs(unusedTGs, listener.DefaultActionArns()...)
+		}
 		unusedTGs.StripDesiredState()
 	}
 
@@ -270,33 +342,65 @@ func (l *LoadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) [
 	tgsOpts.IgnoreDeletes = false
 	tgs, err = l.targetgroups.Reconcile(ctx, tgsOpts)
 	if err != nil {
-		errors = append(errors, err)
+		errs = append(errs, errors.NewReconcileError(errors.StepTargetGroup, err))
 	} else {
 		l.targetgroups = tgs
 	}
 
+	errs = append(errs, l.reconcileReplaced(ctx, rOpts)...)
+
 	if !l.deleted {
 		l.tags.Arn = aws.StringValue(l.lb.current.LoadBalancerArn)
 		err := rOpts.TagsController.Reconcile(ctx, l.tags)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("failed tagging due to %s", err.Error()))
+			errs = append(errs, errors.NewReconcileError(errors.StepTags, fmt.Errorf("failed tagging due to %s", err.Error())))
 		}
 
 		l.sgAssociation.LbArn = aws.StringValue(l.lb.current.LoadBalancerArn)
 		l.sgAssociation.Targets = l.targetgroups
 		err = rOpts.SgAssociationController.Reconcile(ctx, &l.sgAssociation)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("failed association of SecurityGroups due to %s", err.Error()))
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepSecurityGroup.Reason(), "Error associating security groups with %s: %s", l.sgAssociation.LbArn, err.Error())
+			errs = append(errs, errors.NewReconcileError(errors.StepSecurityGroup, fmt.Errorf("failed association of SecurityGroups due to %s", err.Error())))
 		}
 
 		l.attributes.LbArn = aws.StringValue(l.lb.current.LoadBalancerArn)
 		err = rOpts.LbAttributesController.Reconcile(ctx, l.attributes)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("failed configuration of load balancer attributes due to %s", err.Error()))
+			errs = append(errs, errors.NewReconcileError(errors.StepAttributes, fmt.Errorf("failed configuration of load balancer attributes due to %s", err.Error())))
+		}
+	}
+
+	return errs
+}
+
+// reconcileReplaced deletes the load balancer replaced by this load balancer once this load balancer is
+// active and the grace period elapsed. It is deleted right away when this load balancer is deleted.
+func (l *LoadBalancer) reconcileReplaced(ctx context.Context, rOpts *ReconcileOptions) []error {
+	if l.replaced == nil {
+		return nil
+	}
+
+	if l.lb.desired != nil {
+		if l.lb.current == nil {
+			return nil
+		}
+		elapsed, err := l.replacement.Elapsed(ctx, l.lb.current, l.replaced.lb.current, rOpts.Store.GetConfig().ALBReplacementGracePeriod)
+		if err != nil {
+			return []error{errors.NewReconcileError(errors.StepLoadBalancer, err)}
+		}
+		if !elapsed {
+			return nil
 		}
 	}
 
-	return errors
+	albctx.GetLogger(ctx).Infof("Start deletion of replaced ELBV2 %s.", *l.replaced.lb.current.LoadBalancerName)
+	if errs := l.replaced.Reconcile(ctx, rOpts); len(errs) > 0 {
+		return errs
+	}
+	l.replaced = nil
+	l.replacement.Reset()
+	return nil
 }
 
 // create requests a new ELBV2 is created in AWS.
@@ -312,7 +416,7 @@ func (l *LoadBalancer) create(ctx context.Context, rOpts *ReconcileOptions) erro
 
 	o, err := albelbv2.ELBV2svc.CreateLoadBalancer(in)
 	if err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error creating %s: %s", *in.Name, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "Error creating %s: %s", *in.Name, err.Error())
 		albctx.GetLogger(ctx).Errorf("Failed to create ELBV2: %s", err.Error())
 		return err
 	}
@@ -323,16 +427,40 @@ func (l *LoadBalancer) create(ctx context.Context, rOpts *ReconcileOptions) erro
 	if l.options.desired.webACLId != nil {
 		_, err = albwafregional.WAFRegionalsvc.Associate(l.lb.current.LoadBalancerArn, l.options.desired.webACLId)
 		if err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, l.options.desired.webACLId, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, l.options.desired.webACLId, err.Error())
 			albctx.GetLogger(ctx).Errorf("Failed setting Web ACL (%s) association: %s", l.options.desired.webACLId, err.Error())
 			return err
 		}
 	}
+
+	if aws.StringValue(l.options.desired.wafv2ACLArn) != "" {
+		_, err = albwafv2.WAFV2svc.Associate(l.lb.current.LoadBalancerArn, l.options.desired.wafv2ACLArn)
+		if err != nil {
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s WAFv2 Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, *l.options.desired.wafv2ACLArn, err.Error())
+			albctx.GetLogger(ctx).Errorf("Failed setting WAFv2 Web ACL (%s) association: %s", *l.options.desired.wafv2ACLArn, err.Error())
+			return err
+		}
+	}
+
+	// a new load balancer has no other Web ACL or protection than the ones of the controller
+	if aws.StringValue(l.options.desired.wafv2ACLArn) != "" {
+		l.options.current.wafv2ACLArn = l.options.desired.wafv2ACLArn
+	}
+	l.options.current.wafv2ACLArnKnown = true
+	l.options.current.shieldProtectionIDKnown = true
+
+	if aws.BoolValue(l.options.desired.shieldProtection) {
+		return l.protect(ctx)
+	}
 	return nil
 }
 
 // modify modifies the attributes of an existing ALB in AWS.
 func (l *LoadBalancer) modify(ctx context.Context, rOpts *ReconcileOptions) error {
+	if err := l.loadOptions(ctx); err != nil {
+		return err
+	}
+
 	needsMod, canMod := l.needsModification(ctx)
 	if needsMod == 0 {
 		return nil
@@ -346,7 +474,7 @@ func (l *LoadBalancer) modify(ctx context.Context, rOpts *ReconcileOptions) erro
 				LoadBalancerArn: l.lb.current.LoadBalancerArn,
 				Subnets:         util.AvailabilityZones(l.lb.desired.AvailabilityZones).AsSubnets(),
 			}); err != nil {
-				albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s subnet modification failed: %s", *l.lb.current.LoadBalancerName, err.Error())
+				albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s subnet modification failed: %s", *l.lb.current.LoadBalancerName, err.Error())
 				return fmt.Errorf("Failed setting ELBV2 subnets: %s", err)
 			}
 			l.lb.current.AvailabilityZones = l.lb.desired.AvailabilityZones
@@ -367,12 +495,24 @@ func (l *LoadBalancer) modify(ctx context.Context, rOpts *ReconcileOptions) erro
 			albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%s ip address type modified", *l.lb.current.LoadBalancerName)
 		}
 
+		// A load balancer is associated with a single Web ACL, the WAFv2 Web ACL is disassociated before
+		// a WAF Classic Web ACL is associated, and associated once the WAF Classic Web ACL is disassociated
+		if needsMod&wafv2ACLAssociationModified != 0 && aws.StringValue(l.options.desired.wafv2ACLArn) == "" {
+			albctx.GetLogger(ctx).Infof("Disassociating WAFv2 Web ACL.")
+			if _, err := albwafv2.WAFV2svc.Disassociate(l.lb.current.LoadBalancerArn); err != nil {
+				albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s WAFv2 Web ACL disassociation failed: %s", *l.lb.current.LoadBalancerName, err.Error())
+				return fmt.Errorf("Failed removing WAFv2 Web ACL association: %s", err.Error())
+			}
+			l.options.current.wafv2ACLArn = nil
+			albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "WAFv2 Web ACL disassociated")
+		}
+
 		// Modify Web ACL
 		if needsMod&webACLAssociationModified != 0 {
 			if l.options.desired.webACLId != nil { // Associate
 				albctx.GetLogger(ctx).Infof("Associating %v Web ACL.", *l.options.desired.webACLId)
 				if _, err := albwafregional.WAFRegionalsvc.Associate(l.lb.current.LoadBalancerArn, l.options.desired.webACLId); err != nil {
-					albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, *l.options.desired.webACLId, err.Error())
+					albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, *l.options.desired.webACLId, err.Error())
 					return fmt.Errorf("Failed associating Web ACL: %s", err.Error())
 				}
 				l.options.current.webACLId = l.options.desired.webACLId
@@ -380,7 +520,7 @@ func (l *LoadBalancer) modify(ctx context.Context, rOpts *ReconcileOptions) erro
 			} else { // Disassociate
 				albctx.GetLogger(ctx).Infof("Disassociating Web ACL.")
 				if _, err := albwafregional.WAFRegionalsvc.Disassociate(l.lb.current.LoadBalancerArn); err != nil {
-					albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s Web ACL disassociation failed: %s", *l.lb.current.LoadBalancerName, err.Error())
+					albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Web ACL disassociation failed: %s", *l.lb.current.LoadBalancerName, err.Error())
 					return fmt.Errorf("Failed removing Web ACL association: %s", err.Error())
 				}
 				l.options.current.webACLId = l.options.desired.webACLId
@@ -388,19 +528,31 @@ func (l *LoadBalancer) modify(ctx context.Context, rOpts *ReconcileOptions) erro
 			}
 		}
 
-	} else {
-		// Modification is needed, but required full replacement of ALB.
-		// TODO improve this process, it generally fails some deletions and completes in the next sync
-		albctx.GetLogger(ctx).Infof("Start ELBV2 full modification (delete and create).")
-		albctx.GetEventf(ctx)(api.EventTypeNormal, "REBUILD", "Impossible modification requested, rebuilding %s", *l.lb.current.LoadBalancerName)
-		l.delete(ctx, rOpts)
-		// Since listeners and rules are deleted during lb deletion, ensure their current state is removed
-		// as they'll no longer exist.
-		l.listeners.StripCurrentState()
-		l.create(ctx, rOpts)
-		albctx.GetLogger(ctx).Infof("Completed ELBV2 full modification (delete and create). Name: %s | ARN: %s",
-			*l.lb.current.LoadBalancerName, *l.lb.current.LoadBalancerArn)
+		// Modify WAFv2 Web ACL
+		if needsMod&wafv2ACLAssociationModified != 0 && aws.StringValue(l.options.desired.wafv2ACLArn) != "" {
+			albctx.GetLogger(ctx).Infof("Associating %v WAFv2 Web ACL.", *l.options.desired.wafv2ACLArn)
+			if _, err := albwafv2.WAFV2svc.Associate(l.lb.current.LoadBalancerArn, l.options.desired.wafv2ACLArn); err != nil {
+				albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s WAFv2 Web ACL (%s) association failed: %s", *l.lb.current.LoadBalancerName, *l.options.desired.wafv2ACLArn, err.Error())
+				return fmt.Errorf("Failed associating WAFv2 Web ACL: %s", err.Error())
+			}
+			l.options.current.wafv2ACLArn = l.options.desired.wafv2ACLArn
+			albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "WAFv2 Web ACL association updated to %s", *l.options.desired.wafv2ACLArn)
+		}
 
+		// Modify Shield Advanced protection
+		if needsMod&shieldProtectionModified != 0 {
+			if *l.options.desired.shieldProtection {
+				if err := l.protect(ctx); err != nil {
+					return err
+				}
+			} else if err := l.unprotect(ctx); err != nil {
+				return err
+			}
+		}
+
+	} else {
+		// the load balancer is replaced once the previous replacement completed, see NewDesiredLoadBalancer
+		albctx.GetLogger(ctx).Infof("Replacement of %s waits for the previous replacement to complete.", *l.lb.current.LoadBalancerName)
 	}
 
 	return nil
@@ -419,22 +571,112 @@ func (l *LoadBalancer) delete(ctx context.Context, rOpts *ReconcileOptions) erro
 	// we need to disassociate the WAF before deletion
 	if l.options.current.webACLId != nil {
 		if _, err := albwafregional.WAFRegionalsvc.Disassociate(l.lb.current.LoadBalancerArn); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error disassociating Web ACL for %s: %s", *l.lb.current.LoadBalancerName, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "Error disassociating Web ACL for %s: %s", *l.lb.current.LoadBalancerName, err.Error())
 			return fmt.Errorf("Failed disassociation of ELBV2 Web ACL: %s.", err.Error())
 		}
 	}
+	if l.options.current.wafv2ACLArn != nil {
+		if _, err := albwafv2.WAFV2svc.Disassociate(l.lb.current.LoadBalancerArn); err != nil {
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "Error disassociating WAFv2 Web ACL for %s: %s", *l.lb.current.LoadBalancerName, err.Error())
+			return fmt.Errorf("Failed disassociation of ELBV2 WAFv2 Web ACL: %s.", err.Error())
+		}
+	}
+
+	// the protection of a deleted load balancer is left behind otherwise
+	if err := l.loadShieldProtection(ctx); err != nil {
+		return err
+	}
+	if l.options.current.shieldProtectionID != nil {
+		if err := l.unprotect(ctx); err != nil {
+			return err
+		}
+	}
 
 	in := &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: l.lb.current.LoadBalancerArn,
 	}
 
 	if _, err = albelbv2.ELBV2svc.DeleteLoadBalancer(in); err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error deleting %s: %s", *l.lb.current.LoadBalancerName, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "Error deleting %s: %s", *l.lb.current.LoadBalancerName, err.Error())
 		return fmt.Errorf("Failed deletion of ELBV2: %s.", err.Error())
 	}
 	return nil
 }
 
+// loadOptions reads the current WAFv2 Web ACL association and Shield Advanced protection of the load
+// balancer when the controller manages them. They are left unknown, and thereby unmodified, when the
+// controller isn't allowed to read them.
+func (l *LoadBalancer) loadOptions(ctx context.Context) error {
+	// a WAF Classic Web ACL can't be associated while a WAFv2 Web ACL is
+	if !l.options.current.wafv2ACLArnKnown && (l.options.desired.wafv2ACLArn != nil || l.options.desired.webACLId != nil) {
+		arn, err := albwafv2.WAFV2svc.GetWebACLARN(l.lb.current.LoadBalancerArn)
+		switch {
+		case accessDenied(err):
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s WAFv2 Web ACL association is left alone, it can't be read: %s", *l.lb.current.LoadBalancerName, err.Error())
+		case err != nil:
+			return fmt.Errorf("Failed to get associated WAFv2 Web ACL: %s", err.Error())
+		default:
+			l.options.current.wafv2ACLArn = arn
+			l.options.current.wafv2ACLArnKnown = true
+		}
+	}
+
+	if l.options.desired.shieldProtection != nil {
+		return l.loadShieldProtection(ctx)
+	}
+	return nil
+}
+
+// loadShieldProtection reads the current Shield Advanced protection of the load balancer unless it is
+// known already.
+func (l *LoadBalancer) loadShieldProtection(ctx context.Context) error {
+	if l.options.current.shieldProtectionIDKnown {
+		return nil
+	}
+	id, err := albshield.Shieldsvc.GetProtectionID(l.lb.current.LoadBalancerArn)
+	switch {
+	case accessDenied(err):
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Shield Advanced protection is left alone, it can't be read: %s", *l.lb.current.LoadBalancerName, err.Error())
+	case err != nil:
+		return fmt.Errorf("Failed to get Shield Advanced protection: %s", err.Error())
+	default:
+		l.options.current.shieldProtectionID = id
+		l.options.current.shieldProtectionIDKnown = true
+	}
+	return nil
+}
+
+// accessDenied returns whether err rejects a call the controller isn't allowed to make
+func accessDenied(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && (aerr.Code() == "AccessDeniedException" || aerr.Code() == "AccessDenied")
+}
+
+// protect creates the Shield Advanced protection of the load balancer
+func (l *LoadBalancer) protect(ctx context.Context) error {
+	albctx.GetLogger(ctx).Infof("Creating Shield Advanced protection.")
+	id, err := albshield.Shieldsvc.Protect(l.lb.current.LoadBalancerName, l.lb.current.LoadBalancerArn)
+	if err != nil {
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Shield Advanced protection failed: %s", *l.lb.current.LoadBalancerName, err.Error())
+		return fmt.Errorf("Failed creating Shield Advanced protection: %s", err.Error())
+	}
+	l.options.current.shieldProtectionID = id
+	albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%s protected by Shield Advanced", *l.lb.current.LoadBalancerName)
+	return nil
+}
+
+// unprotect deletes the Shield Advanced protection of the load balancer
+func (l *LoadBalancer) unprotect(ctx context.Context) error {
+	albctx.GetLogger(ctx).Infof("Deleting Shield Advanced protection %s.", *l.options.current.shieldProtectionID)
+	if err := albshield.Shieldsvc.Unprotect(l.options.current.shieldProtectionID); err != nil {
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepLoadBalancer.Reason(), "%s Shield Advanced protection removal failed: %s", *l.lb.current.LoadBalancerName, err.Error())
+		return fmt.Errorf("Failed deleting Shield Advanced protection: %s", err.Error())
+	}
+	l.options.current.shieldProtectionID = nil
+	albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%s Shield Advanced protection removed", *l.lb.current.LoadBalancerName)
+	return nil
+}
+
 // needsModification returns if a LB needs to be modified and if it can be modified in place
 // first parameter is true if the LB needs to be changed
 // second parameter true if it can be changed in place
@@ -477,6 +719,12 @@ func (l *LoadBalancer) needsModification(ctx context.Context) (loadBalancerChang
 		if changes&webACLAssociationModified != 0 {
 			albctx.GetLogger(ctx).Debugf("WAF needs to be changed: (%v != %v)", log.Prettify(copts.webACLId), log.Prettify(dopts.webACLId))
 		}
+		if changes&wafv2ACLAssociationModified != 0 {
+			albctx.GetLogger(ctx).Debugf("WAFv2 needs to be changed: (%v != %v)", log.Prettify(copts.wafv2ACLArn), log.Prettify(dopts.wafv2ACLArn))
+		}
+		if changes&shieldProtectionModified != 0 {
+			albctx.GetLogger(ctx).Debugf("Shield Advanced protection needs to be changed: (%v != %v)", log.Prettify(copts.shieldProtectionID), log.Prettify(dopts.shieldProtection))
+		}
 	}
 	return changes, true
 }
@@ -485,6 +733,8 @@ func (l *LoadBalancer) needsModification(ctx context.Context) (loadBalancerChang
 func (l *LoadBalancer) StripDesiredState() {
 	l.lb.desired = nil
 	l.options.desired.webACLId = nil
+	l.options.desired.wafv2ACLArn = nil
+	l.options.desired.shieldProtection = nil
 	if l.listeners != nil {
 		l.listeners.StripDesiredState()
 	}
@@ -511,10 +761,61 @@ func createLBName(namespace string, ingressName string, clustername string) stri
 	return name
 }
 
-// Hostname returns the AWS hostname of the load balancer
-func (l *LoadBalancer) Hostname() *string {
+func createGroupLBName(groupName string, clustername string) string {
+	hasher := md5.New()
+	hasher.Write([]byte(groupName))
+	hash := hex.EncodeToString(hasher.Sum(nil))[:4]
+
+	r, _ := regexp.Compile("[[:^alnum:]]")
+	name := fmt.Sprintf("%s-%s",
+		r.ReplaceAllString(clustername, "-"),
+		groupName,
+	)
+	if len(name) > 26 {
+		name = name[:26]
+	}
+	name = name + "-" + hash
+	return name
+}
+
+// ARN returns the AWS ARN of the load balancer
+func (l *LoadBalancer) ARN() *string {
 	if l.lb.current == nil {
 		return nil
 	}
-	return l.lb.current.DNSName
+	return l.lb.current.LoadBalancerArn
+}
+
+// Hostname returns the AWS hostname of the load balancer. The hostname of a replaced load balancer is
+// returned until its replacement is active.
+func (l *LoadBalancer) Hostname() *string {
+	serving := l.Serving()
+	if serving == nil {
+		return nil
+	}
+	return serving.DNSName
+}
+
+// Serving returns the load balancer in AWS serving the ingress, the replaced load balancer until its
+// replacement is active
+func (l *LoadBalancer) Serving() *elbv2.LoadBalancer {
+	if l.replaced != nil && !replacement.Active(l.lb.current) {
+		return l.replaced.Serving()
+	}
+	return l.lb.current
+}
+
+// AssembleReplacement returns the most recently created of two load balancers of an ingress, replacing the
+// other one. Both are found in AWS when the controller restarted during a replacement.
+func AssembleReplacement(a *LoadBalancer, b *LoadBalancer) *LoadBalancer {
+	if replacement.Newer(b.lb.current, a.lb.current) {
+		a, b = b, a
+	}
+	last := a
+	for last.replaced != nil {
+		last = last.replaced
+	}
+	last.replaced = b
+	last.replacement.Resume()
+	return a
 }
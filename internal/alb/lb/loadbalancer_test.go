@@ -1,10 +1,16 @@
 package lb
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/replacement"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albshield"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafv2"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
@@ -12,7 +18,12 @@ import (
 	api "k8s.io/api/core/v1"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 )
@@ -46,6 +57,7 @@ func TestNewDesiredLoadBalancer(t *testing.T) {
 	ia.LoadBalancer.Scheme = aws.String(elbv2.LoadBalancerSchemeEnumInternal)
 	ia.LoadBalancer.SecurityGroups = types.AWSStringSlice{aws.String(sg1), aws.String(sg2)}
 	ia.LoadBalancer.WebACLId = aws.String("web acl id")
+	ia.LoadBalancer.WAFv2ACLArn = aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/acl/id")
 
 	commonTags := tags.NewTags()
 	commonTags.Tags[tag1Key] = tag1Value
@@ -76,6 +88,284 @@ func TestNewDesiredLoadBalancer(t *testing.T) {
 		t.Errorf("Tag was invalid. Expected: %s | Actual: %s", tag1Value, key1)
 	case *l.options.desired.webACLId != *ia.LoadBalancer.WebACLId:
 		t.Errorf("Web ACL ID was invalid. Expected: %s | Actual: %s", *ia.LoadBalancer.WebACLId, *l.options.desired.webACLId)
+	case *l.options.desired.wafv2ACLArn != *ia.LoadBalancer.WAFv2ACLArn:
+		t.Errorf("WAFv2 Web ACL ARN was invalid. Expected: %s | Actual: %s", *ia.LoadBalancer.WAFv2ACLArn, *l.options.desired.wafv2ACLArn)
+	}
+}
+
+func TestNewDesiredLoadBalancerShieldProtection(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		dflt       bool
+		scheme     string
+		annotation *bool
+		expected   *bool
+	}{
+		{
+			name:   "no default",
+			scheme: elbv2.LoadBalancerSchemeEnumInternetFacing,
+		},
+		{
+			name:     "default for internet-facing load balancers",
+			dflt:     true,
+			scheme:   elbv2.LoadBalancerSchemeEnumInternetFacing,
+			expected: aws.Bool(true),
+		},
+		{
+			name:   "no default for internal load balancers",
+			dflt:   true,
+			scheme: elbv2.LoadBalancerSchemeEnumInternal,
+		},
+		{
+			name:       "annotation overrides the default",
+			dflt:       true,
+			scheme:     elbv2.LoadBalancerSchemeEnumInternetFacing,
+			annotation: aws.Bool(false),
+			expected:   aws.Bool(false),
+		},
+		{
+			name:       "annotation on an internal load balancer",
+			scheme:     elbv2.LoadBalancerSchemeEnumInternal,
+			annotation: aws.Bool(true),
+			expected:   aws.Bool(true),
+		},
+	} {
+		dummyStore := store.NewDummy()
+		cfg := dummyStore.GetConfig()
+		cfg.ALBNamePrefix = clusterName
+		cfg.DefaultShieldAdvancedProtection = tc.dflt
+		dummyStore.SetConfig(cfg)
+
+		ia := dummyStore.GetIngressAnnotationsResponse
+		ia.LoadBalancer.Scheme = aws.String(tc.scheme)
+		ia.LoadBalancer.ShieldAdvancedProtection = tc.annotation
+
+		l, err := NewDesiredLoadBalancer(&NewDesiredLoadBalancerOptions{
+			Ingress:    dummy.NewIngress(),
+			CommonTags: tags.NewTags(),
+			Store:      dummyStore,
+		})
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(l.options.desired.shieldProtection, tc.expected) {
+			t.Errorf("%s: expected Shield Advanced protection %v, got %v", tc.name, aws.BoolValue(tc.expected), aws.BoolValue(l.options.desired.shieldProtection))
+		}
+	}
+}
+
+func TestNewDesiredLoadBalancerReplacement(t *testing.T) {
+	name := createLBName(api.NamespaceDefault, ingressName, clusterName)
+	replacementName := replacement.Name(name, name)
+	if replacementName == name || len(replacementName) != len(name) {
+		t.Fatalf("unexpected replacement name %s of %s", replacementName, name)
+	}
+
+	existing := func(id string, scheme string) *LoadBalancer {
+		return &LoadBalancer{
+			id:   id,
+			tags: tags.NewTags(),
+			lb: lb{current: &elbv2.LoadBalancer{
+				LoadBalancerName: aws.String(id),
+				Scheme:           aws.String(scheme),
+			}},
+		}
+	}
+	inProgress := existing(replacementName, elbv2.LoadBalancerSchemeEnumInternetFacing)
+	inProgress.replaced = existing(name, elbv2.LoadBalancerSchemeEnumInternal)
+
+	for _, tc := range []struct {
+		name         string
+		existing     *LoadBalancer
+		expectedID   string
+		replacesPrev bool
+	}{
+		{
+			name:       "same scheme",
+			existing:   existing(name, elbv2.LoadBalancerSchemeEnumInternal),
+			expectedID: name,
+		},
+		{
+			name:         "scheme change",
+			existing:     existing(name, elbv2.LoadBalancerSchemeEnumInternetFacing),
+			expectedID:   replacementName,
+			replacesPrev: true,
+		},
+		{
+			name:         "scheme change of a replacement",
+			existing:     existing(replacementName, elbv2.LoadBalancerSchemeEnumInternetFacing),
+			expectedID:   name,
+			replacesPrev: true,
+		},
+		{
+			name:       "scheme change during a replacement",
+			existing:   inProgress,
+			expectedID: replacementName,
+		},
+	} {
+		dummyStore := store.NewDummy()
+		cfg := dummyStore.GetConfig()
+		cfg.ALBNamePrefix = clusterName
+		dummyStore.SetConfig(cfg)
+		dummyStore.GetIngressAnnotationsResponse.LoadBalancer.Scheme = aws.String(elbv2.LoadBalancerSchemeEnumInternal)
+
+		l, err := NewDesiredLoadBalancer(&NewDesiredLoadBalancerOptions{
+			ExistingLoadBalancer: tc.existing,
+			Ingress:              dummy.NewIngress(),
+			CommonTags:           tags.NewTags(),
+			Store:                dummyStore,
+		})
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if l.id != tc.expectedID || *l.lb.desired.LoadBalancerName != tc.expectedID || l.sgAssociation.LbID != tc.expectedID {
+			t.Errorf("%s: expected load balancer %s, got %s", tc.name, tc.expectedID, l.id)
+		}
+		if !tc.replacesPrev {
+			if l != tc.existing {
+				t.Errorf("%s: expected the existing load balancer to be kept", tc.name)
+			}
+			continue
+		}
+		if l.replaced != tc.existing || l.lb.current != nil {
+			t.Errorf("%s: expected a new load balancer replacing the existing one", tc.name)
+		}
+		if tc.existing.lb.desired != nil {
+			t.Errorf("%s: expected the desired state of the replaced load balancer to be stripped", tc.name)
+		}
+	}
+}
+
+func TestAssembleReplacement(t *testing.T) {
+	created := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	loadBalancer := func(id string, created time.Time) *LoadBalancer {
+		return &LoadBalancer{
+			id: id,
+			lb: lb{current: &elbv2.LoadBalancer{
+				LoadBalancerName: aws.String(id),
+				CreatedTime:      aws.Time(created),
+				State:            &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumProvisioning)},
+				DNSName:          aws.String(id + ".elb.amazonaws.com"),
+			}},
+		}
+	}
+	previous := loadBalancer("previous", created)
+	replacement := loadBalancer("replacement", created.Add(time.Hour))
+
+	l := AssembleReplacement(previous, replacement)
+	if l != replacement || l.replaced != previous {
+		t.Fatalf("expected %s to replace %s, got %s replacing %v", replacement.id, previous.id, l.id, l.replaced)
+	}
+	if aws.StringValue(l.Hostname()) != "previous.elb.amazonaws.com" {
+		t.Errorf("expected the hostname of the replaced load balancer until the replacement is active, got %s", aws.StringValue(l.Hostname()))
+	}
+	l.lb.current.State.Code = aws.String(elbv2.LoadBalancerStateEnumActive)
+	if aws.StringValue(l.Hostname()) != "replacement.elb.amazonaws.com" {
+		t.Errorf("expected the hostname of the active replacement, got %s", aws.StringValue(l.Hostname()))
+	}
+	// the grace period is counted from the creation of the replacement, it doesn't start over
+	if elapsed, err := l.replacement.Elapsed(context.Background(), l.lb.current, previous.lb.current, time.Hour); err != nil || !elapsed {
+		t.Errorf("expected the grace period of the assembled replacement to be elapsed, got %v, %v", elapsed, err)
+	}
+}
+
+func TestOptionsNeedsModification(t *testing.T) {
+	classicACL := aws.String("web acl id")
+	wafv2ACL := aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/acl/id")
+	otherWAFv2ACL := aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/other/id")
+
+	for _, tc := range []struct {
+		name     string
+		current  opts
+		desired  opts
+		unknown  bool
+		expected loadBalancerChange
+	}{
+		{
+			name: "no Web ACL",
+		},
+		{
+			name:    "unchanged Web ACLs",
+			current: opts{wafv2ACLArn: wafv2ACL},
+			desired: opts{wafv2ACLArn: aws.String(*wafv2ACL)},
+		},
+		{
+			name:     "WAFv2 Web ACL associated",
+			desired:  opts{wafv2ACLArn: wafv2ACL},
+			expected: wafv2ACLAssociationModified,
+		},
+		{
+			name:     "WAFv2 Web ACL replaced",
+			current:  opts{wafv2ACLArn: wafv2ACL},
+			desired:  opts{wafv2ACLArn: otherWAFv2ACL},
+			expected: wafv2ACLAssociationModified,
+		},
+		{
+			name:    "unmanaged WAFv2 Web ACL",
+			current: opts{wafv2ACLArn: wafv2ACL},
+		},
+		{
+			name:     "WAFv2 Web ACL disassociated",
+			current:  opts{wafv2ACLArn: wafv2ACL},
+			desired:  opts{wafv2ACLArn: aws.String("")},
+			expected: wafv2ACLAssociationModified,
+		},
+		{
+			name:    "no WAFv2 Web ACL to disassociate",
+			desired: opts{wafv2ACLArn: aws.String("")},
+		},
+		{
+			name:     "WAFv2 Web ACL replaced by a WAF Classic Web ACL",
+			current:  opts{wafv2ACLArn: wafv2ACL},
+			desired:  opts{webACLId: classicACL},
+			expected: webACLAssociationModified | wafv2ACLAssociationModified,
+		},
+		{
+			name:     "WAF Classic Web ACL replaced by a WAFv2 Web ACL",
+			current:  opts{webACLId: classicACL},
+			desired:  opts{wafv2ACLArn: wafv2ACL},
+			expected: webACLAssociationModified | wafv2ACLAssociationModified,
+		},
+		{
+			name:    "unknown WAFv2 Web ACL",
+			desired: opts{wafv2ACLArn: aws.String("")},
+			current: opts{wafv2ACLArn: wafv2ACL},
+			unknown: true,
+		},
+		{
+			name:    "unmanaged Shield Advanced protection",
+			current: opts{shieldProtectionID: aws.String("protection")},
+		},
+		{
+			name:     "Shield Advanced protection requested",
+			desired:  opts{shieldProtection: aws.Bool(true)},
+			expected: shieldProtectionModified,
+		},
+		{
+			name:    "Shield Advanced protection unchanged",
+			current: opts{shieldProtectionID: aws.String("protection")},
+			desired: opts{shieldProtection: aws.Bool(true)},
+		},
+		{
+			name:     "Shield Advanced protection removed",
+			current:  opts{shieldProtectionID: aws.String("protection")},
+			desired:  opts{shieldProtection: aws.Bool(false)},
+			expected: shieldProtectionModified,
+		},
+		{
+			name:    "unknown Shield Advanced protection",
+			desired: opts{shieldProtection: aws.Bool(true)},
+			unknown: true,
+		},
+	} {
+		tc.current.wafv2ACLArnKnown = !tc.unknown
+		tc.current.shieldProtectionIDKnown = !tc.unknown
+		o := options{current: tc.current, desired: tc.desired}
+		if changes := o.needsModification(); changes != tc.expected {
+			t.Errorf("%s: expected changes %b, got %b", tc.name, tc.expected, changes)
+		}
 	}
 }
 
@@ -101,3 +391,55 @@ func TestNewDesiredLoadBalancer(t *testing.T) {
 // TestLoadBalancerFailsWithInvalidName ensures an error is returned when the LoadBalancerName does
 // match what would have been calculated for the LB from the clustername, ingressname, and
 // namespace
+
+type deniedWAFV2 struct {
+	wafv2iface.WAFV2API
+	calls int
+}
+
+func (d *deniedWAFV2) GetWebACLForResource(*wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error) {
+	d.calls++
+	return nil, awserr.New("AccessDeniedException", "not authorized to perform wafv2:GetWebACLForResource", nil)
+}
+
+type deniedShield struct {
+	shieldiface.ShieldAPI
+	calls int
+}
+
+func (d *deniedShield) DescribeProtection(*shield.DescribeProtectionInput) (*shield.DescribeProtectionOutput, error) {
+	d.calls++
+	return nil, awserr.New("AccessDeniedException", "not authorized to perform shield:DescribeProtection", nil)
+}
+
+func TestLoadOptions(t *testing.T) {
+	waf := &deniedWAFV2{}
+	shd := &deniedShield{}
+	albwafv2.WAFV2svc = &albwafv2.WAFV2{WAFV2API: waf}
+	albshield.Shieldsvc = &albshield.Shield{ShieldAPI: shd}
+
+	l := &LoadBalancer{lb: lb{current: &elbv2.LoadBalancer{
+		LoadBalancerName: aws.String("lb"),
+		LoadBalancerArn:  aws.String("arn"),
+	}}}
+	if err := l.loadOptions(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if waf.calls != 0 || shd.calls != 0 {
+		t.Errorf("expected no call for unmanaged options, got %v WAFv2 and %v Shield calls", waf.calls, shd.calls)
+	}
+
+	l.options.desired = opts{wafv2ACLArn: aws.String(""), shieldProtection: aws.Bool(true)}
+	if err := l.loadOptions(context.Background()); err != nil {
+		t.Fatalf("expected denied calls to be ignored, got %v", err)
+	}
+	if waf.calls != 1 || shd.calls != 1 {
+		t.Errorf("expected a call per managed option, got %v WAFv2 and %v Shield calls", waf.calls, shd.calls)
+	}
+	if l.options.current.wafv2ACLArnKnown || l.options.current.shieldProtectionIDKnown {
+		t.Errorf("expected denied options to be unknown")
+	}
+	if changes := l.options.needsModification(); changes != 0 {
+		t.Errorf("expected unknown options to be left alone, got changes %b", changes)
+	}
+}
@@ -1,14 +1,16 @@
 package lb
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/replacement"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
-	extensions "k8s.io/api/extensions/v1beta1"
 )
 
 // LoadBalancer contains the overarching configuration for the ALB
@@ -22,6 +24,11 @@ type LoadBalancer struct {
 	sgAssociation sg.Association
 	options       options
 
+	// replaced is the load balancer replaced by this one, e.g. after a scheme change. It keeps serving
+	// until this load balancer is active and the grace period of the replacement elapsed.
+	replaced    *LoadBalancer
+	replacement replacement.Replacement
+
 	deleted bool // flag representing the LoadBalancer instance was fully deleted.
 }
 
@@ -37,6 +44,20 @@ type options struct {
 
 type opts struct {
 	webACLId *string
+
+	// wafv2ACLArn is nil when the WAFv2 Web ACL association of the load balancer isn't managed by the
+	// controller, and empty when the load balancer shouldn't be associated with a WAFv2 Web ACL
+	wafv2ACLArn *string
+
+	// shieldProtection is nil when the Shield Advanced protection of the load balancer isn't managed
+	// by the controller, shieldProtectionID is the ID of the current protection
+	shieldProtection   *bool
+	shieldProtectionID *string
+
+	// wafv2ACLArnKnown and shieldProtectionIDKnown are set once the current wafv2ACLArn and
+	// shieldProtectionID were read from AWS, they are only read when the controller manages them
+	wafv2ACLArnKnown        bool
+	shieldProtectionIDKnown bool
 }
 
 func (o options) needsModification() loadBalancerChange {
@@ -46,6 +67,20 @@ func (o options) needsModification() loadBalancerChange {
 		(o.current.webACLId != nil && o.desired.webACLId != nil && *o.current.webACLId != *o.desired.webACLId) {
 		changes |= webACLAssociationModified
 	}
+	switch {
+	case !o.current.wafv2ACLArnKnown:
+	case o.desired.wafv2ACLArn != nil:
+		if aws.StringValue(o.desired.wafv2ACLArn) != aws.StringValue(o.current.wafv2ACLArn) {
+			changes |= wafv2ACLAssociationModified
+		}
+	case o.desired.webACLId != nil && o.current.wafv2ACLArn != nil:
+		// a load balancer is associated with a single Web ACL, the WAF Classic Web ACL replaces the WAFv2 one
+		changes |= wafv2ACLAssociationModified
+	}
+	if o.desired.shieldProtection != nil && o.current.shieldProtectionIDKnown &&
+		*o.desired.shieldProtection != (o.current.shieldProtectionID != nil) {
+		changes |= shieldProtectionModified
+	}
 	return changes
 }
 
@@ -56,11 +91,12 @@ const (
 	schemeModified
 	ipAddressTypeModified
 	webACLAssociationModified
+	wafv2ACLAssociationModified
+	shieldProtectionModified
 )
 
 type ReconcileOptions struct {
 	Store                   store.Storer
-	Ingress                 *extensions.Ingress
 	SgAssociationController sg.AssociationController
 	LbAttributesController  AttributesController
 	TgAttributesController  tg.AttributesController
@@ -16,6 +16,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	api "k8s.io/api/core/v1"
@@ -27,11 +28,16 @@ type NewDesiredListenerOptions struct {
 	ExistingListener *Listener
 	Port             loadbalancer.PortData
 	CertificateArn   *string
-	SslPolicy        *string
-	Ingress          *extensions.Ingress
-	Store            store.Storer
-	TargetGroups     tg.TargetGroups
-	IgnoreHostHeader *bool
+	// AdditionalCertificateArns are served by the listener besides the default CertificateArn
+	AdditionalCertificateArns []*string
+	SslPolicy                 *string
+	Ingress                   *extensions.Ingress
+	Store                     store.Storer
+	TargetGroups              tg.TargetGroups
+	IgnoreHostHeader          *bool
+	// Ingresses lists the members of an ingress group listening on this port, in group order. When
+	// set, rules are generated for every member and Ingress only supplies the default backend.
+	Ingresses []*extensions.Ingress
 }
 
 // NewDesiredListener returns a new listener.Listener based on the parameters provided.
@@ -53,49 +59,101 @@ func NewDesiredListener(o *NewDesiredListenerOptions) (*Listener, error) {
 		l.Protocol = aws.String(elbv2.ProtocolEnumHttps)
 	}
 
-	if o.SslPolicy != nil && o.Port.Scheme == elbv2.ProtocolEnumHttps {
+	if o.SslPolicy != nil && l.Certificates != nil {
 		l.SslPolicy = o.SslPolicy
 	}
 
 	listener := &Listener{
 		ls:             ls{desired: l},
 		defaultBackend: o.Ingress.Spec.Backend,
+		ingress:        o.Ingress,
+	}
+
+	if l.Certificates != nil {
+		for _, arn := range o.AdditionalCertificateArns {
+			if *arn != *o.CertificateArn && !containsString(listener.certificates.desired, arn) {
+				listener.certificates.desired = append(listener.certificates.desired, arn)
+			}
+		}
+	}
+
+	if listener.defaultBackend == nil {
+		listener.defaultBackend = action.Default404Backend()
+	}
+
+	// Requests reaching the default action are authenticated like the requests matching a rule
+	if aws.StringValue(l.Protocol) == elbv2.ProtocolEnumHttps {
+		authAction, err := rs.NewAuthAction(o.Store, o.Ingress, *listener.defaultBackend)
+		if err != nil {
+			return nil, err
+		}
+		if authAction != nil {
+			authAction.Order = aws.Int64(1)
+			l.DefaultActions[0].Order = aws.Int64(2)
+			l.DefaultActions = append([]*elbv2.Action{authAction}, l.DefaultActions...)
+		}
 	}
 
 	if o.ExistingListener != nil {
 		listener.rules = o.ExistingListener.rules
 	}
 
-	var p int
-	for _, rule := range o.Ingress.Spec.Rules {
-		var err error
+	if len(o.Ingresses) == 0 {
+		if err := listener.addRules(o, o.Ingress, o.IgnoreHostHeader); err != nil {
+			return nil, err
+		}
+	}
 
-		listener.rules, p, err = rs.NewDesiredRules(&rs.NewDesiredRulesOptions{
-			Ingress:          o.Ingress,
-			Store:            o.Store,
-			Priority:         p,
-			ListenerRules:    listener.rules,
-			ListenerProtocol: listener.ls.desired.Protocol,
-			ListenerPort:     o.Port,
-			Rule:             &rule,
-			IgnoreHostHeader: o.IgnoreHostHeader,
-			TargetGroups:     o.TargetGroups,
-		})
+	for _, ing := range o.Ingresses {
+		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
 		if err != nil {
 			return nil, err
 		}
+
+		if err := listener.addRules(o, ing, annos.Rule.IgnoreHostHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := listener.rules.AllocatePriorities(); err != nil {
+		return nil, err
 	}
 
 	if o.ExistingListener != nil {
 		o.ExistingListener.ls.desired = listener.ls.desired
+		o.ExistingListener.certificates.desired = listener.certificates.desired
 		o.ExistingListener.rules = listener.rules
 		o.ExistingListener.defaultBackend = listener.defaultBackend
+		o.ExistingListener.ingress = listener.ingress
 		return o.ExistingListener, nil
 	}
 
 	return listener, nil
 }
 
+// addRules appends the rules of ing to the listener, priorities are allocated once every rule is added.
+func (l *Listener) addRules(o *NewDesiredListenerOptions, ing *extensions.Ingress, ignoreHostHeader *bool) error {
+	for i, rule := range ing.Spec.Rules {
+		var err error
+
+		l.rules, err = rs.NewDesiredRules(&rs.NewDesiredRulesOptions{
+			Ingress:          ing,
+			Store:            o.Store,
+			ListenerRules:    l.rules,
+			ListenerProtocol: l.ls.desired.Protocol,
+			ListenerPort:     o.Port,
+			Rule:             &rule,
+			RuleIndex:        i,
+			IgnoreHostHeader: ignoreHostHeader,
+			TargetGroups:     o.TargetGroups,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type NewCurrentListenerOptions struct {
 	Listener     *elbv2.Listener
 	TargetGroups tg.TargetGroups
@@ -117,8 +175,10 @@ func NewCurrentListener(o *NewCurrentListenerOptions) (*Listener, error) {
 	}
 
 	var defaultBackend *extensions.IngressBackend
-	if *o.Listener.DefaultActions[0].Type == elbv2.ActionTypeEnumForward {
-		tgArn := *o.Listener.DefaultActions[0].TargetGroupArn
+	// weighted forward actions have no single target group and are configured by annotation
+	defaultAction := rs.TargetAction(o.Listener.DefaultActions)
+	if *defaultAction.Type == elbv2.ActionTypeEnumForward && defaultAction.TargetGroupArn != nil {
+		tgArn := *defaultAction.TargetGroupArn
 
 		tgTags, ok := resourceTags.TargetGroups[tgArn]
 		if !ok {
@@ -140,32 +200,62 @@ func NewCurrentListener(o *NewCurrentListenerOptions) (*Listener, error) {
 		}
 	}
 
+	var certificateArns []*string
+	if aws.StringValue(o.Listener.Protocol) == elbv2.ProtocolEnumHttps {
+		certificates, err := albelbv2.ELBV2svc.DescribeListenerCertificatesForListener(o.Listener.ListenerArn)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe certificates of listener %s: %s", *o.Listener.ListenerArn, err.Error())
+		}
+		for _, c := range certificates {
+			certificateArns = append(certificateArns, c.CertificateArn)
+		}
+	}
+
 	return &Listener{
 		ls:             ls{current: o.Listener},
+		certificates:   certificates{current: certificateArns},
 		defaultBackend: defaultBackend,
 		rules:          rules,
 	}, nil
 }
 
+// resolveDefaultBackend returns the action routing the requests that match no rule, it follows the
+// authenticate action of the default actions if there is one.
 func (l *Listener) resolveDefaultBackend(rOpts *ReconcileOptions) (*elbv2.Action, error) {
+	target := rs.TargetAction(l.ls.desired.DefaultActions)
 	if action.Use(l.defaultBackend.ServicePort.String()) {
-		annos, err := rOpts.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(rOpts.Ingress))
+		annos, err := rOpts.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(l.ingress))
 		if err != nil {
 			return nil, err
 		}
 
-		return annos.Action.GetAction(l.defaultBackend.ServiceName)
+		a, err := annos.Action.GetAction(l.defaultBackend.ServiceName)
+		if err != nil {
+			return nil, err
+		}
+
+		var lookupErr error
+		out := a.ELBV2Action(func(b extensions.IngressBackend) *string {
+			i := rOpts.TargetGroups.LookupByBackend(l.ingress.Namespace, b)
+			if i < 0 {
+				lookupErr = fmt.Errorf("cannot reconcile listeners, unable to find a target group for default backend %s",
+					b.String())
+				return nil
+			}
+			return rOpts.TargetGroups[i].CurrentARN()
+		})
+		out.Order = target.Order
+		return out, lookupErr
 	}
 
-	i := rOpts.TargetGroups.LookupByBackend(*l.defaultBackend)
+	i := rOpts.TargetGroups.LookupByBackend(l.ingress.Namespace, *l.defaultBackend)
 	if i < 0 {
 		return nil, fmt.Errorf("cannot reconcile listeners, unable to find a target group for default backend %s",
 			l.defaultBackend.String())
 	}
 
-	action := l.ls.desired.DefaultActions[0]
-	action.TargetGroupArn = rOpts.TargetGroups[i].CurrentARN()
-	return action, nil
+	target.TargetGroupArn = rOpts.TargetGroups[i].CurrentARN()
+	return target, nil
 }
 
 // Reconcile compares the current and desired state of this Listener instance. Comparison
@@ -176,7 +266,8 @@ func (l *Listener) Reconcile(ctx context.Context, rOpts *ReconcileOptions) (err
 	// If there is a desired listener, set some of the ARNs which are not available when we assemble the desired state
 	if l.ls.desired != nil {
 		l.ls.desired.LoadBalancerArn = rOpts.LoadBalancerArn
-		l.ls.desired.DefaultActions[0], err = l.resolveDefaultBackend(rOpts)
+		last := len(l.ls.desired.DefaultActions) - 1
+		l.ls.desired.DefaultActions[last], err = l.resolveDefaultBackend(rOpts)
 		if err != nil {
 			return err
 		}
@@ -211,12 +302,18 @@ func (l *Listener) Reconcile(ctx context.Context, rOpts *ReconcileOptions) (err
 		albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%v listener modified", *l.ls.current.Port)
 	}
 
+	if l.ls.current != nil && l.ls.desired != nil {
+		if err := l.reconcileCertificates(ctx); err != nil {
+			return err
+		}
+	}
+
 	if l.ls.current != nil {
 		if rs, err := l.rules.Reconcile(ctx, &rs.ReconcileOptions{
 			ListenerArn:  l.ls.current.ListenerArn,
 			TargetGroups: rOpts.TargetGroups,
 		}); err != nil {
-			return err
+			return errors.NewReconcileError(errors.StepRule, err)
 		} else {
 			l.rules = rs
 		}
@@ -239,11 +336,12 @@ func (l *Listener) create(ctx context.Context, rOpts *ReconcileOptions) error {
 	}
 	o, err := albelbv2.ELBV2svc.CreateListener(in)
 	if err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error creating %v listener: %s", *desired.Port, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepListener.Reason(), "Error creating %v listener: %s", *desired.Port, err.Error())
 		return fmt.Errorf("Failed Listener creation: %s.", err.Error())
 	}
 
 	l.ls.current = o.Listeners[0]
+	action.KeepClientSecrets(l.ls.current.DefaultActions, desired.DefaultActions)
 	return nil
 }
 
@@ -261,18 +359,71 @@ func (l *Listener) modify(ctx context.Context, rOpts *ReconcileOptions) error {
 
 	o, err := albelbv2.ELBV2svc.ModifyListener(in)
 	if err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error modifying %v listener: %s", *desired.Port, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepListener.Reason(), "Error modifying %v listener: %s", *desired.Port, err.Error())
 		return fmt.Errorf("Failed Listener modification: %s", err.Error())
 	}
 	l.ls.current = o.Listeners[0]
+	action.KeepClientSecrets(l.ls.current.DefaultActions, desired.DefaultActions)
 
 	return nil
 }
 
+// reconcileCertificates adds and removes the certificates served by the listener besides its
+// default certificate, which is part of the listener itself and handled by create and modify.
+func (l *Listener) reconcileCertificates(ctx context.Context) error {
+	if aws.StringValue(l.ls.current.Protocol) != elbv2.ProtocolEnumHttps {
+		// certificates are dropped along with the HTTPS protocol
+		l.certificates.current = nil
+		return nil
+	}
+
+	var additions, removals []*elbv2.Certificate
+	for _, arn := range l.certificates.desired {
+		if !containsString(l.certificates.current, arn) {
+			additions = append(additions, &elbv2.Certificate{CertificateArn: arn})
+		}
+	}
+	for _, arn := range l.certificates.current {
+		if !containsString(l.certificates.desired, arn) {
+			removals = append(removals, &elbv2.Certificate{CertificateArn: arn})
+		}
+	}
+
+	if len(additions) > 0 {
+		albctx.GetLogger(ctx).Infof("Adding certificates %v to %v listener.", log.Prettify(additions), *l.ls.current.Port)
+		in := &elbv2.AddListenerCertificatesInput{
+			ListenerArn:  l.ls.current.ListenerArn,
+			Certificates: additions,
+		}
+		if _, err := albelbv2.ELBV2svc.AddListenerCertificates(in); err != nil {
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepListener.Reason(), "Error adding certificates to %v listener: %s", *l.ls.current.Port, err.Error())
+			return fmt.Errorf("Failed Listener certificates addition: %s", err.Error())
+		}
+	}
+
+	if len(removals) > 0 {
+		albctx.GetLogger(ctx).Infof("Removing certificates %v from %v listener.", log.Prettify(removals), *l.ls.current.Port)
+		in := &elbv2.RemoveListenerCertificatesInput{
+			ListenerArn:  l.ls.current.ListenerArn,
+			Certificates: removals,
+		}
+		if _, err := albelbv2.ELBV2svc.RemoveListenerCertificates(in); err != nil {
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepListener.Reason(), "Error removing certificates from %v listener: %s", *l.ls.current.Port, err.Error())
+			return fmt.Errorf("Failed Listener certificates removal: %s", err.Error())
+		}
+	}
+
+	if len(additions) > 0 || len(removals) > 0 {
+		albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%v listener certificates modified", *l.ls.current.Port)
+	}
+	l.certificates.current = l.certificates.desired
+	return nil
+}
+
 // delete removes a Listener from an existing ALB in AWS.
 func (l *Listener) delete(ctx context.Context, rOpts *ReconcileOptions) error {
 	if err := albelbv2.ELBV2svc.RemoveListener(l.ls.current.ListenerArn); err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error deleting %v listener: %s", *l.ls.current.Port, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepListener.Reason(), "Error deleting %v listener: %s", *l.ls.current.Port, err.Error())
 		return fmt.Errorf("Failed Listener deletion. ARN: %s: %s", *l.ls.current.ListenerArn, err.Error())
 	}
 
@@ -301,7 +452,7 @@ func (l *Listener) needsModification(ctx context.Context, rOpts *ReconcileOption
 	case !util.DeepEqual(lsc.Certificates, lsd.Certificates):
 		albctx.GetLogger(ctx).Debugf("Certificates needs to be changed (%v != %v)", log.Prettify(lsc.Certificates), log.Prettify(lsd.Certificates))
 		return true
-	case !util.DeepEqual(lsc.DefaultActions, lsd.DefaultActions):
+	case !action.ActionsEqual(lsc.DefaultActions, lsd.DefaultActions):
 		albctx.GetLogger(ctx).Debugf("DefaultActions needs to be changed (%v != %v)", log.Prettify(lsc.DefaultActions), log.Prettify(lsd.DefaultActions))
 		return true
 	case !util.DeepEqual(lsc.SslPolicy, lsd.SslPolicy):
@@ -314,12 +465,14 @@ func (l *Listener) needsModification(ctx context.Context, rOpts *ReconcileOption
 // StripDesiredState removes the desired state from the listener.
 func (l *Listener) StripDesiredState() {
 	l.ls.desired = nil
+	l.certificates.desired = nil
 	l.rules.StripDesiredState()
 }
 
 // stripCurrentState removes the current state from the listener.
 func (l *Listener) stripCurrentState() {
 	l.ls.current = nil
+	l.certificates.current = nil
 	l.rules.StripCurrentState()
 }
 
@@ -327,12 +480,21 @@ func (l *Listener) GetRules() rs.Rules {
 	return l.rules
 }
 
-func (l *Listener) DefaultActionArn() *string {
-	if l.ls.current == nil || len(l.ls.current.DefaultActions) < 1 || l.ls.current.DefaultActions[0].Type == nil {
+func (l *Listener) DefaultActionArns() []*string {
+	if l.ls.current == nil || len(l.ls.current.DefaultActions) < 1 {
 		return nil
 	}
-	if *l.ls.current.DefaultActions[0].Type == elbv2.ActionTypeEnumForward {
-		return l.ls.current.DefaultActions[0].TargetGroupArn
+	if a := rs.TargetAction(l.ls.current.DefaultActions); a != nil && aws.StringValue(a.Type) == elbv2.ActionTypeEnumForward {
+		return action.TargetGroupArns(a)
 	}
 	return nil
 }
+
+func containsString(s []*string, v *string) bool {
+	for _, e := range s {
+		if aws.StringValue(e) == aws.StringValue(v) {
+			return true
+		}
+	}
+	return false
+}
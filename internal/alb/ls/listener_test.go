@@ -2,6 +2,7 @@ package ls
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
@@ -16,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/auth"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
@@ -96,6 +98,7 @@ func TestNewHTTPListener(t *testing.T) {
 	o := &NewDesiredListenerOptions{
 		Port:         loadbalancer.PortData{desiredPort, elbv2.ProtocolEnumHttp},
 		Ingress:      ing,
+		Store:        store.NewDummy(),
 		TargetGroups: tgs,
 	}
 
@@ -128,11 +131,13 @@ func TestNewHTTPSListener(t *testing.T) {
 	})
 
 	o := &NewDesiredListenerOptions{
-		Ingress:        ing,
-		Port:           loadbalancer.PortData{desiredPort, "HTTPS"},
-		CertificateArn: desiredCertArn,
-		SslPolicy:      desiredSslPolicy,
-		TargetGroups:   tgs,
+		Ingress:                   ing,
+		Port:                      loadbalancer.PortData{desiredPort, "HTTPS"},
+		CertificateArn:            desiredCertArn,
+		AdditionalCertificateArns: []*string{aws.String("def456"), desiredCertArn, aws.String("def456")},
+		SslPolicy:                 desiredSslPolicy,
+		Store:                     store.NewDummy(),
+		TargetGroups:              tgs,
 	}
 
 	l, _ := NewDesiredListener(o)
@@ -154,6 +159,62 @@ func TestNewHTTPSListener(t *testing.T) {
 	case *l.ls.desired.SslPolicy != *desiredSslPolicy:
 		t.Errorf("Invalid certificate SSL Policy. Actual: %s | Expected: %s",
 			*l.ls.desired.SslPolicy, *desiredSslPolicy)
+	case len(l.ls.desired.Certificates) != 1:
+		t.Errorf("Expected only the default certificate on the listener, got %v", len(l.ls.desired.Certificates))
+	case len(l.certificates.desired) != 1 || *l.certificates.desired[0] != "def456":
+		t.Errorf("Invalid additional certificates. Actual: %v | Expected: [def456]",
+			aws.StringValueSlice(l.certificates.desired))
+	}
+}
+
+// TestNewHTTPSListenerWithAuthentication assures requests reaching the default backend of an
+// HTTPS listener are authenticated.
+func TestNewHTTPSListenerWithAuthentication(t *testing.T) {
+	setup()
+	s := store.NewDummy()
+	s.GetServiceAnnotationsResponse.Auth = &auth.Config{
+		Type: aws.String(auth.TypeOIDC),
+		IdpOidc: &auth.IdpOidc{
+			Issuer:                "https://idp.example.com",
+			AuthorizationEndpoint: "https://idp.example.com/authorize",
+			TokenEndpoint:         "https://idp.example.com/token",
+			UserInfoEndpoint:      "https://idp.example.com/userinfo",
+			SecretName:            "oidc-secret",
+		},
+	}
+	ing := &extensions.Ingress{
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(newPort)},
+		},
+	}
+
+	l, err := NewDesiredListener(&NewDesiredListenerOptions{
+		Ingress:        ing,
+		Port:           loadbalancer.PortData{Port: 443, Scheme: elbv2.ProtocolEnumHttps},
+		CertificateArn: aws.String("abc123"),
+		Store:          s,
+		TargetGroups:   rOpts1.TargetGroups,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	albelbv2.ELBV2svc.SetField("CreateListenerOutput", &elbv2.CreateListenerOutput{
+		Listeners: []*elbv2.Listener{{ListenerArn: aws.String("listener arn"), Port: aws.Int64(443), Protocol: aws.String(elbv2.ProtocolEnumHttps)}},
+	})
+	if err := l.Reconcile(context.Background(), rOpts1); err != nil {
+		t.Fatal(err)
+	}
+
+	actions := l.ls.desired.DefaultActions
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 default actions, got %v", len(actions))
+	}
+	if *actions[0].Type != elbv2.ActionTypeEnumAuthenticateOidc || *actions[0].Order != 1 {
+		t.Errorf("expected the authenticate action first, got %v", actions[0])
+	}
+	if *actions[1].Type != elbv2.ActionTypeEnumForward || *actions[1].Order != 2 || aws.StringValue(actions[1].TargetGroupArn) != newTg {
+		t.Errorf("expected the forward action to the default backend second, got %v", actions[1])
 	}
 }
 
@@ -166,6 +227,7 @@ func TestReconcileCreate(t *testing.T) {
 	l := Listener{
 		ls:             ls{desired: mockList1},
 		defaultBackend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(newPort)},
+		ingress:        &extensions.Ingress{},
 	}
 
 	m := mockList1
@@ -215,6 +277,7 @@ func TestReconcileModifyPortChange(t *testing.T) {
 	listenerArn := "listener arn"
 	l := Listener{
 		defaultBackend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(newPort)},
+		ingress:        &extensions.Ingress{},
 		ls: ls{
 			desired: mockList2,
 			current: mockList1,
@@ -243,6 +306,7 @@ func TestReconcileModifyNoChange(t *testing.T) {
 	setup()
 	l := Listener{
 		defaultBackend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(newPort)},
+		ingress:        &extensions.Ingress{},
 		ls: ls{
 			desired: mockList2,
 			current: mockList1,
@@ -257,6 +321,43 @@ func TestReconcileModifyNoChange(t *testing.T) {
 	}
 }
 
+// TestReconcileCertificates calls Reconcile on a mock HTTPS Listener whose additional certificates
+// differ, and assures they are reconciled without modifying the listener itself.
+func TestReconcileCertificates(t *testing.T) {
+	setup()
+
+	mockList3.ListenerArn = aws.String("listener arn")
+	l := Listener{
+		defaultBackend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(newPort)},
+		ingress:        &extensions.Ingress{},
+		ls: ls{
+			desired: mockList3,
+			current: mockList3,
+		},
+		certificates: certificates{
+			current: []*string{aws.String("old")},
+			desired: []*string{aws.String("new")},
+		},
+	}
+
+	albelbv2.ELBV2svc.SetField("AddListenerCertificatesError", fmt.Errorf("certificate not found"))
+	if err := l.Reconcile(context.Background(), rOpts1); err == nil {
+		t.Error("Expected an error when adding certificates fails")
+	}
+	if *l.certificates.current[0] != "old" {
+		t.Errorf("Current certificates changed after a failed addition: %v", aws.StringValueSlice(l.certificates.current))
+	}
+
+	albelbv2.ELBV2svc.SetField("AddListenerCertificatesError", nil)
+	if err := l.Reconcile(context.Background(), rOpts1); err != nil {
+		t.Error(err)
+	}
+	if !types.DeepEqual(l.certificates.current, l.certificates.desired) {
+		t.Errorf("After reconciliation, current certificates %v did not match desired %v",
+			aws.StringValueSlice(l.certificates.current), aws.StringValueSlice(l.certificates.desired))
+	}
+}
+
 // TestModificationNeeds sends different listeners through to see if a modification is needed.
 func TestModificationNeeds(t *testing.T) {
 	setup()
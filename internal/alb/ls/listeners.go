@@ -2,12 +2,16 @@ package ls
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 
 	extensions "k8s.io/api/extensions/v1beta1"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albacm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
@@ -72,7 +76,10 @@ func NewCurrentListeners(o *NewCurrentListenersOptions) (Listeners, error) {
 }
 
 type NewDesiredListenersOptions struct {
-	Ingress           *extensions.Ingress
+	Ingress *extensions.Ingress
+	// Ingresses lists the members of an ingress group sharing the load balancer, in group order.
+	// When set, listeners are created for the ports of every member and Ingress is ignored.
+	Ingresses         []*extensions.Ingress
 	Store             store.Storer
 	ExistingListeners Listeners
 	TargetGroups      tg.TargetGroups
@@ -82,13 +89,13 @@ type NewDesiredListenersOptions struct {
 func NewDesiredListeners(o *NewDesiredListenersOptions) (Listeners, error) {
 	var output Listeners
 
-	annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
+	ports, err := o.listenerPorts()
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate a listener for each port in the annotations
-	for _, port := range annos.LoadBalancer.Ports {
+	for _, port := range ports {
 		// Track down the existing listener for this port
 		var thisListener *Listener
 		for _, l := range o.ExistingListeners {
@@ -102,15 +109,22 @@ func NewDesiredListeners(o *NewDesiredListenersOptions) (Listeners, error) {
 			}
 		}
 
+		certificateArn, additionalCertificateArns, err := port.certificateArns()
+		if err != nil {
+			return nil, err
+		}
+
 		newListener, err := NewDesiredListener(&NewDesiredListenerOptions{
-			Port:             port,
-			CertificateArn:   annos.Listener.CertificateArn,
-			SslPolicy:        annos.Listener.SslPolicy,
-			Ingress:          o.Ingress,
-			Store:            o.Store,
-			TargetGroups:     o.TargetGroups,
-			IgnoreHostHeader: annos.Rule.IgnoreHostHeader,
-			ExistingListener: thisListener,
+			Port:                      port.PortData,
+			CertificateArn:            certificateArn,
+			AdditionalCertificateArns: additionalCertificateArns,
+			SslPolicy:                 port.annotations.Listener.SslPolicy,
+			Ingress:                   port.defaultBackendIngress(),
+			Ingresses:                 port.members,
+			Store:                     o.Store,
+			TargetGroups:              o.TargetGroups,
+			IgnoreHostHeader:          port.annotations.Rule.IgnoreHostHeader,
+			ExistingListener:          thisListener,
 		})
 		if err != nil {
 			return nil, err
@@ -123,7 +137,7 @@ func NewDesiredListeners(o *NewDesiredListenersOptions) (Listeners, error) {
 	// representing it needs to be deleted
 	for _, l := range o.ExistingListeners {
 		exists := false
-		for _, port := range annos.LoadBalancer.Ports {
+		for _, port := range ports {
 			if l.ls.current == nil {
 				continue
 			}
@@ -140,3 +154,108 @@ func NewDesiredListeners(o *NewDesiredListenersOptions) (Listeners, error) {
 
 	return output, nil
 }
+
+// listenerPort is a port the load balancer listens on, along with the Ingress whose annotations
+// configure the listener and, for ingress groups, every member listening on the port.
+type listenerPort struct {
+	loadbalancer.PortData
+	ingress     *extensions.Ingress
+	annotations *annotations.Ingress
+	members     []*extensions.Ingress
+}
+
+// defaultBackendIngress returns the first Ingress listening on the port that defines a default backend.
+func (p *listenerPort) defaultBackendIngress() *extensions.Ingress {
+	for _, ing := range p.members {
+		if ing.Spec.Backend != nil {
+			return ing
+		}
+	}
+	return p.ingress
+}
+
+// certificateArns returns the default and additional certificates of the listener on the port.
+// Unless they are set by the certificate-arn annotation, HTTPS listeners use the issued ACM
+// certificates matching the hosts of the ingresses listening on the port.
+func (p *listenerPort) certificateArns() (*string, []*string, error) {
+	if p.annotations.Listener.CertificateArn != nil || p.Scheme != elbv2.ProtocolEnumHttps {
+		return p.annotations.Listener.CertificateArn, p.annotations.Listener.AdditionalCertificateArns, nil
+	}
+
+	ingresses := p.members
+	if len(ingresses) == 0 {
+		ingresses = []*extensions.Ingress{p.ingress}
+	}
+	hosts := ingressHosts(ingresses)
+	if len(hosts) == 0 {
+		return nil, nil, nil
+	}
+
+	arns, err := albacm.ACMsvc.FindCertificateArns(hosts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover certificates for hosts %v: %s", hosts, err.Error())
+	}
+	if len(arns) == 0 {
+		return nil, nil, fmt.Errorf("no certificate-arn annotation and no issued ACM certificate matches hosts %v", hosts)
+	}
+	return arns[0], arns[1:], nil
+}
+
+// ingressHosts returns the hosts of the TLS section and rules of the ingresses, without duplicates.
+func ingressHosts(ingresses []*extensions.Ingress) []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	for _, ing := range ingresses {
+		for _, tls := range ing.Spec.TLS {
+			for _, host := range tls.Hosts {
+				add(host)
+			}
+		}
+		for _, rule := range ing.Spec.Rules {
+			add(rule.Host)
+		}
+	}
+	return hosts
+}
+
+// listenerPorts returns the ports to create listeners for. Within an ingress group the listener
+// settings for a port are taken from the first member, in group order, listening on it.
+func (o *NewDesiredListenersOptions) listenerPorts() ([]*listenerPort, error) {
+	var ports []*listenerPort
+
+	if len(o.Ingresses) == 0 {
+		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
+		if err != nil {
+			return nil, err
+		}
+		for _, port := range annos.LoadBalancer.Ports {
+			ports = append(ports, &listenerPort{PortData: port, ingress: o.Ingress, annotations: annos})
+		}
+		return ports, nil
+	}
+
+	byPort := make(map[int64]*listenerPort)
+	for _, ing := range o.Ingresses {
+		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
+		if err != nil {
+			return nil, err
+		}
+		for _, port := range annos.LoadBalancer.Ports {
+			p, ok := byPort[port.Port]
+			if !ok {
+				p = &listenerPort{PortData: port, ingress: ing, annotations: annos}
+				byPort[port.Port] = p
+				ports = append(ports, p)
+			}
+			p.members = append(p.members, ing)
+		}
+	}
+	return ports, nil
+}
@@ -2,6 +2,7 @@ package ls
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
@@ -13,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	extensions "k8s.io/api/extensions/v1beta1"
 )
 
 var (
@@ -133,3 +135,53 @@ func TestMultipleListeners(t *testing.T) {
 		}
 	}
 }
+
+func TestIngressGroupListeners(t *testing.T) {
+	ing1 := dummy.NewIngress()
+	ing1.Spec.Backend = nil
+	ing2 := dummy.NewIngress()
+	ing2.Namespace = "other"
+	dummyStore := store.NewDummy()
+	dummyStore.GetIngressAnnotationsResponse.LoadBalancer.Ports = []loadbalancer.PortData{{Port: ports[0], Scheme: elbv2.ProtocolEnumHttp}}
+
+	ingresses := []*extensions.Ingress{ing1, ing2}
+	tgs, _ := tg.NewDesiredTargetGroups(&tg.NewDesiredTargetGroupsOptions{
+		Ingresses:      ingresses,
+		LoadBalancerID: "lbid",
+		Store:          dummyStore,
+		CommonTags:     tags.NewTags(),
+	})
+
+	ls, err := NewDesiredListeners(&NewDesiredListenersOptions{
+		Ingresses:    ingresses,
+		Store:        dummyStore,
+		TargetGroups: tgs,
+	})
+	if err != nil {
+		t.Errorf("Failed to create listeners. Error: %s", err.Error())
+	}
+
+	expRules := len(ing1.Spec.Rules) + len(ing2.Spec.Rules)
+	switch {
+	case len(ls) != 1:
+		t.Errorf("Created %d listeners, should have been %d", len(ls), 1)
+	case len(ls[0].rules) != expRules:
+		t.Errorf("Quantity of rules attached to listener is invalid. Was %d, expected %d.", len(ls[0].rules), expRules)
+	case ls[0].ingress != ing2:
+		t.Errorf("Default backend should be taken from the first member defining one")
+	}
+}
+
+func TestIngressHosts(t *testing.T) {
+	a := dummy.NewIngress()
+	a.Spec.TLS = []extensions.IngressTLS{{Hosts: []string{"secure.example.com", "www.example.com"}}}
+	a.Spec.Rules = []extensions.IngressRule{{Host: "www.example.com"}, {Host: ""}}
+	b := dummy.NewIngress()
+	b.Spec.Rules = []extensions.IngressRule{{Host: "api.example.com"}, {Host: "secure.example.com"}}
+
+	hosts := ingressHosts([]*extensions.Ingress{a, b})
+	expected := []string{"secure.example.com", "www.example.com", "api.example.com"}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected hosts %v, got %v", expected, hosts)
+	}
+}
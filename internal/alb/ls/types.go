@@ -14,8 +14,10 @@ type Listeners []*Listener
 // Listener contains the relevant ID, Rules, and current/desired Listeners
 type Listener struct {
 	ls             ls
+	certificates   certificates
 	rules          rs.Rules
 	defaultBackend *extensions.IngressBackend
+	ingress        *extensions.Ingress // the Ingress providing defaultBackend
 	deleted        bool
 }
 
@@ -24,9 +26,14 @@ type ls struct {
 	desired *elbv2.Listener
 }
 
+// certificates are the ARNs of the certificates of a listener besides its default certificate
+type certificates struct {
+	current []*string
+	desired []*string
+}
+
 type ReconcileOptions struct {
 	Store           store.Storer
-	Ingress         *extensions.Ingress
 	LoadBalancerArn *string
 	TargetGroups    tg.TargetGroups
 }
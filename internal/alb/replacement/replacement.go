@@ -0,0 +1,109 @@
+package replacement
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	api "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+)
+
+// Replacement is the state of a load balancer replacing another one, e.g. after a scheme change which
+// can't be applied in place. The replaced load balancer keeps serving until the load balancer replacing
+// it is active and the grace period elapsed.
+type Replacement struct {
+	until   time.Time // end of the grace period, zero until the load balancer replacing the other one is active
+	resumed bool      // flag representing the replacement was found in AWS, see Resume
+}
+
+// Resume restores a replacement found in AWS, e.g. after a restart of the controller or when the load
+// balancers are assembled again from AWS. The grace period is counted from the creation of the load
+// balancer replacing the other one, so it doesn't start over.
+func (r *Replacement) Resume() {
+	r.resumed = true
+}
+
+// Elapsed returns true once current, the load balancer replacing the replaced load balancer, is active and
+// the grace period elapsed. The state of current is refreshed until it is active.
+func (r *Replacement) Elapsed(ctx context.Context, current *elbv2.LoadBalancer, replaced *elbv2.LoadBalancer, gracePeriod time.Duration) (bool, error) {
+	if current == nil {
+		return false, nil
+	}
+	if !Active(current) {
+		if err := RefreshState(current); err != nil {
+			return false, err
+		}
+		if !Active(current) {
+			albctx.GetLogger(ctx).Infof("Waiting for %s to be active, %s serves until then.",
+				*current.LoadBalancerName, *replaced.LoadBalancerName)
+			return false, nil
+		}
+	}
+	if r.until.IsZero() && r.resumed {
+		r.until = createdTime(current).Add(gracePeriod)
+		albctx.GetLogger(ctx).Infof("%s replaces %s, which is deleted at %s.",
+			*current.LoadBalancerName, *replaced.LoadBalancerName, r.until.Format(time.RFC3339))
+	}
+	if r.until.IsZero() {
+		r.until = time.Now().Add(gracePeriod)
+		albctx.GetEventf(ctx)(api.EventTypeNormal, "REPLACE", "%s replaces %s, which is deleted at %s",
+			*current.LoadBalancerName, *replaced.LoadBalancerName, r.until.Format(time.RFC3339))
+	}
+	return !time.Now().Before(r.until), nil
+}
+
+// Reset clears the state once the replaced load balancer was deleted
+func (r *Replacement) Reset() {
+	*r = Replacement{}
+}
+
+// RefreshState describes the load balancer to update its state, e.g. once it was provisioned
+func RefreshState(current *elbv2.LoadBalancer) error {
+	lb, err := albelbv2.ELBV2svc.GetLoadBalancerByArn(aws.StringValue(current.LoadBalancerArn))
+	if err != nil {
+		return fmt.Errorf("Failed describing load balancer %s: %s", *current.LoadBalancerName, err.Error())
+	}
+	if lb != nil {
+		current.State = lb.State
+	}
+	return nil
+}
+
+// Active returns true when the load balancer is provisioned and routes traffic
+func Active(current *elbv2.LoadBalancer) bool {
+	return current != nil && current.State != nil &&
+		aws.StringValue(current.State.Code) == elbv2.LoadBalancerStateEnumActive
+}
+
+// Newer returns true when the load balancer a was created after b. Both are found in AWS when the
+// controller restarted during a replacement, the most recently created one replaces the other one.
+func Newer(a *elbv2.LoadBalancer, b *elbv2.LoadBalancer) bool {
+	return createdTime(a).After(createdTime(b))
+}
+
+func createdTime(lb *elbv2.LoadBalancer) time.Time {
+	if lb == nil {
+		return time.Time{}
+	}
+	return aws.TimeValue(lb.CreatedTime)
+}
+
+// Name returns the name of the load balancer replacing the load balancer named current, name being the
+// name of the load balancers of the ingress or Service. The replacements alternate between name and name
+// with another hash, so a load balancer and its replacement never share their name.
+func Name(current string, name string) string {
+	if current != name {
+		return name
+	}
+	hasher := md5.New()
+	hasher.Write([]byte(name))
+	hash := hex.EncodeToString(hasher.Sum(nil))[:4]
+	return name[:len(name)-4] + hash
+}
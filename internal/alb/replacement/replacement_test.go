@@ -0,0 +1,119 @@
+package replacement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+func testContext(events *[]string) context.Context {
+	ctx := albctx.SetLogger(context.Background(), log.New("test"))
+	return albctx.SetEventf(ctx, func(_ string, reason string, _ string, _ ...interface{}) {
+		*events = append(*events, reason)
+	})
+}
+
+func loadBalancer(name string, state string) *elbv2.LoadBalancer {
+	return &elbv2.LoadBalancer{
+		LoadBalancerArn:  aws.String(name + "-arn"),
+		LoadBalancerName: aws.String(name),
+		State:            &elbv2.LoadBalancerState{Code: aws.String(state)},
+	}
+}
+
+func TestName(t *testing.T) {
+	name := "cluster-default-ingress-1234"
+	replacementName := Name(name, name)
+	if replacementName == name || len(replacementName) != len(name) {
+		t.Fatalf("unexpected replacement name %s of %s", replacementName, name)
+	}
+	if n := Name(replacementName, name); n != name {
+		t.Errorf("expected the replacement of %s to be named %s, got %s", replacementName, name, n)
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	var events []string
+	ctx := testContext(&events)
+	current := loadBalancer("new", elbv2.LoadBalancerStateEnumProvisioning)
+	replaced := loadBalancer("old", elbv2.LoadBalancerStateEnumActive)
+
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("GetLoadBalancerByArn", "new-arn").Return(loadBalancer("new", elbv2.LoadBalancerStateEnumProvisioning), nil).Once()
+	albelbv2.ELBV2svc = elbv2svc
+
+	r := &Replacement{}
+	if elapsed, err := r.Elapsed(ctx, nil, replaced, 0); err != nil || elapsed {
+		t.Errorf("expected the replacement to wait for the creation of the load balancer, got %v, %v", elapsed, err)
+	}
+	if elapsed, err := r.Elapsed(ctx, current, replaced, 0); err != nil || elapsed {
+		t.Errorf("expected the replacement to wait for the load balancer to be active, got %v, %v", elapsed, err)
+	}
+	if len(events) != 0 {
+		t.Errorf("unexpected events %v", events)
+	}
+
+	elbv2svc.On("GetLoadBalancerByArn", "new-arn").Return(loadBalancer("new", elbv2.LoadBalancerStateEnumActive), nil).Once()
+	if elapsed, err := r.Elapsed(ctx, current, replaced, time.Hour); err != nil || elapsed {
+		t.Errorf("expected the grace period to start once the load balancer is active, got %v, %v", elapsed, err)
+	}
+	if !Active(current) || len(events) != 1 || events[0] != "REPLACE" {
+		t.Errorf("expected a single REPLACE event once the load balancer is active, got %v", events)
+	}
+
+	// the grace period doesn't start over
+	if elapsed, _ := r.Elapsed(ctx, current, replaced, 0); elapsed || len(events) != 1 {
+		t.Errorf("expected the grace period to be kept, got %v, %v", elapsed, events)
+	}
+	r.Reset()
+	if elapsed, _ := r.Elapsed(ctx, current, replaced, 0); !elapsed {
+		t.Errorf("expected the grace period to elapse")
+	}
+	elbv2svc.AssertExpectations(t)
+}
+
+func TestNewer(t *testing.T) {
+	older := &elbv2.LoadBalancer{CreatedTime: aws.Time(time.Now().Add(-time.Hour))}
+	newer := &elbv2.LoadBalancer{CreatedTime: aws.Time(time.Now())}
+	if !Newer(newer, older) || Newer(older, newer) {
+		t.Errorf("expected the most recently created load balancer to be newer")
+	}
+	if Newer(nil, older) || !Newer(older, nil) {
+		t.Errorf("expected a load balancer without creation time to be older")
+	}
+}
+
+func TestElapsedResumed(t *testing.T) {
+	var events []string
+	ctx := testContext(&events)
+	replaced := loadBalancer("old", elbv2.LoadBalancerStateEnumActive)
+
+	for _, tc := range []struct {
+		name     string
+		created  time.Time
+		expected bool
+	}{
+		{name: "grace period in progress", created: time.Now().Add(-time.Minute), expected: false},
+		{name: "grace period elapsed", created: time.Now().Add(-time.Hour), expected: true},
+	} {
+		current := loadBalancer("new", elbv2.LoadBalancerStateEnumActive)
+		current.CreatedTime = aws.Time(tc.created)
+
+		r := &Replacement{}
+		r.Resume()
+		if elapsed, err := r.Elapsed(ctx, current, replaced, 5*time.Minute); err != nil || elapsed != tc.expected {
+			t.Errorf("%s: expected the grace period to be counted from the creation of the load balancer, got %v, %v", tc.name, elapsed, err)
+		}
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no REPLACE event for a resumed replacement, got %v", events)
+	}
+}
@@ -3,11 +3,17 @@ package rs
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -20,7 +26,6 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
-	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 )
 
 type NewDesiredRuleOptions struct {
@@ -30,8 +35,10 @@ type NewDesiredRuleOptions struct {
 	Hostname         string
 	IgnoreHostHeader *bool
 	Path             string
+	PathType         string
 	SvcName          string
 	SvcPort          intstr.IntOrString
+	ListenerProtocol *string
 }
 
 // NewDesiredRule returns an rule.Rule based on the provided parameters.
@@ -47,19 +54,40 @@ func NewDesiredRule(o *NewDesiredRuleOptions) (*Rule, error) {
 		},
 	}
 
-	// Requested an `use-annotation` type rule
-	if o.Ingress != nil && action.Use(o.SvcPort.String()) {
-		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
+	var annos *annotations.Ingress
+	if o.Ingress != nil {
+		var err error
+		annos, err = o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
 		if err != nil {
 			return nil, err
 		}
+	}
 
+	// Requested an `use-annotation` type rule
+	var weighted *action.Action
+	if o.Ingress != nil && action.Use(o.SvcPort.String()) {
 		actionConfig, err := annos.Action.GetAction(o.SvcName)
 		if err != nil {
 			return nil, err
 		}
 
-		r.Actions[0] = actionConfig
+		r.Actions[0] = actionConfig.ELBV2Action(nil)
+		if actionConfig.ForwardConfig != nil {
+			weighted = actionConfig
+		}
+	}
+
+	// Authentication is only supported by HTTPS listeners
+	if annos != nil && aws.StringValue(o.ListenerProtocol) == elbv2.ProtocolEnumHttps {
+		authAction, err := newAuthAction(o, annos)
+		if err != nil {
+			return nil, err
+		}
+		if authAction != nil {
+			authAction.Order = aws.Int64(1)
+			r.Actions[0].Order = aws.Int64(2)
+			r.Actions = append([]*elbv2.Action{authAction}, r.Actions...)
+		}
 	}
 
 	if !*r.IsDefault {
@@ -71,19 +99,93 @@ func NewDesiredRule(o *NewDesiredRuleOptions) (*Rule, error) {
 		}
 
 		if o.Path != "" {
-			r.Conditions = append(r.Conditions, &elbv2.RuleCondition{
-				Field:  aws.String("path-pattern"),
-				Values: []*string{aws.String(o.Path)},
-			})
+			patterns, err := pathPatterns(o.Path, o.PathType)
+			if err != nil {
+				return nil, err
+			}
+			c := &elbv2.RuleCondition{Field: aws.String("path-pattern")}
+			if len(patterns) == 1 {
+				c.Values = aws.StringSlice(patterns)
+			} else {
+				// only a single value is allowed in Values
+				c.PathPatternConfig = &elbv2.PathPatternConditionConfig{Values: aws.StringSlice(patterns)}
+			}
+			r.Conditions = append(r.Conditions, c)
+		}
+
+		if annos != nil {
+			for _, c := range annos.Conditions.GetConditions(o.SvcName) {
+				for _, existing := range r.Conditions {
+					if *existing.Field == *c.Field {
+						return nil, fmt.Errorf("conditions.%v configures a %v condition which is already set by the ingress rule", o.SvcName, *c.Field)
+					}
+				}
+			}
+			r.Conditions = append(r.Conditions, annos.Conditions.GetConditions(o.SvcName)...)
 		}
 	}
 
+	var namespace string
+	if o.Ingress != nil {
+		namespace = o.Ingress.Namespace
+	}
+
 	return &Rule{
-		svc:    svc{desired: service{name: o.SvcName, port: o.SvcPort}},
+		svc:    svc{desired: service{namespace: namespace, name: o.SvcName, port: o.SvcPort}},
 		rs:     rs{desired: r},
+		action: weighted,
 	}, nil
 }
 
+// NewAuthAction returns the authenticate action configured for a backend of the ingress, or nil when
+// requests to the backend aren't authenticated.
+func NewAuthAction(s store.Storer, ing *extensions.Ingress, backend extensions.IngressBackend) (*elbv2.Action, error) {
+	annos, err := s.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
+	if err != nil {
+		return nil, err
+	}
+	return newAuthAction(&NewDesiredRuleOptions{
+		Ingress: ing,
+		Store:   s,
+		SvcName: backend.ServiceName,
+		SvcPort: backend.ServicePort,
+	}, annos)
+}
+
+// newAuthAction returns the authenticate action configured for the rule's backend, service
+// annotations take precedence over the annotations of the ingress.
+func newAuthAction(o *NewDesiredRuleOptions, annos *annotations.Ingress) (*elbv2.Action, error) {
+	authConfig := annos.Auth
+	if !action.Use(o.SvcPort.String()) {
+		svcAnnos, err := o.Store.GetServiceAnnotations(o.Ingress.Namespace+"/"+o.SvcName, annos)
+		if err != nil {
+			return nil, err
+		}
+		authConfig = svcAnnos.Auth
+	}
+
+	if !authConfig.Enabled() {
+		return nil, nil
+	}
+
+	return authConfig.Action(func(secretName string) (string, string, error) {
+		key := o.Ingress.Namespace + "/" + secretName
+		secret, err := o.Store.GetSecret(key)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get OIDC client credentials from secret %v: %v", key, err.Error())
+		}
+		clientID, ok := secret.Data[auth.SecretClientIDKey]
+		if !ok {
+			return "", "", fmt.Errorf("secret %v is missing the %v key", key, auth.SecretClientIDKey)
+		}
+		clientSecret, ok := secret.Data[auth.SecretClientSecretKey]
+		if !ok {
+			return "", "", fmt.Errorf("secret %v is missing the %v key", key, auth.SecretClientSecretKey)
+		}
+		return string(clientID), string(clientSecret), nil
+	})
+}
+
 type NewCurrentRuleOptions struct {
 	SvcName string
 	SvcPort intstr.IntOrString
@@ -108,6 +210,14 @@ func (r *Rule) Reconcile(ctx context.Context, rOpts *ReconcileOptions) error {
 			if *r.rs.desired.Actions[i].Type != elbv2.ActionTypeEnumForward {
 				continue
 			}
+			if r.action != nil {
+				order := r.rs.desired.Actions[i].Order
+				r.rs.desired.Actions[i] = r.action.ELBV2Action(func(b extensions.IngressBackend) *string {
+					return r.backendTargetGroupArn(ctx, rOpts.TargetGroups, b)
+				})
+				r.rs.desired.Actions[i].Order = order
+				continue
+			}
 			r.rs.desired.Actions[i].TargetGroupArn = r.TargetGroupArn(ctx, rOpts.TargetGroups)
 		}
 	}
@@ -156,14 +266,18 @@ func (r *Rule) Reconcile(ctx context.Context, rOpts *ReconcileOptions) error {
 }
 
 func (r *Rule) TargetGroupArn(ctx context.Context, tgs tg.TargetGroups) *string {
-	i := tgs.LookupByBackend(extensions.IngressBackend{ServiceName: r.svc.desired.name, ServicePort: r.svc.desired.port})
+	return r.backendTargetGroupArn(ctx, tgs, extensions.IngressBackend{ServiceName: r.svc.desired.name, ServicePort: r.svc.desired.port})
+}
+
+func (r *Rule) backendTargetGroupArn(ctx context.Context, tgs tg.TargetGroups, backend extensions.IngressBackend) *string {
+	i := tgs.LookupByBackend(r.svc.desired.namespace, backend)
 	if i < 0 {
-		albctx.GetLogger(ctx).Errorf("Failed to locate TargetGroup related to this service: %s:%s", r.svc.desired.name, r.svc.desired.port.String())
+		albctx.GetLogger(ctx).Errorf("Failed to locate TargetGroup related to this service: %s:%s", backend.ServiceName, backend.ServicePort.String())
 		return nil
 	}
 	arn := tgs[i].CurrentARN()
 	if arn == nil {
-		albctx.GetLogger(ctx).Errorf("Located TargetGroup but no known (current) state found: %s:%s", r.svc.desired.name, r.svc.desired.port.String())
+		albctx.GetLogger(ctx).Errorf("Located TargetGroup but no known (current) state found: %s:%s", backend.ServiceName, backend.ServicePort.String())
 	}
 	return arn
 }
@@ -178,10 +292,11 @@ func (r *Rule) create(ctx context.Context, rOpts *ReconcileOptions) error {
 
 	o, err := albelbv2.ELBV2svc.CreateRule(in)
 	if err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error creating %v rule: %s", *in.Priority, err.Error())
-		return fmt.Errorf("Failed Rule creation. Rule: %s | Error: %s", log.Prettify(r.rs.desired), err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepRule.Reason(), "Error creating %v rule: %s", *in.Priority, err.Error())
+		return fmt.Errorf("Failed Rule creation. Rule: %s | Error: %s", log.Prettify(withoutSecrets(r.rs.desired)), err.Error())
 	}
 	r.rs.current = o.Rules[0]
+	action.KeepClientSecrets(r.rs.current.Actions, r.rs.desired.Actions)
 	r.svc.current = r.svc.desired
 
 	return nil
@@ -197,11 +312,12 @@ func (r *Rule) modify(ctx context.Context, rOpts *ReconcileOptions) error {
 	o, err := albelbv2.ELBV2svc.ModifyRule(in)
 	if err != nil {
 		msg := fmt.Sprintf("Error modifying rule %s: %s", *r.rs.current.RuleArn, err.Error())
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", msg)
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepRule.Reason(), msg)
 		return fmt.Errorf(msg)
 	}
 	if len(o.Rules) > 0 {
 		r.rs.current = o.Rules[0]
+		action.KeepClientSecrets(r.rs.current.Actions, r.rs.desired.Actions)
 	}
 	r.svc.current = r.svc.desired
 
@@ -223,7 +339,7 @@ func (r *Rule) delete(ctx context.Context, rOpts *ReconcileOptions) error {
 
 	in := &elbv2.DeleteRuleInput{RuleArn: r.rs.current.RuleArn}
 	if _, err := albelbv2.ELBV2svc.DeleteRule(in); err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error deleting %s rule: %s", *r.rs.current.Priority, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepRule.Reason(), "Error deleting %s rule: %s", *r.rs.current.Priority, err.Error())
 		return fmt.Errorf("Failed Rule deletion. Error: %s", err.Error())
 	}
 
@@ -239,8 +355,8 @@ func (r *Rule) needsModification(ctx context.Context) bool {
 	case crs == nil:
 		albctx.GetLogger(ctx).Debugf("Current is nil")
 		return true
-	case !util.DeepEqual(crs.Actions, drs.Actions):
-		albctx.GetLogger(ctx).Debugf("Actions needs to be changed (%v != %v)", log.Prettify(crs.Actions), log.Prettify(drs.Actions))
+	case !action.ActionsEqual(crs.Actions, drs.Actions):
+		albctx.GetLogger(ctx).Debugf("Actions needs to be changed (%v != %v)", log.Prettify(action.WithoutSecrets(crs.Actions)), log.Prettify(action.WithoutSecrets(drs.Actions)))
 		return true
 	case !conditionsEqual(crs.Conditions, drs.Conditions):
 		albctx.GetLogger(ctx).Debugf("Conditions needs to be changed (%v != %v)", log.Prettify(crs.Conditions), log.Prettify(drs.Conditions))
@@ -256,19 +372,44 @@ func (r *Rule) needsModification(ctx context.Context) bool {
 	return false
 }
 
-// conditionsEqual returns true if c1 and c2 are identical conditions.
+// pathPatterns returns the path-pattern values matching the path of an Ingress with the pathType.
+// Exact paths are matched literally and Prefix paths also match every path below them, neither
+// may contain the wildcards of path-pattern values. ImplementationSpecific paths are path-pattern
+// values.
+func pathPatterns(path, pathType string) ([]string, error) {
+	switch pathType {
+	case k8s.PathTypeExact, k8s.PathTypePrefix:
+		if strings.ContainsAny(path, "*?") {
+			return nil, fmt.Errorf("path %v has pathType %v and can't contain the wildcards * and ?, use pathType %v for path-pattern values",
+				path, pathType, k8s.PathTypeImplementationSpecific)
+		}
+	case k8s.PathTypeImplementationSpecific, "":
+		return []string{path}, nil
+	default:
+		return nil, fmt.Errorf("path %v has unknown pathType %v", path, pathType)
+	}
+
+	if pathType == k8s.PathTypeExact {
+		return []string{path}, nil
+	}
+	prefix := strings.TrimRight(path, "/")
+	if prefix == "" {
+		return []string{"/*"}, nil
+	}
+	return []string{prefix, prefix + "/*"}, nil
+}
+
+// conditionsEqual returns true if c1 and c2 are identical conditions. The order of conditions
+// and their values is ignored, as is whether values are set on the condition or its configuration.
 func conditionsEqual(c1 []*elbv2.RuleCondition, c2 []*elbv2.RuleCondition) bool {
-	cMap1 := conditionToMap(c1)
-	cMap2 := conditionToMap(c2)
+	if len(c1) != len(c2) {
+		return false
+	}
 
-	for k, v := range cMap1 {
-		val, ok := cMap2[k]
-		// If key didn't exist, mod is needed
-		if !ok {
-			return false
-		}
-		// If key existed but values were diff, mod is needed
-		if !util.DeepEqual(v, val) {
+	k1 := conditionKeys(c1)
+	k2 := conditionKeys(c2)
+	for i := range k1 {
+		if k1[i] != k2[i] {
 			return false
 		}
 	}
@@ -276,13 +417,65 @@ func conditionsEqual(c1 []*elbv2.RuleCondition, c2 []*elbv2.RuleCondition) bool
 	return true
 }
 
-// conditionsToMap converts a elbv2.Conditions struct into a map[string]string representation
-func conditionToMap(cs []*elbv2.RuleCondition) map[string][]*string {
-	cMap := make(map[string][]*string)
+// conditionKeys converts conditions into a sorted list of comparable string representations
+func conditionKeys(cs []*elbv2.RuleCondition) []string {
+	var keys []string
 	for _, c := range cs {
-		cMap[*c.Field] = c.Values
+		field := aws.StringValue(c.Field)
+		var values []string
+
+		switch field {
+		case conditions.FieldHostHeader, conditions.FieldPathPattern:
+			values = aws.StringValueSlice(conditions.Values(c))
+		case conditions.FieldHTTPHeader:
+			if c.HttpHeaderConfig != nil {
+				field += ":" + aws.StringValue(c.HttpHeaderConfig.HttpHeaderName)
+				values = aws.StringValueSlice(c.HttpHeaderConfig.Values)
+			}
+		case conditions.FieldHTTPRequestMethod:
+			if c.HttpRequestMethodConfig != nil {
+				values = aws.StringValueSlice(c.HttpRequestMethodConfig.Values)
+			}
+		case conditions.FieldQueryString:
+			if c.QueryStringConfig != nil {
+				for _, kv := range c.QueryStringConfig.Values {
+					values = append(values, fmt.Sprintf("%q=%q", aws.StringValue(kv.Key), aws.StringValue(kv.Value)))
+				}
+			}
+		case conditions.FieldSourceIP:
+			if c.SourceIpConfig != nil {
+				values = aws.StringValueSlice(c.SourceIpConfig.Values)
+			}
+		default:
+			values = aws.StringValueSlice(c.Values)
+		}
+
+		sort.Strings(values)
+		keys = append(keys, fmt.Sprintf("%s%q", field, uniqueValues(values)))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// uniqueValues removes the duplicates of sorted values, which don't change what a condition matches
+func uniqueValues(values []string) []string {
+	var unique []string
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
 	}
-	return cMap
+	return unique
+}
+
+// withoutSecrets returns a copy of the rule safe for logging
+func withoutSecrets(r *elbv2.Rule) *elbv2.Rule {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Actions = action.WithoutSecrets(r.Actions)
+	return &c
 }
 
 // stripDesiredState removes the desired state from the rule.
@@ -311,17 +504,32 @@ func (r Rule) IsDesiredDefault() bool {
 	return *r.rs.desired.IsDefault
 }
 
+// TargetAction returns the action routing requests, which follows any authenticate action.
+func TargetAction(actions []*elbv2.Action) *elbv2.Action {
+	for _, a := range actions {
+		switch aws.StringValue(a.Type) {
+		case elbv2.ActionTypeEnumAuthenticateCognito, elbv2.ActionTypeEnumAuthenticateOidc:
+			continue
+		}
+		return a
+	}
+	return nil
+}
+
 func (r Rule) valid(listenerPort int64, listenerProtocol *string) bool {
-	if r.rs.desired.Actions[0].RedirectConfig != nil {
+	if rc := TargetAction(r.rs.desired.Actions).RedirectConfig; rc != nil {
 		var host, path *string
-		rc := r.rs.desired.Actions[0].RedirectConfig
 
 		for _, c := range r.rs.desired.Conditions {
+			values := conditions.Values(c)
+			if len(values) == 0 {
+				continue
+			}
 			if *c.Field == "host-header" {
-				host = c.Values[0]
+				host = values[0]
 			}
 			if *c.Field == "path-pattern" {
-				path = c.Values[0]
+				path = values[0]
 			}
 		}
 
@@ -3,9 +3,12 @@ package rs
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
 
 func TestNewDesiredRule(t *testing.T) {
@@ -38,7 +42,7 @@ func TestNewDesiredRule(t *testing.T) {
 			Store:      store.NewDummy(),
 			TargetPort: 0,
 			ExpectedRule: Rule{
-				svc: svc{desired: service{name: "fixed-response-action", port: intstr.FromString(action.UseActionAnnotation)}},
+				svc: svc{desired: service{namespace: "default", name: "fixed-response-action", port: intstr.FromString(action.UseActionAnnotation)}},
 				rs: rs{
 					desired: &elbv2.Rule{
 						Priority:  aws.String("1"),
@@ -65,7 +69,7 @@ func TestNewDesiredRule(t *testing.T) {
 			Store:      store.NewDummy(),
 			TargetPort: 0,
 			ExpectedRule: Rule{
-				svc: svc{desired: service{name: "redirect", port: intstr.FromString(action.UseActionAnnotation)}},
+				svc: svc{desired: service{namespace: "default", name: "redirect", port: intstr.FromString(action.UseActionAnnotation)}},
 				rs: rs{
 					desired: &elbv2.Rule{
 						Priority:  aws.String("1"),
@@ -87,6 +91,70 @@ func TestNewDesiredRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			Priority:   1,
+			Hostname:   "hostname",
+			SvcName:    "header-routed-service",
+			SvcPort:    intstr.FromInt(8080),
+			Ingress:    dummy.NewIngress(),
+			Store:      store.NewDummy(),
+			TargetPort: 8080,
+			ExpectedRule: Rule{
+				svc: svc{desired: service{namespace: "default", name: "header-routed-service", port: intstr.FromInt(8080)}},
+				rs: rs{
+					desired: &elbv2.Rule{
+						Priority:  aws.String("1"),
+						IsDefault: aws.Bool(false),
+						Conditions: []*elbv2.RuleCondition{
+							{
+								Field:  aws.String("host-header"),
+								Values: []*string{aws.String("hostname")},
+							},
+							{
+								Field: aws.String("http-header"),
+								HttpHeaderConfig: &elbv2.HttpHeaderConditionConfig{
+									HttpHeaderName: aws.String("X-Api-Version"),
+									Values:         []*string{aws.String("v2")},
+								},
+							},
+						},
+						Actions: []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward)}},
+					},
+				},
+			},
+		},
+		{
+			Priority:   1,
+			SvcName:    "weighted-routing",
+			SvcPort:    intstr.FromString(action.UseActionAnnotation),
+			Ingress:    dummy.NewIngress(),
+			Store:      store.NewDummy(),
+			TargetPort: 0,
+			ExpectedRule: Rule{
+				svc: svc{desired: service{namespace: "default", name: "weighted-routing", port: intstr.FromString(action.UseActionAnnotation)}},
+				rs: rs{
+					desired: &elbv2.Rule{
+						Priority:  aws.String("1"),
+						IsDefault: aws.Bool(false),
+						Actions: []*elbv2.Action{
+							{
+								Type: aws.String(elbv2.ActionTypeEnumForward),
+								ForwardConfig: &elbv2.ForwardActionConfig{
+									TargetGroups: []*elbv2.TargetGroupTuple{
+										{Weight: aws.Int64(90)},
+										{Weight: aws.Int64(10)},
+									},
+									TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+										Enabled:         aws.Bool(true),
+										DurationSeconds: aws.Int64(300),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Priority:   1,
 			SvcName:    "namespace-service",
@@ -702,6 +770,91 @@ func TestNeedsModification(t *testing.T) {
 	}
 }
 
+func TestConditionsEqual(t *testing.T) {
+	cases := []struct {
+		c1    []*elbv2.RuleCondition
+		c2    []*elbv2.RuleCondition
+		equal bool
+	}{
+		{ // values set on the condition match values reported on its configuration
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("host-header"), Values: []*string{aws.String("hostname")}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{
+					Field:            aws.String("host-header"),
+					Values:           []*string{aws.String("hostname")},
+					HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: []*string{aws.String("hostname")}},
+				},
+			},
+			equal: true,
+		},
+		{ // order of conditions and values is ignored
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), Values: []*string{aws.String("/path")}},
+				{Field: aws.String("http-request-method"), HttpRequestMethodConfig: &elbv2.HttpRequestMethodConditionConfig{Values: []*string{aws.String("GET"), aws.String("HEAD")}}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("http-request-method"), HttpRequestMethodConfig: &elbv2.HttpRequestMethodConditionConfig{Values: []*string{aws.String("HEAD"), aws.String("GET")}}},
+				{Field: aws.String("path-pattern"), Values: []*string{aws.String("/path")}},
+			},
+			equal: true,
+		},
+		{ // an additional desired condition is detected
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), Values: []*string{aws.String("/path")}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), Values: []*string{aws.String("/path")}},
+				{Field: aws.String("source-ip"), SourceIpConfig: &elbv2.SourceIpConditionConfig{Values: []*string{aws.String("10.0.0.0/8")}}},
+			},
+			equal: false,
+		},
+		{ // http-header conditions differ by header name
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("http-header"), HttpHeaderConfig: &elbv2.HttpHeaderConditionConfig{HttpHeaderName: aws.String("X-A"), Values: []*string{aws.String("v")}}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("http-header"), HttpHeaderConfig: &elbv2.HttpHeaderConditionConfig{HttpHeaderName: aws.String("X-B"), Values: []*string{aws.String("v")}}},
+			},
+			equal: false,
+		},
+		{ // query-string conditions differ by value
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("query-string"), QueryStringConfig: &elbv2.QueryStringConditionConfig{Values: []*elbv2.QueryStringKeyValuePair{{Key: aws.String("beta"), Value: aws.String("true")}}}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("query-string"), QueryStringConfig: &elbv2.QueryStringConditionConfig{Values: []*elbv2.QueryStringKeyValuePair{{Key: aws.String("beta"), Value: aws.String("false")}}}},
+			},
+			equal: false,
+		},
+		{ // the values of a Prefix path match the values reported on the condition configuration
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: []*string{aws.String("/api"), aws.String("/api/*")}}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: []*string{aws.String("/api/*"), aws.String("/api"), aws.String("/api")}}},
+			},
+			equal: true,
+		},
+		{ // a Prefix path differs from the same ImplementationSpecific path
+			c1: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: []*string{aws.String("/api"), aws.String("/api/*")}}},
+			},
+			c2: []*elbv2.RuleCondition{
+				{Field: aws.String("path-pattern"), Values: []*string{aws.String("/api")}},
+			},
+			equal: false,
+		},
+	}
+
+	for i, c := range cases {
+		if conditionsEqual(c.c1, c.c2) != c.equal {
+			t.Errorf("conditionsEqual.%v returned %v, expected %v", i, !c.equal, c.equal)
+		}
+	}
+}
+
 func TestRuleStripDesiredState(t *testing.T) {
 	r := &Rule{rs: rs{desired: &elbv2.Rule{}}}
 
@@ -892,3 +1045,119 @@ func TestRuleValid(t *testing.T) {
 		}
 	}
 }
+
+func TestNewDesiredRuleWithAuthentication(t *testing.T) {
+	s := store.NewDummy()
+	s.GetServiceAnnotationsResponse.Auth = &auth.Config{
+		Type: aws.String(auth.TypeOIDC),
+		IdpOidc: &auth.IdpOidc{
+			Issuer:                "https://idp.example.com",
+			AuthorizationEndpoint: "https://idp.example.com/authorize",
+			TokenEndpoint:         "https://idp.example.com/token",
+			UserInfoEndpoint:      "https://idp.example.com/userinfo",
+			SecretName:            "oidc-secret",
+		},
+	}
+
+	for _, c := range []struct {
+		protocol        string
+		expectedActions int
+	}{
+		{elbv2.ProtocolEnumHttps, 2},
+		{elbv2.ProtocolEnumHttp, 1}, // authentication requires HTTPS
+	} {
+		rule, err := NewDesiredRule(&NewDesiredRuleOptions{
+			Priority:         1,
+			Ingress:          dummy.NewIngress(),
+			Store:            s,
+			SvcName:          "service",
+			SvcPort:          intstr.FromInt(8080),
+			ListenerProtocol: aws.String(c.protocol),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actions := rule.rs.desired.Actions
+		if len(actions) != c.expectedActions {
+			t.Errorf("expected %v actions on a %v listener, got %v", c.expectedActions, c.protocol, len(actions))
+			continue
+		}
+		if c.expectedActions == 1 {
+			continue
+		}
+		if *actions[0].Type != elbv2.ActionTypeEnumAuthenticateOidc || *actions[0].Order != 1 {
+			t.Errorf("expected the authenticate action first, got %v", log.Prettify(actions[0]))
+		}
+		if *actions[0].AuthenticateOidcConfig.ClientSecret != "client-secret" {
+			t.Errorf("expected the client secret to be read from the secret")
+		}
+		if *actions[1].Type != elbv2.ActionTypeEnumForward || *actions[1].Order != 2 {
+			t.Errorf("expected the forward action second, got %v", log.Prettify(actions[1]))
+		}
+		if TargetAction(actions) != actions[1] {
+			t.Errorf("expected the forward action to route requests")
+		}
+	}
+}
+
+func TestPathPatterns(t *testing.T) {
+	cases := []struct {
+		path     string
+		pathType string
+		expected []string
+		err      bool
+	}{
+		{path: "/api", pathType: k8s.PathTypeImplementationSpecific, expected: []string{"/api"}},
+		{path: "/api/*", pathType: k8s.PathTypeImplementationSpecific, expected: []string{"/api/*"}},
+		{path: "/api/*", pathType: "", expected: []string{"/api/*"}},
+		{path: "/api", pathType: k8s.PathTypeExact, expected: []string{"/api"}},
+		{path: "/api/", pathType: k8s.PathTypeExact, expected: []string{"/api/"}},
+		{path: "/api/*", pathType: k8s.PathTypeExact, err: true},
+		{path: "/api?", pathType: k8s.PathTypeExact, err: true},
+		{path: "/api", pathType: k8s.PathTypePrefix, expected: []string{"/api", "/api/*"}},
+		{path: "/api/", pathType: k8s.PathTypePrefix, expected: []string{"/api", "/api/*"}},
+		{path: "/", pathType: k8s.PathTypePrefix, expected: []string{"/*"}},
+		{path: "/api*", pathType: k8s.PathTypePrefix, err: true},
+		{path: "/api", pathType: "Regex", err: true},
+	}
+
+	for i, c := range cases {
+		patterns, err := pathPatterns(c.path, c.pathType)
+		if (err != nil) != c.err {
+			t.Errorf("pathPatterns.%v returned error %v, expected error: %v", i, err, c.err)
+			continue
+		}
+		if !reflect.DeepEqual(patterns, c.expected) {
+			t.Errorf("pathPatterns.%v returned %v, expected %v", i, patterns, c.expected)
+		}
+	}
+}
+
+func TestNewDesiredRulePathType(t *testing.T) {
+	r, err := NewDesiredRule(&NewDesiredRuleOptions{
+		Path:     "/api",
+		PathType: k8s.PathTypePrefix,
+		SvcName:  "api",
+		SvcPort:  intstr.FromInt(80),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*elbv2.RuleCondition{
+		{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: []*string{aws.String("/api"), aws.String("/api/*")}}},
+	}
+	if !reflect.DeepEqual(r.rs.desired.Conditions, expected) {
+		t.Errorf("NewDesiredRule returned conditions %v, expected %v", log.Prettify(r.rs.desired.Conditions), log.Prettify(expected))
+	}
+
+	_, err = NewDesiredRule(&NewDesiredRuleOptions{
+		Path:     "/api/*",
+		PathType: k8s.PathTypeExact,
+		SvcName:  "api",
+		SvcPort:  intstr.FromInt(80),
+	})
+	if err == nil {
+		t.Errorf("NewDesiredRule accepted a wildcard in an Exact path")
+	}
+}
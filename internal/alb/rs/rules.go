@@ -3,19 +3,28 @@ package rs
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/rule"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 
+	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 )
 
 type NewCurrentRulesOptions struct {
@@ -36,10 +45,12 @@ func NewCurrentRules(o *NewCurrentRulesOptions) (Rules, error) {
 		var svcName string
 		var svcPort intstr.IntOrString
 
-		if *r.Actions[0].Type == elbv2.ActionTypeEnumForward {
-			i, tg := o.TargetGroups.FindCurrentByARN(*r.Actions[0].TargetGroupArn)
+		// weighted forward actions have no single target group and are configured by annotation
+		a := TargetAction(r.Actions)
+		if a != nil && *a.Type == elbv2.ActionTypeEnumForward && a.TargetGroupArn != nil {
+			i, tg := o.TargetGroups.FindCurrentByARN(*a.TargetGroupArn)
 			if i < 0 {
-				return nil, fmt.Errorf("failed to find a target group associated with a rule. This should not be possible. Rule: %s, ARN: %s", awsutil.Prettify(r.RuleArn), *r.Actions[0].TargetGroupArn)
+				return nil, fmt.Errorf("failed to find a target group associated with a rule. This should not be possible. Rule: %s, ARN: %s", awsutil.Prettify(r.RuleArn), *a.TargetGroupArn)
 			}
 			svcName = tg.SvcName
 			svcPort = tg.SvcPort
@@ -59,9 +70,9 @@ func NewCurrentRules(o *NewCurrentRulesOptions) (Rules, error) {
 }
 
 type NewDesiredRulesOptions struct {
-	Priority         int
 	ListenerRules    Rules
 	Rule             *extensions.IngressRule
+	RuleIndex        int
 	Ingress          *extensions.Ingress
 	Store            store.Storer
 	TargetGroups     tg.TargetGroups
@@ -71,64 +82,212 @@ type NewDesiredRulesOptions struct {
 }
 
 // NewDesiredRules returns a Rules created by appending the IngressRule paths to a ListenerRules.
-// The returned priority is the highest priority added to the rules list.
-func NewDesiredRules(o *NewDesiredRulesOptions) (Rules, int, error) {
+// Existing rules with the same conditions and backend are reused, so they keep their priority.
+// The desired rules are moved to the end of the slice in path order, priorities are assigned
+// afterwards by AllocatePriorities.
+func NewDesiredRules(o *NewDesiredRulesOptions) (Rules, error) {
 	rs := o.ListenerRules
 	paths := o.Rule.HTTP.Paths
 
-	if o.Priority == 0 {
-		o.Priority = 1
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("ingress doesn't have any paths defined. This is not a very good ingress")
 	}
 
-	if len(paths) == 0 {
-		return nil, 0, fmt.Errorf("ingress doesn't have any paths defined. This is not a very good ingress")
+	var annos *annotations.Ingress
+	if o.Ingress != nil {
+		var err error
+		annos, err = o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	for _, path := range paths {
+	for i, path := range paths {
+		var p int64
+		if annos != nil {
+			p = annos.Rule.Priority(o.Rule.Host, path.Path)
+		}
+		pathType := k8s.PathTypeImplementationSpecific
+		if o.Ingress != nil {
+			pathType = k8s.PathType(o.Ingress, o.RuleIndex, i)
+		}
+
 		r, err := NewDesiredRule(&NewDesiredRuleOptions{
 			Ingress:          o.Ingress,
 			Store:            o.Store,
-			Priority:         o.Priority,
+			Priority:         int(p),
 			Hostname:         o.Rule.Host,
 			IgnoreHostHeader: o.IgnoreHostHeader,
 			Path:             path.Path,
+			PathType:         pathType,
 			SvcName:          path.Backend.ServiceName,
 			SvcPort:          path.Backend.ServicePort,
+			ListenerProtocol: o.ListenerProtocol,
 		})
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
+		r.fixedPriority = p > 0
 
 		if !r.valid(o.ListenerPort.Port, o.ListenerProtocol) {
 			continue
 		}
 
-		if !rs.merge(r) {
-			rs = append(rs, r)
+		if i, existingRule := rs.findMatch(r); i >= 0 {
+			existingRule.merge(r)
+			rs = append(append(rs[:i:i], rs[i+1:]...), existingRule)
+			continue
 		}
-		o.Priority++
+		rs = append(rs, r)
 	}
 
-	return rs, o.Priority, nil
+	return rs, nil
 }
 
-func (r Rules) merge(mergeRule *Rule) bool {
-	if i, existingRule := r.FindByPriority(mergeRule.rs.desired.Priority); i >= 0 {
-		existingRule.rs.desired = mergeRule.rs.desired
-		existingRule.svc.desired = mergeRule.svc.desired
-		return true
+// findMatch returns the existing rule without desired state that mergeRule replaces. Rules with the
+// same conditions and backend are preferred over rules with the same conditions only.
+func (r Rules) findMatch(mergeRule *Rule) (int, *Rule) {
+	match := -1
+	for i, v := range r {
+		if v.rs.current == nil || v.rs.desired != nil || aws.BoolValue(v.rs.current.IsDefault) {
+			continue
+		}
+		if !conditionsEqual(v.rs.current.Conditions, mergeRule.rs.desired.Conditions) {
+			continue
+		}
+		if v.sameBackend(mergeRule) {
+			return i, v
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, nil
 	}
-	return false
+	return match, r[match]
 }
 
-// Reconcile kicks off the state synchronization for every Rule in this Rules slice.
-func (r Rules) Reconcile(ctx context.Context, rOpts *ReconcileOptions) (Rules, error) {
-	var output Rules
+// merge sets the desired state of mergeRule on the existing rule r
+func (r *Rule) merge(mergeRule *Rule) {
+	r.rs.desired = mergeRule.rs.desired
+	r.svc.desired = mergeRule.svc.desired
+	r.action = mergeRule.action
+	r.fixedPriority = mergeRule.fixedPriority
+}
+
+// sameBackend returns true if the current backend of r is the desired backend of desiredRule
+func (r *Rule) sameBackend(desiredRule *Rule) bool {
+	current := r.svc.current
+	desired := desiredRule.svc.desired
+	if current.port.String() == action.UseActionAnnotation {
+		return desired.port.String() == action.UseActionAnnotation && action.ActionsEqual(r.rs.current.Actions, desiredRule.rs.desired.Actions)
+	}
+	return current.name == desired.name && current.port.String() == desired.port.String()
+}
+
+// prioritySpacing is the gap left between the priorities of consecutive new rules, so rules inserted
+// later between them get a free priority without moving the rules after them
+const prioritySpacing = 10
+
+// AllocatePriorities assigns a priority to every desired rule without a priority configured by
+// annotation. The desired rules must be in evaluation order, as returned by NewDesiredRules.
+// Existing rules keep their priority as long as it is higher than the priority of the rule
+// before them. Other rules get a free priority between the rule before them and the next used
+// priority, prioritySpacing after the rule before them when possible. The rules after them are
+// only moved when there is no free priority in between.
+func (r Rules) AllocatePriorities() error {
+	fixed := make(map[int64]bool)
+	reserved := make(map[int64]bool)
+	for _, v := range r {
+		if v.rs.desired == nil || aws.BoolValue(v.rs.desired.IsDefault) {
+			continue
+		}
+		if v.fixedPriority {
+			p := *priority(v.rs.desired.Priority)
+			if fixed[p] {
+				return fmt.Errorf("rule priority %v is configured for more than one rule", p)
+			}
+			fixed[p] = true
+			continue
+		}
+		if v.rs.current != nil && !aws.BoolValue(v.rs.current.IsDefault) {
+			reserved[*priority(v.rs.current.Priority)] = true
+		}
+	}
+
+	// next returns the lowest priority used after p, MaxPriority+1 when there is none
+	next := func(p int64) int64 {
+		n := int64(rule.MaxPriority + 1)
+		for _, used := range []map[int64]bool{fixed, reserved} {
+			for u := range used {
+				if u > p && u < n {
+					n = u
+				}
+			}
+		}
+		return n
+	}
+
+	var last int64
+	for _, v := range r {
+		if v.rs.desired == nil || aws.BoolValue(v.rs.desired.IsDefault) || v.fixedPriority {
+			continue
+		}
+
+		if v.rs.current != nil && !aws.BoolValue(v.rs.current.IsDefault) {
+			if p := *priority(v.rs.current.Priority); p > last && !fixed[p] {
+				v.rs.desired.Priority = v.rs.current.Priority
+				last = p
+				continue
+			}
+		}
+
+		p := last + prioritySpacing
+		if n := next(last); p >= n {
+			p = last + (n-last)/2
+		}
+		if p <= last {
+			// no free priority before the next used one, the rules after this one are moved
+			p = last + 1
+			for fixed[p] || reserved[p] {
+				p++
+			}
+		}
+		if p > rule.MaxPriority {
+			return fmt.Errorf("no rule priority left, the listener is limited to %v rules", rule.MaxPriority)
+		}
+		v.rs.desired.Priority = aws.String(strconv.FormatInt(p, 10))
+		reserved[p] = true
+		last = p
+	}
+	return nil
+}
 
+// Reconcile kicks off the state synchronization for every Rule in this Rules slice. Rules are
+// deleted first and priorities are changed in a single call, so a priority is never used by two
+// rules at the same time.
+func (r Rules) Reconcile(ctx context.Context, rOpts *ReconcileOptions) (Rules, error) {
 	for _, rule := range r {
+		if rule.rs.desired != nil {
+			continue
+		}
 		if err := rule.Reconcile(ctx, rOpts); err != nil {
 			return nil, err
 		}
+	}
+
+	if err := r.setPriorities(ctx); err != nil {
+		return nil, err
+	}
+
+	var output Rules
+	for _, rule := range r {
+		if rule.rs.desired != nil {
+			if err := rule.Reconcile(ctx, rOpts); err != nil {
+				return nil, err
+			}
+		}
 		if !rule.deleted {
 			output = append(output, rule)
 		}
@@ -137,6 +296,40 @@ func (r Rules) Reconcile(ctx context.Context, rOpts *ReconcileOptions) (Rules, e
 	return output, nil
 }
 
+// setPriorities moves the existing rules whose priority changed to their desired priority
+func (r Rules) setPriorities(ctx context.Context) error {
+	var pairs []*elbv2.RulePriorityPair
+	var moved Rules
+	for _, rule := range r {
+		c, d := rule.rs.current, rule.rs.desired
+		if c == nil || d == nil || aws.BoolValue(c.IsDefault) || aws.BoolValue(d.IsDefault) {
+			continue
+		}
+		if aws.StringValue(c.Priority) == aws.StringValue(d.Priority) {
+			continue
+		}
+		pairs = append(pairs, &elbv2.RulePriorityPair{
+			RuleArn:  c.RuleArn,
+			Priority: priority(d.Priority),
+		})
+		moved = append(moved, rule)
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("Setting the priorities of %v rules: %v", len(pairs), log.Prettify(pairs))
+	if _, err := albelbv2.ELBV2svc.SetRulePriorities(&elbv2.SetRulePrioritiesInput{RulePriorities: pairs}); err != nil {
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepRule.Reason(), "Error setting rule priorities: %s", err.Error())
+		return fmt.Errorf("Failed Rule priority modification. Error: %s", err.Error())
+	}
+	for _, rule := range moved {
+		rule.rs.current.Priority = rule.rs.desired.Priority
+	}
+	albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%v rule priorities modified", len(pairs))
+	return nil
+}
+
 // FindByPriority returns the position in the Rules slice of the rule parameter
 func (r Rules) FindByPriority(priority *string) (int, *Rule) {
 	for p, v := range r {
@@ -152,7 +345,7 @@ func (r Rules) FindByPriority(priority *string) (int, *Rule) {
 
 // FindUnusedTGs returns a list of TargetGroups that are no longer referncd by any of
 // the rules passed into this method.
-func (r Rules) FindUnusedTGs(tgs tg.TargetGroups, defaultArn *string) tg.TargetGroups {
+func (r Rules) FindUnusedTGs(tgs tg.TargetGroups, defaultArns ...*string) tg.TargetGroups {
 	var unused tg.TargetGroups
 
 TG:
@@ -164,8 +357,10 @@ TG:
 			continue
 		}
 
-		if defaultArn != nil && *arn == *defaultArn {
-			continue
+		for _, defaultArn := range defaultArns {
+			if defaultArn != nil && *arn == *defaultArn {
+				continue TG
+			}
 		}
 
 		for _, rule := range r {
@@ -173,10 +368,12 @@ TG:
 				continue TG
 			}
 
-			for _, action := range rule.rs.current.Actions {
-				if action.TargetGroupArn != nil && *action.TargetGroupArn == *arn {
-					used = true
-					continue TG
+			for _, a := range rule.rs.current.Actions {
+				for _, tgArn := range action.TargetGroupArns(a) {
+					if *tgArn == *arn {
+						used = true
+						continue TG
+					}
 				}
 			}
 		}
@@ -91,12 +91,18 @@ func TestNewDesiredRules(t *testing.T) {
 				},
 			},
 		},
-		{ // Listener has one existing rule
+		{ // Listener has one existing rule for the path
 			// No hostname, some path
 			Pass: true,
 			Options: &NewDesiredRulesOptions{
 				ListenerRules: Rules{
-					&Rule{rs: rs{current: &elbv2.Rule{IsDefault: aws.Bool(false), Priority: aws.String("1")}}},
+					&Rule{rs: rs{current: &elbv2.Rule{
+						IsDefault: aws.Bool(false),
+						Priority:  aws.String("1"),
+						Conditions: []*elbv2.RuleCondition{
+							{Field: aws.String("path-pattern"), Values: []*string{aws.String(paths[2])}},
+						},
+					}}},
 				},
 				Rule: &extensions.IngressRule{
 					IngressRuleValue: extensions.IngressRuleValue{
@@ -148,7 +154,7 @@ func TestNewDesiredRules(t *testing.T) {
 		})
 		c.Options.TargetGroups = tgs
 
-		newRules, _, err := NewDesiredRules(c.Options)
+		newRules, err := NewDesiredRules(c.Options)
 		if err != nil && !c.Pass {
 			continue
 		}
@@ -250,3 +256,206 @@ func TestRulesStripCurrentState(t *testing.T) {
 		}
 	}
 }
+
+func TestFindUnusedTGs(t *testing.T) {
+	tgs := tg.TargetGroups{
+		tg.DummyTG("blue-arn", "blue"),
+		tg.DummyTG("green-arn", "green"),
+		tg.DummyTG("default-arn", "default"),
+		tg.DummyTG("unused-arn", "unused"),
+	}
+	rules := Rules{
+		{
+			rs: rs{current: &elbv2.Rule{
+				Actions: []*elbv2.Action{{
+					Type: aws.String(elbv2.ActionTypeEnumForward),
+					ForwardConfig: &elbv2.ForwardActionConfig{
+						TargetGroups: []*elbv2.TargetGroupTuple{
+							{TargetGroupArn: aws.String("blue-arn"), Weight: aws.Int64(90)},
+							{TargetGroupArn: aws.String("green-arn"), Weight: aws.Int64(10)},
+						},
+					},
+				}},
+			}},
+		},
+	}
+
+	unused := rules.FindUnusedTGs(tgs, aws.String("default-arn"))
+	if len(unused) != 1 || *unused[0].CurrentARN() != "unused-arn" {
+		t.Errorf("expected only unused-arn to be unused, got %v", unused)
+	}
+}
+
+func pathRule(priority, path string) *elbv2.Rule {
+	return &elbv2.Rule{
+		IsDefault: aws.Bool(false),
+		Priority:  aws.String(priority),
+		RuleArn:   aws.String("arn" + priority),
+		Conditions: []*elbv2.RuleCondition{
+			{Field: aws.String("path-pattern"), Values: []*string{aws.String(path)}},
+		},
+	}
+}
+
+func TestNewDesiredRulesReusesExistingRules(t *testing.T) {
+	existing := Rules{
+		NewCurrentRule(&NewCurrentRuleOptions{SvcName: "1service", SvcPort: intstr.FromInt(8080), Rule: pathRule("1", paths[0])}),
+		NewCurrentRule(&NewCurrentRuleOptions{SvcName: "2service", SvcPort: intstr.FromInt(8080), Rule: pathRule("2", paths[1])}),
+		NewCurrentRule(&NewCurrentRuleOptions{SvcName: "3service", SvcPort: intstr.FromInt(8080), Rule: pathRule("3", paths[2])}),
+	}
+
+	// /path2 is replaced by a new path and /path3 is routed to another service
+	rules, err := NewDesiredRules(&NewDesiredRulesOptions{
+		ListenerRules: existing,
+		Rule: &extensions.IngressRule{
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{
+						{Path: paths[0], Backend: ingressBackends[0]},
+						{Path: "/new", Backend: ingressBackends[0]},
+						{Path: paths[2], Backend: ingressBackends[1]},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewDesiredRules returned an error: %s", err.Error())
+	}
+	if err := rules.AllocatePriorities(); err != nil {
+		t.Fatalf("AllocatePriorities returned an error: %s", err.Error())
+	}
+
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules, got %v", len(rules))
+	}
+	if rules[0] != existing[1] || rules[0].rs.desired != nil {
+		t.Errorf("expected the /path2 rule to be deleted, got %v", rules[0])
+	}
+	if rules[1] != existing[0] || *rules[1].rs.desired.Priority != "1" {
+		t.Errorf("expected the /path1 rule to keep priority 1, got %v", rules[1])
+	}
+	if rules[2].rs.current != nil || *rules[2].rs.desired.Priority != "2" {
+		t.Errorf("expected the /new rule to be created with the priority of /path2, got %v", rules[2])
+	}
+	if rules[3] != existing[2] || *rules[3].rs.desired.Priority != "3" || rules[3].svc.desired.name != "2service" {
+		t.Errorf("expected the /path3 rule to keep priority 3 and be routed to 2service, got %v", rules[3])
+	}
+
+	// inserting a path before the others moves the rules after it, /path3 is removed
+	// and its priority reused, the moved rule leaves a gap after it
+	rules.StripDesiredState()
+	rules, err = NewDesiredRules(&NewDesiredRulesOptions{
+		ListenerRules: rules[1:],
+		Rule: &extensions.IngressRule{
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{
+						{Path: "/first", Backend: ingressBackends[0]},
+						{Path: paths[0], Backend: ingressBackends[0]},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewDesiredRules returned an error: %s", err.Error())
+	}
+	if err := rules.AllocatePriorities(); err != nil {
+		t.Fatalf("AllocatePriorities returned an error: %s", err.Error())
+	}
+	if *rules[2].rs.desired.Priority != "2" || *rules[3].rs.desired.Priority != "12" {
+		t.Errorf("expected /first and /path1 to have the priorities 2 and 12, got %v and %v", rules[2], rules[3])
+	}
+}
+
+func TestAllocatePriorities(t *testing.T) {
+	desired := func(priority string, fixed bool) *Rule {
+		return &Rule{rs: rs{desired: &elbv2.Rule{IsDefault: aws.Bool(false), Priority: aws.String(priority)}}, fixedPriority: fixed}
+	}
+	existing := func(current, priority string, fixed bool) *Rule {
+		r := desired(priority, fixed)
+		r.rs.current = &elbv2.Rule{IsDefault: aws.Bool(false), Priority: aws.String(current)}
+		return r
+	}
+
+	for i, c := range []struct {
+		Rules    Rules
+		Expected []string
+		Pass     bool
+	}{
+		{ // new rules are numbered in order, leaving gaps for later insertions
+			Rules:    Rules{desired("0", false), desired("0", false)},
+			Expected: []string{"10", "20"},
+			Pass:     true,
+		},
+		{ // existing rules keep their priority, new rules are inserted in the gaps
+			Rules:    Rules{existing("10", "0", false), desired("0", false), existing("20", "0", false)},
+			Expected: []string{"10", "15", "20"},
+			Pass:     true,
+		},
+		{ // new rules leave a gap after the rule before them when the gap allows it
+			Rules:    Rules{existing("2", "0", false), desired("0", false), existing("100", "0", false)},
+			Expected: []string{"2", "12", "100"},
+			Pass:     true,
+		},
+		{ // a new rule inserted between consecutive priorities moves the rules after it
+			Rules:    Rules{existing("1", "0", false), desired("0", false), existing("2", "0", false), existing("3", "0", false)},
+			Expected: []string{"1", "4", "14", "24"},
+			Pass:     true,
+		},
+		{ // priorities configured by annotation are skipped and take over the priority of other rules
+			Rules:    Rules{desired("0", false), existing("2", "0", false), desired("1", true), desired("2", true)},
+			Expected: []string{"3", "13", "1", "2"},
+			Pass:     true,
+		},
+		{ // new rules get the priorities left before the highest priority
+			Rules:    Rules{existing("49995", "0", false), desired("0", false), desired("0", false)},
+			Expected: []string{"49995", "49998", "49999"},
+			Pass:     true,
+		},
+		{ // no priority left
+			Rules: Rules{existing("50000", "0", false), desired("0", false)},
+			Pass:  false,
+		},
+		{ // a priority configured twice
+			Rules: Rules{desired("5", true), desired("5", true)},
+			Pass:  false,
+		},
+	} {
+		err := c.Rules.AllocatePriorities()
+		if err != nil && c.Pass {
+			t.Errorf("AllocatePriorities.%v returned an error but should have passed: %s", i, err.Error())
+			continue
+		}
+		if err == nil && !c.Pass {
+			t.Errorf("AllocatePriorities.%v passed but should have returned an error.", i)
+			continue
+		}
+		for n, p := range c.Expected {
+			if *c.Rules[n].rs.desired.Priority != p {
+				t.Errorf("AllocatePriorities.%v rule %v has priority %v, should be %v.", i, n, *c.Rules[n].rs.desired.Priority, p)
+			}
+		}
+	}
+}
+
+func TestRulesReconcileSetsPriorities(t *testing.T) {
+	moved := NewCurrentRule(&NewCurrentRuleOptions{SvcName: "1service", SvcPort: intstr.FromInt(8080), Rule: pathRule("1", paths[0])})
+	moved.rs.desired = pathRule("2", paths[0])
+	moved.svc.desired = moved.svc.current
+	kept := NewCurrentRule(&NewCurrentRuleOptions{SvcName: "2service", SvcPort: intstr.FromInt(8080), Rule: pathRule("3", paths[1])})
+	kept.rs.desired = pathRule("3", paths[1])
+	kept.svc.desired = kept.svc.current
+
+	rules, err := Rules{moved, kept}.Reconcile(context.Background(), &ReconcileOptions{
+		ListenerArn:  aws.String("listener"),
+		TargetGroups: tg.TargetGroups{tg.DummyTG("arn", "service")},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile returned an error: %s", err.Error())
+	}
+	if len(rules) != 2 || *moved.rs.current.Priority != "2" {
+		t.Errorf("expected the rule to be moved to priority 2, got %v", moved)
+	}
+}
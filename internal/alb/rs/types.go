@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -16,7 +17,10 @@ type Rules []*Rule
 type Rule struct {
 	rs      rs
 	svc     svc
+	action  *action.Action // annotation action of a weighted forward rule
 	deleted bool
+
+	fixedPriority bool // the desired priority is configured by annotation
 }
 
 func (r *Rule) String() string {
@@ -25,8 +29,8 @@ func (r *Rule) String() string {
 	}
 
 	return "[" + strings.Join([]string{
-		"CurrentRule: " + log.String(r.rs.current),
-		"DesiredRule: " + log.String(r.rs.desired),
+		"CurrentRule: " + log.String(withoutSecrets(r.rs.current)),
+		"DesiredRule: " + log.String(withoutSecrets(r.rs.desired)),
 		"CurrentService: " + log.String(r.svc.current),
 		"DesiredService: " + log.String(r.svc.desired),
 	}, ", ") + "]"
@@ -43,12 +47,14 @@ type svc struct {
 }
 
 type service struct {
-	name string
-	port intstr.IntOrString
+	namespace string
+	name      string
+	port      intstr.IntOrString
 }
 
 func (s service) String() string {
 	return "[" + strings.Join([]string{
+		"namespace: " + s.namespace,
 		"name: " + s.name,
 		"port: " + log.String(&s.port),
 	}, ", ") + "]"
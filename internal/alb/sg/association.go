@@ -3,6 +3,7 @@ package sg
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 
@@ -117,10 +118,19 @@ func (controller *associationController) reconcileManagedLbSG(ctx context.Contex
 	}
 	for _, port := range association.LbPorts {
 		ipRanges := []*ec2.IpRange{}
+		var ipv6Ranges []*ec2.Ipv6Range
 		for _, cidr := range association.LbInboundCIDRs {
+			description := aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, aws.StringValue(cidr)))
+			if isIPv6CIDR(aws.StringValue(cidr)) {
+				ipv6Ranges = append(ipv6Ranges, &ec2.Ipv6Range{
+					CidrIpv6:    cidr,
+					Description: description,
+				})
+				continue
+			}
 			ipRanges = append(ipRanges, &ec2.IpRange{
 				CidrIp:      cidr,
-				Description: aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, aws.StringValue(cidr))),
+				Description: description,
 			})
 		}
 		permission := &ec2.IpPermission{
@@ -128,6 +138,7 @@ func (controller *associationController) reconcileManagedLbSG(ctx context.Contex
 			FromPort:   aws.Int64(port),
 			ToPort:     aws.Int64(port),
 			IpRanges:   ipRanges,
+			Ipv6Ranges: ipv6Ranges,
 		}
 		lbSG.InboundPermissions = append(lbSG.InboundPermissions, permission)
 	}
@@ -258,3 +269,8 @@ func (controller *associationController) findSGIDByName(sgName string) (*string,
 	}
 	return nil, nil
 }
+
+// isIPv6CIDR returns true if the CIDR is an IPv6 CIDR, like ::/0
+func isIPv6CIDR(cidr string) bool {
+	return strings.Contains(cidr, ":")
+}
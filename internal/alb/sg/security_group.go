@@ -204,6 +204,12 @@ func ipPermissionEquals(source *ec2.IpPermission, target *ec2.IpPermission) bool
 	if len(diffIPRanges(target.IpRanges, source.IpRanges)) != 0 {
 		return false
 	}
+	if len(diffIPv6Ranges(source.Ipv6Ranges, target.Ipv6Ranges)) != 0 {
+		return false
+	}
+	if len(diffIPv6Ranges(target.Ipv6Ranges, source.Ipv6Ranges)) != 0 {
+		return false
+	}
 	if len(diffUserIDGroupPairs(source.UserIdGroupPairs, target.UserIdGroupPairs)) != 0 {
 		return false
 	}
@@ -236,6 +242,28 @@ func ipRangeEquals(source *ec2.IpRange, target *ec2.IpRange) bool {
 	return aws.StringValue(source.CidrIp) == aws.StringValue(target.CidrIp)
 }
 
+// diffIPv6Ranges calcutes set_difference as source - target
+func diffIPv6Ranges(source []*ec2.Ipv6Range, target []*ec2.Ipv6Range) (diffs []*ec2.Ipv6Range) {
+	for _, sRange := range source {
+		containsInTarget := false
+		for _, tRange := range target {
+			if ipv6RangeEquals(sRange, tRange) {
+				containsInTarget = true
+				break
+			}
+		}
+		if containsInTarget == false {
+			diffs = append(diffs, sRange)
+		}
+	}
+	return diffs
+}
+
+// ipv6RangeEquals test whether two Ipv6Range instance are equals
+func ipv6RangeEquals(source *ec2.Ipv6Range, target *ec2.Ipv6Range) bool {
+	return aws.StringValue(source.CidrIpv6) == aws.StringValue(target.CidrIpv6)
+}
+
 // diffUserIDGroupPairs calcutes set_difference as source - target
 func diffUserIDGroupPairs(source []*ec2.UserIdGroupPair, target []*ec2.UserIdGroupPair) (diffs []*ec2.UserIdGroupPair) {
 	for _, sPair := range source {
@@ -1306,6 +1306,44 @@ func TestDiffIPPermissions(t *testing.T) {
 				},
 			},
 		},
+		{
+			source: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					Ipv6Ranges: []*ec2.Ipv6Range{
+						{
+							CidrIpv6: aws.String("::/0"),
+						},
+					},
+				},
+			},
+			target: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					Ipv6Ranges: []*ec2.Ipv6Range{
+						{
+							CidrIpv6: aws.String("2600:1f14:abc:de00::/56"),
+						},
+					},
+				},
+			},
+			expectedDiffs: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					Ipv6Ranges: []*ec2.Ipv6Range{
+						{
+							CidrIpv6: aws.String("::/0"),
+						},
+					},
+				},
+			},
+		},
 	} {
 		actualDiffs := diffIPPermissions(tc.source, tc.target)
 		if !reflect.DeepEqual(tc.expectedDiffs, actualDiffs) {
@@ -13,15 +13,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 )
 
 // Standard tag key names
 const (
-	IngressName = "kubernetes.io/ingress-name"
-	Namespace   = "kubernetes.io/namespace"
-	ServiceName = "kubernetes.io/service-name"
-	ServicePort = "kubernetes.io/service-port"
+	IngressName  = "kubernetes.io/ingress-name"
+	IngressGroup = "kubernetes.io/ingress-group"
+	Namespace    = "kubernetes.io/namespace"
+	ServiceName  = "kubernetes.io/service-name"
+	ServicePort  = "kubernetes.io/service-port"
 )
 
 // Tags stores the tags for an ARN
@@ -89,7 +91,7 @@ func (c *controller) Reconcile(ctx context.Context, desired *Tags) error {
 			Tags:            aws.StringMap(modify),
 		}
 		if _, err := c.rgt.TagResources(p); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error tagging %s: %s", desired.Arn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTags.Reason(), "Error tagging %s: %s", desired.Arn, err.Error())
 			return err
 		}
 	}
@@ -102,7 +104,7 @@ func (c *controller) Reconcile(ctx context.Context, desired *Tags) error {
 			TagKeys:         aws.StringSlice(remove),
 		}
 		if _, err := c.rgt.UntagResources(p); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error tagging %s: %s", desired.Arn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTags.Reason(), "Error tagging %s: %s", desired.Arn, err.Error())
 			return err
 		}
 	}
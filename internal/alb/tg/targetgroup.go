@@ -19,6 +19,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albrgt"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
@@ -38,6 +39,7 @@ type NewDesiredTargetGroupOptions struct {
 	Store          store.Storer
 	LoadBalancerID string
 	Backend        *extensions.IngressBackend
+	IngressGroup   bool
 }
 
 // NewDesiredTargetGroup returns a new targetgroup.TargetGroup based on the parameters provided.
@@ -56,13 +58,22 @@ func NewDesiredTargetGroup(o *NewDesiredTargetGroupOptions) (*TargetGroup, error
 		return nil, err
 	}
 
+	targetType := aws.StringValue(o.Annotations.TargetGroup.TargetType)
+	ipAddressType := aws.StringValue(o.Annotations.TargetGroup.IPAddressType)
+	if ipAddressType == elbv2.TargetGroupIpAddressTypeEnumIpv6 && targetType != elbv2.TargetTypeEnumIp {
+		return nil, fmt.Errorf("target group of service %s/%s can't use the %s IP address type with the %s target type, use the %s target type", o.Ingress.Namespace, o.Backend.ServiceName, ipAddressType, targetType, elbv2.TargetTypeEnumIp)
+	}
+	targets := NewTargets(targetType, o.Ingress, o.Backend)
+	targets.IPAddressType = ipAddressType
+
 	return &TargetGroup{
-		ID:         id,
-		SvcName:    o.Backend.ServiceName,
-		SvcPort:    o.Backend.ServicePort,
-		TargetType: aws.StringValue(o.Annotations.TargetGroup.TargetType),
-		tags:       tgTags,
-		targets:    NewTargets(aws.StringValue(o.Annotations.TargetGroup.TargetType), o.Ingress, o.Backend),
+		ID:           id,
+		SvcNamespace: o.Ingress.Namespace,
+		SvcName:      o.Backend.ServiceName,
+		SvcPort:      o.Backend.ServicePort,
+		TargetType:   targetType,
+		tags:         tgTags,
+		targets:      targets,
 		tg: tg{
 			desired: &elbv2.TargetGroup{
 				HealthCheckPath:            o.Annotations.HealthCheck.Path,
@@ -71,6 +82,7 @@ func NewDesiredTargetGroup(o *NewDesiredTargetGroupOptions) (*TargetGroup, error
 				HealthCheckProtocol:        o.Annotations.HealthCheck.Protocol,
 				HealthCheckTimeoutSeconds:  o.Annotations.HealthCheck.TimeoutSeconds,
 				HealthyThresholdCount:      o.Annotations.TargetGroup.HealthyThresholdCount,
+				IpAddressType:              o.Annotations.TargetGroup.IPAddressType,
 				// LoadBalancerArns:
 				Matcher:                 &elbv2.Matcher{HttpCode: o.Annotations.TargetGroup.SuccessCodes},
 				Port:                    aws.Int64(targetGroupDefaultPort),
@@ -88,6 +100,10 @@ func NewDesiredTargetGroup(o *NewDesiredTargetGroupOptions) (*TargetGroup, error
 func (o *NewDesiredTargetGroupOptions) generateID() (string, error) {
 	hasher := md5.New()
 	hasher.Write([]byte(o.LoadBalancerID))
+	if o.IngressGroup {
+		// members of an ingress group may live in different namespaces and reference services by the same name
+		hasher.Write([]byte(o.Ingress.Namespace))
+	}
 	if o.Backend == nil {
 		return "", fmt.Errorf("generateID called without a backend service. this should not happen")
 	}
@@ -98,6 +114,10 @@ func (o *NewDesiredTargetGroupOptions) generateID() (string, error) {
 	hasher.Write([]byte(o.Backend.ServicePort.String()))
 	hasher.Write([]byte(aws.StringValue(o.Annotations.TargetGroup.BackendProtocol)))
 	hasher.Write([]byte(aws.StringValue(o.Annotations.TargetGroup.TargetType)))
+	// the IP address type of a target group can't be modified, IPv6 target groups are new target groups
+	if aws.StringValue(o.Annotations.TargetGroup.IPAddressType) == elbv2.TargetGroupIpAddressTypeEnumIpv6 {
+		hasher.Write([]byte(elbv2.TargetGroupIpAddressTypeEnumIpv6))
+	}
 
 	return fmt.Sprintf("%.12s-%.19s", o.Store.GetConfig().ALBNamePrefix, hex.EncodeToString(hasher.Sum(nil))), nil
 }
@@ -108,6 +128,7 @@ type NewDesiredTargetGroupFromBackendOptions struct {
 	LoadBalancerID       string
 	Store                store.Storer
 	Ingress              *extensions.Ingress
+	IngressGroup         bool
 	ExistingTargetGroups TargetGroups
 }
 
@@ -132,6 +153,7 @@ func NewDesiredTargetGroupFromBackend(o *NewDesiredTargetGroupFromBackendOptions
 		Store:          o.Store,
 		LoadBalancerID: o.LoadBalancerID,
 		Backend:        o.Backend,
+		IngressGroup:   o.IngressGroup,
 	})
 	if err != nil {
 		return nil, err
@@ -223,7 +245,7 @@ func (t *TargetGroup) Reconcile(ctx context.Context, rOpts *ReconcileOptions) er
 		t.attributes.TgArn = aws.StringValue(t.tg.current.TargetGroupArn)
 		err := rOpts.TgAttributesController.Reconcile(ctx, t.attributes)
 		if err != nil {
-			return fmt.Errorf("failed configuration of target group attributes due to %s", err.Error())
+			return errors.NewReconcileError(errors.StepAttributes, fmt.Errorf("failed configuration of target group attributes due to %s", err.Error()))
 		}
 		t.targets.TgArn = aws.StringValue(t.tg.current.TargetGroupArn)
 		err = rOpts.TgTargetsController.Reconcile(ctx, t.targets)
@@ -233,7 +255,7 @@ func (t *TargetGroup) Reconcile(ctx context.Context, rOpts *ReconcileOptions) er
 		t.tags.Arn = aws.StringValue(t.tg.current.TargetGroupArn)
 		err = rOpts.TagsController.Reconcile(ctx, t.tags)
 		if err != nil {
-			return fmt.Errorf("failed configuration of target group tags due to %s", err.Error())
+			return errors.NewReconcileError(errors.StepTags, fmt.Errorf("failed configuration of target group tags due to %s", err.Error()))
 		}
 
 	}
@@ -255,6 +277,7 @@ func (t *TargetGroup) create(ctx context.Context, rOpts *ReconcileOptions) error
 		HealthCheckProtocol:        desired.HealthCheckProtocol,
 		HealthCheckTimeoutSeconds:  desired.HealthCheckTimeoutSeconds,
 		HealthyThresholdCount:      desired.HealthyThresholdCount,
+		IpAddressType:              desired.IpAddressType,
 		Matcher:                    desired.Matcher,
 		Port:                       desired.Port,
 		Protocol:                   desired.Protocol,
@@ -266,7 +289,7 @@ func (t *TargetGroup) create(ctx context.Context, rOpts *ReconcileOptions) error
 
 	o, err := albelbv2.ELBV2svc.CreateTargetGroup(in)
 	if err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error creating target group %s: %s", t.ID, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTargetGroup.Reason(), "Error creating target group %s: %s", t.ID, err.Error())
 		return fmt.Errorf("Failed TargetGroup creation: %s.", err.Error())
 	}
 	t.tg.current = o.TargetGroups[0]
@@ -292,7 +315,7 @@ func (t *TargetGroup) modify(ctx context.Context, mods tgChange, rOpts *Reconcil
 			UnhealthyThresholdCount:    desired.UnhealthyThresholdCount,
 		})
 		if err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error modifying target group %s: %s", t.ID, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTargetGroup.Reason(), "Error modifying target group %s: %s", t.ID, err.Error())
 			return fmt.Errorf("Failed TargetGroup modification. ARN: %s | Error: %s",
 				*t.CurrentARN(), err.Error())
 		}
@@ -307,7 +330,7 @@ func (t *TargetGroup) modify(ctx context.Context, mods tgChange, rOpts *Reconcil
 // delete a TargetGroup.
 func (t *TargetGroup) delete(ctx context.Context, rOpts *ReconcileOptions) error {
 	if err := albelbv2.ELBV2svc.RemoveTargetGroup(t.CurrentARN()); err != nil {
-		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error deleting %v target group: %s", t.ID, err.Error())
+		albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTargetGroup.Reason(), "Error deleting %v target group: %s", t.ID, err.Error())
 		return err
 	}
 	t.deleted = true
@@ -396,5 +419,6 @@ func (t *TargetGroup) copyDesiredState(s *TargetGroup) {
 	t.targets = s.targets
 
 	t.tg.desired = s.tg.desired
+	t.SvcNamespace = s.SvcNamespace
 	t.TargetType = s.TargetType
 }
@@ -10,16 +10,17 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
 
-// LookupByBackend returns the position of a TargetGroup by an IngressBackend, returning -1 if unfound.
-func (t TargetGroups) LookupByBackend(backend extensions.IngressBackend) int {
+// LookupByBackend returns the position of a TargetGroup by the namespace and IngressBackend, returning -1 if unfound.
+func (t TargetGroups) LookupByBackend(namespace string, backend extensions.IngressBackend) int {
 	for p, v := range t {
 		if v == nil {
 			continue
 		}
 
-		if v.SvcName == backend.ServiceName && v.tg.desired != nil && v.SvcPort.String() == backend.ServicePort.String() {
+		if v.SvcNamespace == namespace && v.SvcName == backend.ServiceName && v.tg.desired != nil && v.SvcPort.String() == backend.ServicePort.String() {
 			return p
 		}
 	}
@@ -97,7 +98,10 @@ func NewCurrentTargetGroups(o *NewCurrentTargetGroupsOptions) (TargetGroups, err
 }
 
 type NewDesiredTargetGroupsOptions struct {
-	Ingress              *extensions.Ingress
+	Ingress *extensions.Ingress
+	// Ingresses lists the members of an ingress group sharing the load balancer. When set, target
+	// groups are created for the backends of every member and Ingress is ignored.
+	Ingresses            []*extensions.Ingress
 	LoadBalancerID       string
 	ExistingTargetGroups TargetGroups
 	Store                store.Storer
@@ -106,35 +110,69 @@ type NewDesiredTargetGroupsOptions struct {
 
 // NewDesiredTargetGroups returns a new targetgroups.TargetGroups based on an extensions.Ingress.
 func NewDesiredTargetGroups(o *NewDesiredTargetGroupsOptions) (TargetGroups, error) {
-	var backends []*extensions.IngressBackend
-	if o.Ingress.Spec.Backend != nil {
-		backends = append(backends, o.Ingress.Spec.Backend)
+	type ingressBackend struct {
+		ingress *extensions.Ingress
+		backend *extensions.IngressBackend
 	}
-	for _, rule := range o.Ingress.Spec.Rules {
-		for i := range rule.HTTP.Paths {
-			backends = append(backends, &rule.HTTP.Paths[i].Backend)
+
+	ingresses := o.Ingresses
+	if len(ingresses) == 0 {
+		ingresses = []*extensions.Ingress{o.Ingress}
+	}
+
+	var backends []ingressBackend
+	for _, ing := range ingresses {
+		if ing.Spec.Backend != nil {
+			backends = append(backends, ingressBackend{ing, ing.Spec.Backend})
+		}
+		for _, rule := range ing.Spec.Rules {
+			for i := range rule.HTTP.Paths {
+				backends = append(backends, ingressBackend{ing, &rule.HTTP.Paths[i].Backend})
+			}
+		}
+	}
+
+	// weighted forward actions send requests to the target groups of several backends
+	var weightedBackends []ingressBackend
+	for _, b := range backends {
+		if !action.Use(b.backend.ServicePort.String()) {
+			continue
+		}
+		annos, err := o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(b.ingress))
+		if err != nil {
+			return o.ExistingTargetGroups, err
+		}
+		a, err := annos.Action.GetAction(b.backend.ServiceName)
+		if err != nil {
+			return o.ExistingTargetGroups, err
+		}
+		for _, backend := range a.Backends() {
+			backend := backend
+			weightedBackends = append(weightedBackends, ingressBackend{b.ingress, &backend})
 		}
 	}
+	backends = append(backends, weightedBackends...)
 
 	var targetGroupsInUse TargetGroups
 	backendsProcessed := make(map[string]bool)
-	for _, backend := range backends {
-		if action.Use(backend.ServicePort.String()) {
-			// action annotations do not need target groups
+	for _, b := range backends {
+		if action.Use(b.backend.ServicePort.String()) {
+			// other action annotations do not need target groups
 			continue
 		}
-		backendName := backend.ServiceName + ":" + backend.ServicePort.String()
+		backendName := b.ingress.Namespace + "/" + b.backend.ServiceName + ":" + b.backend.ServicePort.String()
 		if _, ok := backendsProcessed[backendName]; ok {
 			continue
 		}
 		backendsProcessed[backendName] = true
 
 		targetGroup, err := NewDesiredTargetGroupFromBackend(&NewDesiredTargetGroupFromBackendOptions{
-			Backend:              backend,
+			Backend:              b.backend,
 			CommonTags:           o.CommonTags,
 			LoadBalancerID:       o.LoadBalancerID,
 			Store:                o.Store,
-			Ingress:              o.Ingress,
+			Ingress:              b.ingress,
+			IngressGroup:         len(o.Ingresses) > 0,
 			ExistingTargetGroups: o.ExistingTargetGroups,
 		})
 
@@ -5,10 +5,13 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func init() {
@@ -33,3 +36,63 @@ func TestNewDesiredTargetGroups(t *testing.T) {
 		t.Errorf("%v target groups were expected, got %v", expected, len(tg))
 	}
 }
+
+func TestNewDesiredTargetGroupsForIngressGroup(t *testing.T) {
+	ing1 := dummy.NewIngress()
+	ing2 := dummy.NewIngress()
+	ing2.Namespace = "other"
+
+	tgs, err := NewDesiredTargetGroups(&NewDesiredTargetGroupsOptions{
+		Ingresses:      []*extensions.Ingress{ing1, ing2},
+		LoadBalancerID: "lbid",
+		Store:          store.NewDummy(),
+		CommonTags:     tags.NewTags(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := 2 * (len(ing1.Spec.Rules) + 1) // services of the same name in different namespaces
+	if len(tgs) != expected {
+		t.Errorf("%v target groups were expected, got %v", expected, len(tgs))
+	}
+
+	backend := ing2.Spec.Rules[0].HTTP.Paths[0].Backend
+	i1 := tgs.LookupByBackend(ing1.Namespace, backend)
+	i2 := tgs.LookupByBackend(ing2.Namespace, backend)
+	if i1 < 0 || i2 < 0 || tgs[i1].ID == tgs[i2].ID {
+		t.Errorf("expected distinct target groups per namespace for %v", backend.ServiceName)
+	}
+}
+
+func TestNewDesiredTargetGroupsForWeightedAction(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.Spec.Rules[0].HTTP.Paths[0].Backend = extensions.IngressBackend{
+		ServiceName: "weighted-routing",
+		ServicePort: intstr.FromString(action.UseActionAnnotation),
+	}
+
+	tgs, err := NewDesiredTargetGroups(&NewDesiredTargetGroupsOptions{
+		Ingress:        ing,
+		LoadBalancerID: "lbid",
+		Store:          store.NewDummy(),
+		CommonTags:     tags.NewTags(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := len(ing.Spec.Rules) + 2 // the action forwards to two services, +1 for default backend
+	if len(tgs) != expected {
+		t.Errorf("%v target groups were expected, got %v", expected, len(tgs))
+	}
+
+	for _, backend := range []extensions.IngressBackend{
+		{ServiceName: "service-blue", ServicePort: intstr.FromInt(80)},
+		{ServiceName: "service-green", ServicePort: intstr.FromString("http")},
+	} {
+		if tgs.LookupByBackend(ing.Namespace, backend) < 0 {
+			t.Errorf("expected a target group for %v:%v", backend.ServiceName, backend.ServicePort.String())
+		}
+	}
+}
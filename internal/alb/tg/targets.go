@@ -3,6 +3,7 @@ package tg
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 )
@@ -19,12 +21,16 @@ type Targets struct {
 	// TgArn is the ARN of the target group
 	TgArn string
 
-	// Targets are the targets for the target group
+	// Targets are the targets for the target group, as registered by the last reconciliation
 	Targets []*elbv2.TargetDescription
 
 	// TargetType is the type of targets, either ip or instance
 	TargetType string
 
+	// IPAddressType is the IP address type of the target group, either ipv4 or ipv6. Only the pod IPs
+	// of that family are registered as ip targets.
+	IPAddressType string
+
 	// Ingress is the ingress for the targets
 	Ingress *extensions.Ingress
 
@@ -65,6 +71,13 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 	if err != nil {
 		return err
 	}
+	if t.TargetType == elbv2.TargetTypeEnumIp {
+		var skipped []*elbv2.TargetDescription
+		desired, skipped = ipTargetsOfType(desired, t.IPAddressType)
+		if len(skipped) > 0 {
+			albctx.GetLogger(ctx).Warnf("Skipping targets of another IP address type than %v: %v", t.IPAddressType, tdsString(skipped))
+		}
+	}
 	current, err := c.getCurrentTargets(t.TgArn)
 	if err != nil {
 		return err
@@ -79,7 +92,7 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 
 		if _, err := c.elbv2.RegisterTargets(in); err != nil {
 			albctx.GetLogger(ctx).Errorf("Error adding targets to %v: %v", t.TgArn, err.Error())
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error adding targets to target group %s: %s", t.TgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTargetGroup.Reason(), "Error adding targets to target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
 	}
@@ -93,10 +106,11 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 
 		if _, err := c.elbv2.DeregisterTargets(in); err != nil {
 			albctx.GetLogger(ctx).Errorf("Error removing targets from %v: %v", t.TgArn, err.Error())
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error removing targets from target group %s: %s", t.TgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, errors.StepTargetGroup.Reason(), "Error removing targets from target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
 	}
+	t.Targets = desired
 	return nil
 }
 
@@ -117,6 +131,21 @@ func (c *targetsController) getCurrentTargets(TgArn string) ([]*elbv2.TargetDesc
 	return current, nil
 }
 
+// ipTargetsOfType splits the ip targets between the targets which can be registered in a target group of
+// the IP address type, ipv4 when empty, and the others
+func ipTargetsOfType(targets []*elbv2.TargetDescription, ipAddressType string) (matching []*elbv2.TargetDescription, others []*elbv2.TargetDescription) {
+	for _, t := range targets {
+		ip := net.ParseIP(aws.StringValue(t.Id))
+		ipv6 := ip != nil && ip.To4() == nil
+		if ipv6 == (ipAddressType == elbv2.TargetGroupIpAddressTypeEnumIpv6) {
+			matching = append(matching, t)
+		} else {
+			others = append(others, t)
+		}
+	}
+	return matching, others
+}
+
 // targetChangeSets compares b to a, returning a list of targets to add and remove from a to match b
 func targetChangeSets(current, desired []*elbv2.TargetDescription) (add []*elbv2.TargetDescription, remove []*elbv2.TargetDescription) {
 	currentMap := map[string]bool{}
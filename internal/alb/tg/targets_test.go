@@ -277,6 +277,34 @@ func Test_targetChangeSets(t *testing.T) {
 	}
 }
 
+func Test_ipTargetsOfType(t *testing.T) {
+	targets := []*elbv2.TargetDescription{newTd("192.168.1.1", 80), newTd("2600:1f14:abc:de01::1", 80)}
+	for _, tc := range []struct {
+		name          string
+		ipAddressType string
+		matching      []*elbv2.TargetDescription
+		others        []*elbv2.TargetDescription
+	}{
+		{
+			name:     "default ipv4 target group",
+			matching: []*elbv2.TargetDescription{newTd("192.168.1.1", 80)},
+			others:   []*elbv2.TargetDescription{newTd("2600:1f14:abc:de01::1", 80)},
+		},
+		{
+			name:          "ipv6 target group",
+			ipAddressType: elbv2.TargetGroupIpAddressTypeEnumIpv6,
+			matching:      []*elbv2.TargetDescription{newTd("2600:1f14:abc:de01::1", 80)},
+			others:        []*elbv2.TargetDescription{newTd("192.168.1.1", 80)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			matching, others := ipTargetsOfType(targets, tc.ipAddressType)
+			assert.Equal(t, tc.matching, matching, "matching targets not as expected")
+			assert.Equal(t, tc.others, others, "other targets not as expected")
+		})
+	}
+}
+
 func Test_tdsString(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
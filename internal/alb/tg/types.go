@@ -12,10 +12,11 @@ type TargetGroups []*TargetGroup
 
 // TargetGroup contains the current & desired configuration
 type TargetGroup struct {
-	ID         string
-	SvcName    string
-	SvcPort    intstr.IntOrString
-	TargetType string
+	ID           string
+	SvcNamespace string
+	SvcName      string
+	SvcPort      intstr.IntOrString
+	TargetType   string
 
 	tg         tg
 	attributes *Attributes
@@ -9,7 +9,9 @@ import (
 
 	"github.com/cenkalti/backoff"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albrgt"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 
 	// k8saws "k8s.io/kubernetes/pkg/cloudprovider/providers/aws"
@@ -18,6 +20,7 @@ import (
 
 	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
@@ -29,9 +32,14 @@ import (
 const MaxRetryTime = 2 * time.Hour
 const restrictIngressConfigMap = "alb-ingress-controller-internet-facing-ingresses"
 
+// ForceReconcileAnnotation is the suffix of the annotation retrying an ingress at the next sync when its
+// value changes, without waiting for the backoff of the previous failures
+const ForceReconcileAnnotation = "force-reconcile"
+
 type NewALBIngressOptions struct {
 	Namespace  string
 	Name       string
+	GroupName  string
 	Ingress    *extensions.Ingress
 	Recorder   record.EventRecorder
 	Reconciled bool
@@ -43,9 +51,14 @@ type NewALBIngressOptions struct {
 // exists.
 func NewALBIngress(o *NewALBIngressOptions) *ALBIngress {
 	var id string
-	if o.Ingress != nil {
+	switch {
+	case o.GroupName != "":
+		id = groupID(o.GroupName)
+		o.Namespace = ""
+		o.Name = ""
+	case o.Ingress != nil:
 		id = k8s.MetaNamespaceKey(o.Ingress)
-	} else {
+	default:
 		id = fmt.Sprintf(o.Namespace + "/" + o.Name)
 	}
 
@@ -59,6 +72,7 @@ func NewALBIngress(o *NewALBIngressOptions) *ALBIngress {
 		id:          id,
 		namespace:   o.Namespace,
 		ingressName: o.Name,
+		groupName:   o.GroupName,
 		lock:        new(sync.Mutex),
 		logger:      log.New(id),
 		store:       o.Store,
@@ -76,6 +90,10 @@ type NewALBIngressFromIngressOptions struct {
 	ExistingIngress *ALBIngress
 	Recorder        record.EventRecorder
 	Store           store.Storer
+	// GroupName and Ingresses are set when assembling an ingress group. Ingresses holds every
+	// member in group order and Ingress is the first of them.
+	GroupName string
+	Ingresses []*extensions.Ingress
 }
 
 // NewALBIngressFromIngress builds ALBIngress's based off of an Ingress object
@@ -89,12 +107,20 @@ func NewALBIngressFromIngress(o *NewALBIngressFromIngressOptions) (*ALBIngress,
 	newIngress := NewALBIngress(&NewALBIngressOptions{
 		Namespace: o.Ingress.GetNamespace(),
 		Name:      o.Ingress.Name,
+		GroupName: o.GroupName,
 		Recorder:  o.Recorder,
 		Ingress:   o.Ingress,
 		Store:     o.Store,
 	})
+	newIngress.members = o.Ingresses
+	forceReconcile := forceReconcileRequests(newIngress.ingresses())
 
 	if o.ExistingIngress != nil {
+		if o.ExistingIngress.forceReconcile != forceReconcile {
+			// the force-reconcile annotation was changed to retry the ingress without waiting for the backoff
+			o.ExistingIngress.forceReconcile = forceReconcile
+			o.ExistingIngress.Retry()
+		}
 		if !o.ExistingIngress.ready() && !o.ExistingIngress.valid {
 			// silently fail to assemble the ingress if we are not ready to retry it
 			o.ExistingIngress.reconciled = false
@@ -108,16 +134,19 @@ func NewALBIngressFromIngress(o *NewALBIngressFromIngressOptions) (*ALBIngress,
 		defer newIngress.lock.Unlock()
 		// reattach k8s ingress as if assembly happened through aws sync, it may be missing.
 		newIngress.ingress = o.Ingress
+		newIngress.members = o.Ingresses
+		newIngress.deleting = nil
 		// Ensure all desired state is removed from the copied ingress. The desired state of each
 		// component will be generated later in this function.
 		newIngress.stripDesiredState()
 		newIngress.valid = false
 	}
+	newIngress.forceReconcile = forceReconcile
 
 	newIngress.reconciled = false
 
 	// Load up the ingress with our current annotations.
-	newIngress.annotations, err = o.Store.GetIngressAnnotations(newIngress.id)
+	newIngress.annotations, err = o.Store.GetIngressAnnotations(k8s.MetaNamespaceKey(o.Ingress))
 	if err != nil {
 		if _, ok := err.(store.NotExistsError); ok {
 			newIngress.resetBackoff() // Don't blame the ingress for the annotations not being in sync
@@ -126,6 +155,12 @@ func NewALBIngressFromIngress(o *NewALBIngressFromIngressOptions) (*ALBIngress,
 		return newIngress, fmt.Errorf("error parsing annotations: %s", err.Error())
 	}
 
+	for _, ing := range newIngress.ingresses() {
+		if reason := ing.Annotations[k8s.UnsupportedKey]; reason != "" {
+			return newIngress, fmt.Errorf("ingress %s can't be served: %s", k8s.MetaNamespaceKey(ing), reason)
+		}
+	}
+
 	lbTags := tags.NewTags()
 	lbTags.Tags = newIngress.Tags()
 	for k, v := range newIngress.annotations.Tags.LoadBalancer {
@@ -140,7 +175,7 @@ func NewALBIngressFromIngress(o *NewALBIngressFromIngressOptions) (*ALBIngress,
 
 		}
 		if !allowed {
-			return newIngress, fmt.Errorf("ingress %s/%s is not allowed to be internet-facing", o.Ingress.GetNamespace(), o.Ingress.Name)
+			return newIngress, fmt.Errorf("ingress %s is not allowed to be internet-facing", newIngress.id)
 		}
 	}
 
@@ -150,6 +185,8 @@ func NewALBIngressFromIngress(o *NewALBIngressFromIngressOptions) (*ALBIngress,
 		Ingress:              o.Ingress,
 		Store:                o.Store,
 		CommonTags:           lbTags,
+		GroupName:            o.GroupName,
+		Ingresses:            o.Ingresses,
 	})
 
 	if err != nil {
@@ -208,19 +245,24 @@ func NewALBIngressFromAWSLoadBalancer(o *NewALBIngressFromAWSLoadBalancerOptions
 		return nil, fmt.Errorf("Failed to get AWS tags. Error: %s", err.Error())
 	}
 
-	namespace, ingressName, err := tagsFromIngress(resourceTags.LoadBalancers[*o.LoadBalancer.LoadBalancerArn])
-	if err != nil {
-		return nil, fmt.Errorf("The LoadBalancer %s does not have the proper tags, can't import: %s", *o.LoadBalancer.LoadBalancerName, err.Error())
-	}
-
-	// Assemble ingress
-	ingress := NewALBIngress(&NewALBIngressOptions{
-		Namespace:  namespace,
-		Name:       ingressName,
+	ingressOpts := &NewALBIngressOptions{
 		Recorder:   o.Recorder,
 		Store:      o.Store,
 		Reconciled: true,
-	})
+	}
+
+	lbTags := resourceTags.LoadBalancers[*o.LoadBalancer.LoadBalancerArn]
+	if groupName, ok := lbTags.Get(tags.IngressGroup); ok {
+		ingressOpts.GroupName = groupName
+	} else {
+		ingressOpts.Namespace, ingressOpts.Name, err = tagsFromIngress(lbTags)
+		if err != nil {
+			return nil, fmt.Errorf("The LoadBalancer %s does not have the proper tags, can't import: %s", *o.LoadBalancer.LoadBalancerName, err.Error())
+		}
+	}
+
+	// Assemble ingress
+	ingress := NewALBIngress(ingressOpts)
 
 	// Assemble load balancer
 	ingress.loadBalancer, err = lb.NewCurrentLoadBalancer(&lb.NewCurrentLoadBalancerOptions{
@@ -237,12 +279,75 @@ func NewALBIngressFromAWSLoadBalancer(o *NewALBIngressFromAWSLoadBalancerOptions
 	return ingress, nil
 }
 
-// Eventf writes an event to the ALBIngress's Kubernetes ingress resource
+// NewDeletingALBIngressOptions are the options to NewDeletingALBIngress
+type NewDeletingALBIngressOptions struct {
+	ExistingIngress *ALBIngress
+	Recorder        record.EventRecorder
+	Store           store.Storer
+	// GroupName is set when every member of an ingress group is being deleted
+	GroupName string
+	Ingresses []*extensions.Ingress
+}
+
+// NewDeletingALBIngress returns an ALBIngress removing the AWS resources of deleted ingresses. The
+// finalizer of the ingresses is removed once its load balancer was deleted.
+func NewDeletingALBIngress(o *NewDeletingALBIngressOptions) *ALBIngress {
+	a := o.ExistingIngress
+	if a == nil {
+		a = NewALBIngress(&NewALBIngressOptions{
+			GroupName: o.GroupName,
+			Ingress:   o.Ingresses[0],
+			Recorder:  o.Recorder,
+			Store:     o.Store,
+		})
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.deleting == nil || len(a.ingresses()) > 0 {
+		// first attempt to delete the resources, don't wait for the backoff of a failed creation
+		a.resetBackoff()
+	}
+	a.ingress = nil
+	a.members = nil
+	a.deleting = o.Ingresses
+	a.stripDesiredState()
+	a.reconciled = false
+	a.valid = true
+	return a
+}
+
+// Eventf writes an event to the ALBIngress's Kubernetes ingress resource, or to every member of
+// its ingress group, and to the deleted ingresses waiting for its reconciliation
 func (a *ALBIngress) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
-	if a.ingress == nil || a.recorder == nil {
+	if a.recorder == nil {
+		return
+	}
+	for _, ing := range a.ingresses() {
+		a.recorder.Eventf(ing, eventtype, reason, messageFmt, args...)
+	}
+	a.deletingEventf(eventtype, reason, messageFmt, args...)
+}
+
+// deletingEventf writes an event to the deleted ingresses waiting for the reconciliation of the ALBIngress
+func (a *ALBIngress) deletingEventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	if a.recorder == nil {
 		return
 	}
-	a.recorder.Eventf(a.ingress, eventtype, reason, messageFmt, args...)
+	for _, ing := range a.deleting {
+		a.recorder.Eventf(ing, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// ingresses returns the Kubernetes ingress resources making up the ALBIngress
+func (a *ALBIngress) ingresses() []*extensions.Ingress {
+	if a.groupName != "" {
+		return a.members
+	}
+	if a.ingress == nil {
+		return nil
+	}
+	return []*extensions.Ingress{a.ingress}
 }
 
 // Hostnames returns the AWS hostnames for the load balancer
@@ -283,30 +388,106 @@ func (a *ALBIngress) Reconcile(ctx context.Context, rOpts *ReconcileOptions) err
 		return nil
 	}
 
-	errors := a.loadBalancer.Reconcile(ctx,
-		&lb.ReconcileOptions{
-			Store:                   rOpts.Store,
-			Ingress:                 a.ingress,
-			SgAssociationController: rOpts.SgAssociationController,
-			LbAttributesController:  rOpts.LbAttributesController,
-			TgAttributesController:  rOpts.TgAttributesController,
-			TgTargetsController:     rOpts.TgTargetsController,
-			TagsController:          rOpts.TagsController,
-		})
-	if len(errors) > 0 {
+	// the finalizer must be in place before any AWS resource is created
+	if err := a.addFinalizers(); err != nil {
+		a.reconciled = false
+		a.setReconcileErrors(errors.NewReconcileError(errors.StepFinalizer, err))
+		a.Eventf(api.EventTypeWarning, errors.StepFinalizer.Reason(), "Error adding finalizer: %s", err.Error())
+		a.incrementBackoff()
+		a.logger.Errorf("Will retry to reconcile in %v", a.nextAttempt)
+		return fmt.Errorf("Failed to add finalizer: %s", err.Error())
+	}
+
+	// the records of the hosts are deleted before the load balancer they point to
+	deleting := len(a.ingresses()) == 0
+	var errs []error
+	if deleting {
+		if err := a.reconcileRecords(ctx, rOpts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if a.loadBalancer != nil {
+		errs = append(errs, a.loadBalancer.Reconcile(ctx,
+			&lb.ReconcileOptions{
+				Store:                   rOpts.Store,
+				SgAssociationController: rOpts.SgAssociationController,
+				LbAttributesController:  rOpts.LbAttributesController,
+				TgAttributesController:  rOpts.TgAttributesController,
+				TgTargetsController:     rOpts.TgTargetsController,
+				TagsController:          rOpts.TagsController,
+			})...)
+	}
+	if !deleting {
+		if err := a.reconcileRecords(ctx, rOpts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
 		// marks reconciled state as false so UpdateIngressStatus won't operate
 		a.reconciled = false
+		a.setReconcileErrors(errs...)
 		a.logger.Errorf("Failed to reconcile state on this ingress")
-		for _, err := range errors {
-			a.logger.Errorf(" - %s", err.Error())
+		for _, err := range errs {
+			a.logger.Errorf(" - %s: %s", errors.Reason(err), err.Error())
 		}
 		a.incrementBackoff()
 		a.logger.Errorf("Will retry to reconcile in %v", a.nextAttempt)
-		return fmt.Errorf("Reconcile failed")
+		a.deletingEventf(api.EventTypeWarning, errors.Reason(errs[0]), "Failed to remove AWS resources, keeping finalizer %s. Will retry in %v", Finalizer, a.nextAttempt)
+		if len(errs) == 1 {
+			return errs[0]
+		}
+		return utilerrors.NewAggregate(errs)
 	}
 	// marks reconciled state as true so that UpdateIngressStatus will operate
 	a.reconciled = true
+	a.setReconciled()
 	a.resetBackoff()
+
+	if len(a.ingresses()) == 0 {
+		// the load balancer was deleted along with every ingress it served
+		a.loadBalancer = nil
+	}
+	return a.removeFinalizers()
+}
+
+// addFinalizers adds the controller finalizer to every ingress served by the ALBIngress
+func (a *ALBIngress) addFinalizers() error {
+	cfg := a.store.GetConfig()
+	if cfg.DryRun {
+		return nil
+	}
+	for _, ing := range a.ingresses() {
+		if err := addFinalizer(cfg, ing); err != nil {
+			return fmt.Errorf("%s: %s", k8s.MetaNamespaceKey(ing), err.Error())
+		}
+	}
+	return nil
+}
+
+// removeFinalizers removes the controller finalizer from the deleted ingresses, once their AWS
+// resources were removed
+func (a *ALBIngress) removeFinalizers() error {
+	cfg := a.store.GetConfig()
+	if cfg.DryRun {
+		return nil
+	}
+
+	var remaining []*extensions.Ingress
+	for _, ing := range a.deleting {
+		if err := removeFinalizer(cfg, ing); err != nil {
+			if a.recorder != nil {
+				a.recorder.Eventf(ing, api.EventTypeWarning, errors.StepFinalizer.Reason(), "Error removing finalizer %s: %s", Finalizer, err.Error())
+			}
+			a.logger.Errorf("Failed to remove finalizer from %s: %s", k8s.MetaNamespaceKey(ing), err.Error())
+			remaining = append(remaining, ing)
+			continue
+		}
+		a.logger.Infof("Removed finalizer from %s", k8s.MetaNamespaceKey(ing))
+	}
+	a.deleting = remaining
+	if len(remaining) > 0 {
+		return fmt.Errorf("Failed to remove finalizer from %d ingresses", len(remaining))
+	}
 	return nil
 }
 
@@ -322,6 +503,10 @@ func (a *ALBIngress) Tags() map[string]string {
 	m := make(map[string]string)
 	// m[k8saws.TagNameKubernetesClusterPrefix+a.store.GetConfig().ClusterName] = k8saws.ResourceLifecycleOwned
 	m["kubernetes.io/cluster/"+a.store.GetConfig().ClusterName] = "owned"
+	if a.groupName != "" {
+		m[tags.IngressGroup] = a.groupName
+		return m
+	}
 	m[tags.Namespace] = a.namespace
 	m[tags.IngressName] = a.ingressName
 
@@ -349,19 +534,47 @@ func (a *ALBIngress) incrementBackoff() {
 	}
 }
 
+// ingressAllowedExternal returns true if the ingress, or every member of its ingress group, is
+// allowed to be internet-facing.
 func (a *ALBIngress) ingressAllowedExternal(configNamespace string) (bool, error) {
-	configMap, err := a.store.GetConfigMap(configNamespace + "/" + restrictIngressConfigMap)
+	return AllowedExternal(a.store, configNamespace, a.ingresses()...)
+}
+
+// AllowedExternal returns true if every ingress is listed in the ConfigMap of the ingresses allowed
+// to be internet-facing, in configNamespace, when the scheme is restricted.
+func AllowedExternal(s store.Storer, configNamespace string, ingresses ...*extensions.Ingress) (bool, error) {
+	configMap, err := s.GetConfigMap(configNamespace + "/" + restrictIngressConfigMap)
 	if err != nil {
 		return false, err
 	}
+
+	allowed := make(map[string]bool)
 	for ns, ingressString := range configMap.Data {
 		ingressString := strings.Replace(ingressString, " ", "", -1)
-		ingresses := strings.Split(ingressString, ",")
-		for _, ing := range ingresses {
-			if a.namespace == ns && a.ingressName == ing {
-				return true, nil
-			}
+		for _, name := range strings.Split(ingressString, ",") {
+			allowed[ns+"/"+name] = true
+		}
+	}
+
+	for _, ing := range ingresses {
+		if !allowed[ing.Namespace+"/"+ing.Name] {
+			return false, nil
 		}
 	}
-	return false, nil
+	return true, nil
+}
+
+// forceReconcileRequests returns the values of the force-reconcile annotation of the ingresses
+func forceReconcileRequests(ingresses []*extensions.Ingress) string {
+	var requests []string
+	for _, ing := range ingresses {
+		if v, err := parser.GetStringAnnotation(ForceReconcileAnnotation, ing); err == nil {
+			requests = append(requests, k8s.MetaNamespaceKey(ing)+"="+*v)
+		}
+	}
+	return strings.Join(requests, ",")
+}
+
+func groupID(groupName string) string {
+	return "group:" + groupName
 }
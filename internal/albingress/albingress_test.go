@@ -1,13 +1,35 @@
 package albingress
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 )
 
 func init() {
@@ -55,3 +77,361 @@ func TestNewALBIngressFromIngress(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSortGroupMembers(t *testing.T) {
+	first := dummy.NewIngress()
+	first.Name = "b"
+	second := dummy.NewIngress()
+	second.Name = "a"
+	third := dummy.NewIngress()
+	third.Name = "c"
+
+	sorted := sortGroupMembers([]groupMember{
+		{ingress: third, order: 10},
+		{ingress: first, order: -5},
+		{ingress: second, order: 10},
+	})
+
+	expected := []*extensions.Ingress{first, second, third}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("member %d was %s, expected %s", i, sorted[i].Name, expected[i].Name)
+		}
+	}
+}
+
+func TestHosts(t *testing.T) {
+	first := dummy.NewIngress()
+	first.Spec.Rules = []extensions.IngressRule{{Host: "b.example.com"}, {Host: ""}}
+	first.Spec.TLS = []extensions.IngressTLS{{Hosts: []string{"a.example.com", "b.example.com"}}}
+	second := dummy.NewIngress()
+	second.Spec.Rules = []extensions.IngressRule{{Host: "c.example.com"}}
+
+	a := &ALBIngress{groupName: "group", members: []*extensions.Ingress{first, second}}
+	expected := []string{"a.example.com", "b.example.com", "c.example.com"}
+	if hosts := a.hosts(); !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected hosts %v, got %v", expected, hosts)
+	}
+}
+
+func TestUsesSecret(t *testing.T) {
+	s := store.NewDummy()
+	ing := dummy.NewIngress()
+	if usesSecret(s, ing, "default/oidc-secret") {
+		t.Errorf("expected an ingress without authentication to use no secret")
+	}
+
+	s.GetServiceAnnotationsResponse.Auth = &auth.Config{
+		Type:    aws.String(auth.TypeOIDC),
+		IdpOidc: &auth.IdpOidc{SecretName: "oidc-secret"},
+	}
+	if !usesSecret(s, ing, "default/oidc-secret") {
+		t.Errorf("expected the secret of the services to be used")
+	}
+	if usesSecret(s, ing, "other/oidc-secret") {
+		t.Errorf("expected the secrets of other namespaces to be unused")
+	}
+}
+
+func TestGroupTags(t *testing.T) {
+	ingress := NewALBIngress(&NewALBIngressOptions{
+		Namespace: "default",
+		Name:      "ingress1",
+		GroupName: "shared",
+		Store:     store.NewDummy(),
+	})
+
+	if ingress.ID() != groupID("shared") {
+		t.Errorf("ID was %s, expected %s", ingress.ID(), groupID("shared"))
+	}
+
+	m := ingress.Tags()
+	if m[tags.IngressGroup] != "shared" {
+		t.Errorf("Group tag was %q, expected %q", m[tags.IngressGroup], "shared")
+	}
+	if _, ok := m[tags.IngressName]; ok {
+		t.Errorf("Ingress name tag should not be set on ingress group resources")
+	}
+}
+
+func newFinalizerTestStore(ingresses ...*extensions.Ingress) (*store.Dummy, *fakedynamic.FakeDynamicClient) {
+	client := fakedynamic.NewSimpleDynamicClient(scheme.Scheme)
+	tracker := k8stesting.NewObjectTracker(scheme.Scheme, scheme.Codecs.UniversalDecoder())
+	for _, ing := range ingresses {
+		tracker.Add(ing.DeepCopy())
+	}
+	client.PrependReactor("*", "ingresses", unstructuredReaction(k8stesting.ObjectReaction(tracker)))
+	client.PrependReactor("patch", "ingresses", unstructuredReaction(mergePatchFinalizers(tracker)))
+
+	s := store.NewDummy()
+	cfg := config.NewDefault()
+	cfg.DynamicClient = client
+	s.SetConfig(cfg)
+	s.ListIngressesFunc = func() []*extensions.Ingress { return ingresses }
+	return s, client
+}
+
+// unstructuredReaction returns the objects of react as returned by the dynamic client
+func unstructuredReaction(react k8stesting.ReactionFunc) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		handled, obj, err := react(action)
+		if err != nil || obj == nil {
+			return handled, obj, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		return handled, &unstructured.Unstructured{Object: content}, err
+	}
+}
+
+// mergePatchFinalizers applies the finalizers patches, the fake clients handle every patch as a
+// strategic merge patch which merges the finalizers instead of replacing them.
+func mergePatchFinalizers(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		var p struct {
+			Metadata struct {
+				Finalizers []string `json:"finalizers"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(patch.GetPatch(), &p); err != nil {
+			return true, nil, err
+		}
+		obj, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		ing := obj.(*extensions.Ingress)
+		ing.Finalizers = p.Metadata.Finalizers
+		return true, ing, tracker.Update(patch.GetResource(), ing, patch.GetNamespace())
+	}
+}
+
+func getFinalizers(t *testing.T, client dynamic.Interface, ing *extensions.Ingress) []string {
+	current, err := client.Resource(k8s.ExtensionsIngressResource).Namespace(ing.Namespace).Get(ing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return current.GetFinalizers()
+}
+
+func TestReconcileAddsFinalizer(t *testing.T) {
+	ing := dummy.NewIngress()
+	s, client := newFinalizerTestStore(ing)
+
+	ingress := NewALBIngress(&NewALBIngressOptions{
+		Ingress: ing,
+		Store:   s,
+	})
+	ingress.valid = true
+
+	if err := ingress.Reconcile(context.Background(), &ReconcileOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if finalizers := getFinalizers(t, client, ing); !reflect.DeepEqual(finalizers, []string{Finalizer}) {
+		t.Errorf("Finalizer %s was not added, finalizers were %v", Finalizer, finalizers)
+	}
+}
+
+func TestDeletedIngressFinalizerRemoved(t *testing.T) {
+	ing := dummy.NewIngress()
+	now := metav1.Now()
+	ing.DeletionTimestamp = &now
+	ing.Finalizers = []string{"other", Finalizer}
+	s, client := newFinalizerTestStore(ing)
+
+	existing := NewALBIngress(&NewALBIngressOptions{Ingress: ing, Store: s})
+	ingresses := NewALBIngressesFromIngresses(&NewALBIngressesFromIngressesOptions{
+		Store:        s,
+		ALBIngresses: ALBIngresses{existing},
+	})
+	if len(ingresses) != 1 || ingresses[0] != existing {
+		t.Fatalf("Expected the existing ALBIngress to remove the resources of the deleted ingress, got %v", ingresses)
+	}
+	if len(existing.ingresses()) != 0 || len(existing.deleting) != 1 {
+		t.Fatalf("Expected the ingress to be deleting, got %d ingresses and %d deleting", len(existing.ingresses()), len(existing.deleting))
+	}
+
+	if err := existing.Reconcile(context.Background(), &ReconcileOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if finalizers := getFinalizers(t, client, ing); !reflect.DeepEqual(finalizers, []string{"other"}) {
+		t.Errorf("Finalizers were %v, expected [other]", finalizers)
+	}
+	if len(existing.deleting) != 0 {
+		t.Errorf("Expected no ingress left to finalize, got %d", len(existing.deleting))
+	}
+}
+
+func TestDeletedIngressWithoutFinalizerIgnored(t *testing.T) {
+	ing := dummy.NewIngress()
+	now := metav1.Now()
+	ing.DeletionTimestamp = &now
+	s, _ := newFinalizerTestStore(ing)
+
+	ingresses := NewALBIngressesFromIngresses(&NewALBIngressesFromIngressesOptions{Store: s})
+	if len(ingresses) != 0 {
+		t.Errorf("Expected the deleted ingress to be ignored, got %d ALBIngresses", len(ingresses))
+	}
+}
+
+func TestRemoveFinalizerFailure(t *testing.T) {
+	ing := dummy.NewIngress()
+	now := metav1.Now()
+	ing.DeletionTimestamp = &now
+	ing.Finalizers = []string{Finalizer}
+	s, client := newFinalizerTestStore(ing)
+	client.PrependReactor("patch", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("forbidden")
+	})
+	recorder := record.NewFakeRecorder(10)
+
+	ingress := NewDeletingALBIngress(&NewDeletingALBIngressOptions{
+		Store:     s,
+		Recorder:  recorder,
+		Ingresses: []*extensions.Ingress{ing},
+	})
+	if err := ingress.Reconcile(context.Background(), &ReconcileOptions{}); err == nil {
+		t.Errorf("Expected an error when the finalizer can't be removed")
+	}
+	if len(ingress.deleting) != 1 {
+		t.Errorf("Expected the ingress to be finalized again, got %d deleting", len(ingress.deleting))
+	}
+
+	select {
+	case e := <-recorder.Events:
+		if !strings.HasPrefix(e, "Warning FailedFinalizer Error removing finalizer") {
+			t.Errorf("Unexpected event %q", e)
+		}
+	default:
+		t.Errorf("Expected a warning event")
+	}
+}
+
+func TestUnsupportedIngressRejected(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.Annotations = map[string]string{k8s.UnsupportedKey: "resource backend StorageBucket static-assets is not supported, only Service backends are"}
+
+	_, err := NewALBIngressFromIngress(&NewALBIngressFromIngressOptions{
+		Ingress: ing,
+		Store:   store.NewDummy(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "resource backend StorageBucket") {
+		t.Errorf("Expected the unsupported ingress to be rejected, got %v", err)
+	}
+}
+
+func TestReconcileStatus(t *testing.T) {
+	ing := dummy.NewIngress()
+	s, client := newFinalizerTestStore(ing)
+	recorder := record.NewFakeRecorder(10)
+
+	ingress := NewALBIngress(&NewALBIngressOptions{
+		Ingress:  ing,
+		Store:    s,
+		Recorder: recorder,
+	})
+	ingress.valid = true
+
+	client.PrependReactor("patch", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("forbidden")
+	})
+	if err := ingress.Reconcile(context.Background(), &ReconcileOptions{}); err == nil {
+		t.Fatal("Expected an error when the finalizer can't be added")
+	}
+	status := ingress.ReconcileStatus()
+	if status.LastReconcileTime != nil || status.LastError == nil || status.LastError.Reason != "FailedFinalizer" {
+		t.Errorf("Expected the finalizer failure to be recorded, got %+v", status)
+	}
+	if e := <-recorder.Events; !strings.HasPrefix(e, "Warning FailedFinalizer Error adding finalizer") {
+		t.Errorf("Unexpected event %q", e)
+	}
+
+	// drop the failing reactor
+	client.ReactionChain = client.ReactionChain[1:]
+	ingress.resetBackoff()
+	if err := ingress.Reconcile(context.Background(), &ReconcileOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	status = ingress.ReconcileStatus()
+	if status.LastReconcileTime == nil || status.LastError != nil {
+		t.Errorf("Expected the successful reconciliation to be recorded, got %+v", status)
+	}
+}
+
+func TestForceReconcileResetsBackoff(t *testing.T) {
+	ing := dummy.NewIngress()
+	s := store.NewDummy()
+
+	existing, err := NewALBIngressFromIngress(&NewALBIngressFromIngressOptions{Ingress: ing, Store: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing.valid = false
+	existing.incrementBackoff()
+	if existing.ready() || existing.DebugInfo().NextAttempt == nil {
+		t.Fatal("Expected the failing ingress to wait for its backoff")
+	}
+
+	// an unchanged ingress keeps waiting
+	if _, err := NewALBIngressFromIngress(&NewALBIngressFromIngressOptions{Ingress: ing, Store: s, ExistingIngress: existing}); err != nil {
+		t.Fatal(err)
+	}
+	if existing.ready() {
+		t.Error("Expected the ingress to keep waiting for its backoff")
+	}
+
+	ing = ing.DeepCopy()
+	ing.Annotations = map[string]string{parser.GetAnnotationWithPrefix(ForceReconcileAnnotation): "1"}
+	if _, err := NewALBIngressFromIngress(&NewALBIngressFromIngressOptions{Ingress: ing, Store: s, ExistingIngress: existing}); err != nil {
+		t.Fatal(err)
+	}
+	if !existing.ready() || existing.DebugInfo().NextAttempt != nil {
+		t.Error("Expected the force-reconcile annotation to reset the backoff")
+	}
+	if existing.forceReconcile != "default/ingress1=1" {
+		t.Errorf("Unexpected force-reconcile requests %q", existing.forceReconcile)
+	}
+}
+
+func TestIngressIDs(t *testing.T) {
+	first := dummy.NewIngress()
+	first.Name = "first"
+	second := dummy.NewIngress()
+	second.Name = "second"
+	second.Spec.Rules = nil
+	s := store.NewDummy()
+	s.ListIngressesFunc = func() []*extensions.Ingress { return []*extensions.Ingress{first, second} }
+
+	if ids := IngressIDs(s); !reflect.DeepEqual(ids, []string{"default/first", "default/second"}) {
+		t.Errorf("Unexpected ingress IDs %v", ids)
+	}
+	if ids := ServiceIngressIDs(s, "default/default-backend"); !reflect.DeepEqual(ids, []string{"default/first", "default/second"}) {
+		t.Errorf("Unexpected ingress IDs of the default backend %v", ids)
+	}
+	if ids := ServiceIngressIDs(s, "default/2service"); !reflect.DeepEqual(ids, []string{"default/first"}) {
+		t.Errorf("Unexpected ingress IDs of 2service %v", ids)
+	}
+	if ids := ServiceIngressIDs(s, "kube-system/2service"); len(ids) != 0 {
+		t.Errorf("Expected no ingress routing to a Service of another namespace, got %v", ids)
+	}
+
+	ing := NewALBIngressFromStore(&NewALBIngressFromStoreOptions{ID: "default/second", Store: s, Metric: &metric.DummyCollector{}})
+	if ing == nil || ing.ID() != "default/second" {
+		t.Errorf("Expected the ALBIngress of the second ingress, got %v", ing)
+	}
+	if ing := NewALBIngressFromStore(&NewALBIngressFromStoreOptions{ID: "default/removed", Store: s, Metric: &metric.DummyCollector{}}); ing != nil {
+		t.Errorf("Expected no ALBIngress without ingress, got %v", ing.ID())
+	}
+
+	// members of an ingress group are served by a single ALBIngress
+	s.GetIngressAnnotationsResponse = annotations.NewIngressDummy()
+	s.GetIngressAnnotationsResponse.Group = &group.Config{Name: "shared"}
+	if ids := IngressIDs(s); !reflect.DeepEqual(ids, []string{"group:shared"}) {
+		t.Errorf("Unexpected ingress IDs of the group %v", ids)
+	}
+	if ids := ServiceIngressIDs(s, "default/default-backend"); !reflect.DeepEqual(ids, []string{"group:shared"}) {
+		t.Errorf("Unexpected ingress IDs of the default backend in the group %v", ids)
+	}
+}
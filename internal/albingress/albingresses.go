@@ -2,8 +2,10 @@ package albingress
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
@@ -11,6 +13,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/glog"
 	pool "gopkg.in/go-playground/pool.v3"
@@ -23,6 +26,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
@@ -36,42 +40,308 @@ type NewALBIngressesFromIngressesOptions struct {
 }
 
 // NewALBIngressesFromIngresses returns a ALBIngresses created from the Kubernetes ingress state.
+// Ingresses sharing a group name are assembled into a single ALBIngress.
 func NewALBIngressesFromIngresses(o *NewALBIngressesFromIngressesOptions) ALBIngresses {
 	var ALBIngresses ALBIngresses
 
+	ids, sets := groupIngresses(o.Store)
+	for _, id := range ids {
+		// Find the existing ingress for this Kubernetes ingress (if it existed).
+		_, existingIngress := o.ALBIngresses.FindByID(id)
+		ALBIngresses = append(ALBIngresses, newALBIngressFromSet(o.Metric, &newALBIngressFromSetOptions{
+			Set:             sets[id],
+			ExistingIngress: existingIngress,
+			Store:           o.Store,
+			Recorder:        o.Recorder,
+		}))
+	}
+	return ALBIngresses
+}
+
+// NewALBIngressFromStoreOptions are the options to NewALBIngressFromStore
+type NewALBIngressFromStoreOptions struct {
+	ID              string
+	ExistingIngress *ALBIngress
+	Recorder        record.EventRecorder
+	Store           store.Storer
+	Metric          metric.Collector
+}
+
+// NewALBIngressFromStore returns the ALBIngress with the ID created from the Kubernetes ingress state,
+// nil when none of the ingresses is served by it anymore.
+func NewALBIngressFromStore(o *NewALBIngressFromStoreOptions) *ALBIngress {
+	_, sets := groupIngresses(o.Store)
+	set, ok := sets[o.ID]
+	if !ok {
+		return nil
+	}
+	return newALBIngressFromSet(o.Metric, &newALBIngressFromSetOptions{
+		Set:             set,
+		ExistingIngress: o.ExistingIngress,
+		Store:           o.Store,
+		Recorder:        o.Recorder,
+	})
+}
+
+// IngressID returns the ID of the ALBIngress serving the ingress
+func IngressID(s store.Storer, ing *extensions.Ingress) string {
+	id, _ := ingressID(s, ing)
+	return id
+}
+
+// IngressIDs returns the IDs of the ALBIngresses serving the ingresses of the store
+func IngressIDs(s store.Storer) []string {
+	ids, _ := groupIngresses(s)
+	return ids
+}
+
+// ServiceIngressIDs returns the IDs of the ALBIngresses serving the ingresses routing to the
+// Service matching key, directly or through a weighted forward action
+func ServiceIngressIDs(s store.Storer, key string) []string {
+	return ingressIDs(s, func(ing *extensions.Ingress) bool {
+		return usesService(s, ing, key)
+	})
+}
+
+// SecretIngressIDs returns the IDs of the ALBIngresses serving the ingresses authenticating requests
+// with the OIDC client credentials of the Secret matching key
+func SecretIngressIDs(s store.Storer, key string) []string {
+	return ingressIDs(s, func(ing *extensions.Ingress) bool {
+		return usesSecret(s, ing, key)
+	})
+}
+
+// ingressIDs returns the IDs of the ALBIngresses serving the ingresses matching uses
+func ingressIDs(s store.Storer, uses func(*extensions.Ingress) bool) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, ing := range s.ListIngresses() {
+		if !class.IsValid(ing) || !uses(ing) {
+			continue
+		}
+		if id := IngressID(s, ing); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// backends returns the default backend and the backends of the rules of the ingress
+func backends(ing *extensions.Ingress) []*extensions.IngressBackend {
+	var backends []*extensions.IngressBackend
+	if ing.Spec.Backend != nil {
+		backends = append(backends, ing.Spec.Backend)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			backends = append(backends, &rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return backends
+}
+
+// usesSecret returns true if the ingress, or a Service it routes to, authenticates requests with the
+// OIDC client credentials of the Secret matching key
+func usesSecret(s store.Storer, ing *extensions.Ingress, key string) bool {
+	annos, err := s.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
+	if err != nil {
+		return false
+	}
+	if name := annos.Auth.SecretName(); name != "" && ing.Namespace+"/"+name == key {
+		return true
+	}
+	for _, backend := range backends(ing) {
+		if action.Use(backend.ServicePort.String()) {
+			continue
+		}
+		svcAnnos, err := s.GetServiceAnnotations(ing.Namespace+"/"+backend.ServiceName, annos)
+		if err != nil {
+			continue
+		}
+		if name := svcAnnos.Auth.SecretName(); name != "" && ing.Namespace+"/"+name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// usesService returns true if the ingress routes to the Service matching key
+func usesService(s store.Storer, ing *extensions.Ingress, key string) bool {
+	for _, backend := range backends(ing) {
+		if !action.Use(backend.ServicePort.String()) {
+			if ing.Namespace+"/"+backend.ServiceName == key {
+				return true
+			}
+			continue
+		}
+		annos, err := s.GetIngressAnnotations(k8s.MetaNamespaceKey(ing))
+		if err != nil {
+			continue
+		}
+		a, err := annos.Action.GetAction(backend.ServiceName)
+		if err != nil {
+			continue
+		}
+		for _, b := range a.Backends() {
+			if ing.Namespace+"/"+b.ServiceName == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressID returns the ID of the ALBIngress serving the ingress, and the name of its ingress group
+func ingressID(s store.Storer, ing *extensions.Ingress) (string, string) {
+	id := k8s.MetaNamespaceKey(ing)
+	annos, err := s.GetIngressAnnotations(id)
+	if err == nil && annos.Group.Grouped() {
+		return groupID(annos.Group.Name), annos.Group.Name
+	}
+	return id, ""
+}
+
+// ingressSet holds the ingresses of the store served by an ALBIngress
+type ingressSet struct {
+	groupName string
+	// ingress is the ingress served alone, members the members of an ingress group
+	ingress  *extensions.Ingress
+	members  []groupMember
+	deleting []*extensions.Ingress
+}
+
+// groupIngresses sorts the ingresses of the store by the ALBIngress serving them, and returns the
+// IDs of the ALBIngresses in the order they were found
+func groupIngresses(s store.Storer) ([]string, map[string]*ingressSet) {
+	var ids []string
+	sets := make(map[string]*ingressSet)
+	set := func(id, groupName string) *ingressSet {
+		if _, ok := sets[id]; !ok {
+			ids = append(ids, id)
+			sets[id] = &ingressSet{groupName: groupName}
+		}
+		return sets[id]
+	}
+
 	// Find every ingress currently in Kubernetes.
-	for _, ingResource := range o.Store.ListIngresses() {
+	for _, ingResource := range s.ListIngresses() {
 		// Ensure the ingress resource found contains an appropriate ingress class.
 		if !class.IsValid(ingResource) {
 			continue
 		}
+		id, groupName := ingressID(s, ingResource)
 
-		applyDefaults(ingResource)
+		// Deleted ingresses are kept by their finalizer until the ALBIngress serving them was reconciled.
+		// Without the finalizer, they are handled like ingresses missing from the store.
+		if isDeleting(ingResource) {
+			if !hasFinalizer(ingResource) {
+				continue
+			}
+			set(id, groupName).deleting = append(sets[id].deleting, ingResource.DeepCopy())
+			continue
+		}
 
-		// Find the existing ingress for this Kubernetes ingress (if it existed).
-		id := k8s.MetaNamespaceKey(ingResource)
-		_, existingIngress := o.ALBIngresses.FindByID(id)
+		// Members of an ingress group are assembled once every ingress has been seen.
+		if groupName != "" {
+			annos, _ := s.GetIngressAnnotations(k8s.MetaNamespaceKey(ingResource))
+			set(id, groupName).members = append(sets[id].members, groupMember{
+				ingress: ingResource.DeepCopy(),
+				order:   annos.Group.Order,
+			})
+			continue
+		}
 
+		ing := ingResource.DeepCopy()
+		applyDefaults(ing)
+		set(id, groupName).ingress = ing
+	}
+	return ids, sets
+}
+
+type newALBIngressFromSetOptions struct {
+	Set             *ingressSet
+	ExistingIngress *ALBIngress
+	Recorder        record.EventRecorder
+	Store           store.Storer
+}
+
+// newALBIngressFromSet assembles the ALBIngress serving a set of ingresses
+func newALBIngressFromSet(m metric.Collector, o *newALBIngressFromSetOptions) *ALBIngress {
+	var ALBIngress *ALBIngress
+	switch {
+	case o.Set.ingress != nil:
 		// Produce a new ALBIngress instance for every ingress found. If ALBIngress returns nil, there
 		// was an issue with the ingress (e.g. bad annotations) and should not be added to the list.
-		ALBIngress, err := NewALBIngressFromIngress(&NewALBIngressFromIngressOptions{
-			Ingress:         ingResource,
-			ExistingIngress: existingIngress,
+		ALBIngress = newALBIngressFromIngress(m, &NewALBIngressFromIngressOptions{
+			Ingress:         o.Set.ingress,
+			ExistingIngress: o.ExistingIngress,
 			Store:           o.Store,
 			Recorder:        o.Recorder,
 		})
-		if err != nil {
-			ALBIngress.incrementBackoff()
-			ALBIngress.Eventf(api.EventTypeWarning, "ERROR", err.Error())
-			ALBIngress.logger.Errorf(err.Error())
-			ALBIngress.logger.Errorf("Will retry in %v", ALBIngress.nextAttempt)
-			o.Metric.IncReconcileErrorCount(ALBIngress.ID())
+	case len(o.Set.members) > 0:
+		members := sortGroupMembers(o.Set.members)
+		ALBIngress = newALBIngressFromIngress(m, &NewALBIngressFromIngressOptions{
+			Ingress:         members[0],
+			ExistingIngress: o.ExistingIngress,
+			Store:           o.Store,
+			Recorder:        o.Recorder,
+			GroupName:       o.Set.groupName,
+			Ingresses:       members,
+		})
+	default:
+		return NewDeletingALBIngress(&NewDeletingALBIngressOptions{
+			ExistingIngress: o.ExistingIngress,
+			Store:           o.Store,
+			Recorder:        o.Recorder,
+			GroupName:       o.Set.groupName,
+			Ingresses:       o.Set.deleting,
+		})
+	}
+
+	// the ingress group still has members, the deleted ones are removed from its rules
+	if len(o.Set.deleting) > 0 {
+		ALBIngress.deleting = o.Set.deleting
+	}
+	return ALBIngress
+}
+
+func newALBIngressFromIngress(m metric.Collector, opts *NewALBIngressFromIngressOptions) *ALBIngress {
+	ALBIngress, err := NewALBIngressFromIngress(opts)
+	if err != nil {
+		ALBIngress.incrementBackoff()
+		ALBIngress.setReconcileErrors(errors.NewReconcileError(errors.StepConfiguration, err))
+		ALBIngress.Eventf(api.EventTypeWarning, errors.StepConfiguration.Reason(), err.Error())
+		ALBIngress.logger.Errorf(err.Error())
+		ALBIngress.logger.Errorf("Will retry in %v", ALBIngress.nextAttempt)
+		m.IncReconcileErrorCount(ALBIngress.ID())
+	}
+	return ALBIngress
+}
+
+type groupMember struct {
+	ingress *extensions.Ingress
+	order   int64
+}
+
+// sortGroupMembers orders the members of an ingress group by their group.order annotation, falling
+// back to namespace/name so the order of the rules on the shared ALB is stable.
+func sortGroupMembers(members []groupMember) []*extensions.Ingress {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].order != members[j].order {
+			return members[i].order < members[j].order
 		}
+		return k8s.MetaNamespaceKey(members[i].ingress) < k8s.MetaNamespaceKey(members[j].ingress)
+	})
 
-		// Add the new ALBIngress instance to the new ALBIngress list.
-		ALBIngresses = append(ALBIngresses, ALBIngress)
+	var ingresses []*extensions.Ingress
+	for _, m := range members {
+		ingresses = append(ingresses, m.ingress)
 	}
-	return ALBIngresses
+	return ingresses
 }
 
 // AssembleIngressesFromAWSOptions are the options to AssembleIngressesFromAWS
@@ -111,7 +381,23 @@ func AssembleIngressesFromAWS(o *AssembleIngressesFromAWSOptions) ALBIngresses {
 	if len(loadBalancers) != len(ingresses) {
 		glog.Fatalf("Assembled %d ingresses from %v load balancers", len(ingresses), len(loadBalancers))
 	}
-	return ingresses
+	return mergeReplacements(ingresses)
+}
+
+// mergeReplacements merges the ALBIngresses assembled from load balancers of the same ingresses, left
+// behind by a replacement in progress. The most recently created load balancer replaces the others.
+func mergeReplacements(ingresses ALBIngresses) ALBIngresses {
+	var merged ALBIngresses
+	for _, ingress := range ingresses {
+		_, existing := merged.FindByID(ingress.id)
+		if existing == nil {
+			merged = append(merged, ingress)
+			continue
+		}
+		existing.loadBalancer = lb.AssembleReplacement(existing.loadBalancer, ingress.loadBalancer)
+		existing.logger.Infof("Replacement of ALB %s in progress", aws.StringValue(existing.loadBalancer.ARN()))
+	}
+	return merged
 }
 
 // FindByID locates the ingress by the id parameter and returns its position
@@ -124,6 +410,22 @@ func (a ALBIngresses) FindByID(id string) (int, *ALBIngress) {
 	return -1, nil
 }
 
+// FindByIngressID locates the ALBIngress managing the Kubernetes ingress with the id parameter,
+// either directly or as a member of an ingress group, and returns its position
+func (a ALBIngresses) FindByIngressID(id string) (int, *ALBIngress) {
+	for p, v := range a {
+		if v.id == id {
+			return p, v
+		}
+		for _, ing := range v.members {
+			if k8s.MetaNamespaceKey(ing) == id {
+				return p, v
+			}
+		}
+	}
+	return -1, nil
+}
+
 // RemovedIngresses compares the ingress list to the ingress list in the type, returning any ingresses that
 // are not in the ingress list parameter.
 func (a ALBIngresses) RemovedIngresses(newList ALBIngresses) ALBIngresses {
@@ -141,6 +443,10 @@ func (a ALBIngresses) RemovedIngresses(newList ALBIngresses) ALBIngresses {
 				ingress.stripDesiredState()
 				ingress.resetBackoff()
 				ingress.valid = true
+				// the ingresses may still hold the finalizer, e.g. when their ingress class changed
+				ingress.deleting = append(ingress.deleting, ingress.ingresses()...)
+				ingress.ingress = nil
+				ingress.members = nil
 				deleteableIngress = append(deleteableIngress, ingress)
 			}
 		}
@@ -149,7 +455,7 @@ func (a ALBIngresses) RemovedIngresses(newList ALBIngresses) ALBIngresses {
 }
 
 // Reconcile syncs the desired state to the current state
-func (a ALBIngresses) Reconcile(m metric.Collector, sgAssociationController sg.AssociationController, lbAttributesController lb.AttributesController, tgAttributesController tg.AttributesController, tgTargetsController tg.TargetsController, tagsController tags.Controller) {
+func (a ALBIngresses) Reconcile(m metric.Collector, sgAssociationController sg.AssociationController, lbAttributesController lb.AttributesController, tgAttributesController tg.AttributesController, tgTargetsController tg.TargetsController, tagsController tags.Controller, dnsController dns.Controller) {
 	p := pool.NewLimited(20)
 	defer p.Close()
 
@@ -162,21 +468,15 @@ func (a ALBIngresses) Reconcile(m metric.Collector, sgAssociationController sg.A
 					return nil, nil
 				}
 
-				ctx := context.Background()
-				ctx = albctx.SetEventf(ctx, ingress.Eventf)
-				ctx = albctx.SetLogger(ctx, log.New(ingress.id))
-				err := ingress.Reconcile(ctx, &ReconcileOptions{
+				return nil, ingress.ReconcileWithMetrics(m, &ReconcileOptions{
 					Store:                   ingress.store,
 					SgAssociationController: sgAssociationController,
 					LbAttributesController:  lbAttributesController,
 					TgAttributesController:  tgAttributesController,
 					TgTargetsController:     tgTargetsController,
 					TagsController:          tagsController,
+					DNSController:           dnsController,
 				})
-				if err != nil {
-					m.IncReconcileErrorCount(ingress.ID())
-				}
-				return nil, err
 			}
 		}(ingress))
 	}
@@ -190,11 +490,30 @@ func (a ALBIngresses) Reconcile(m metric.Collector, sgAssociationController sg.A
 	}
 }
 
+// ReconcileWithMetrics reconciles the ALBIngress with a context reporting its events and logs,
+// and counts its failures
+func (a *ALBIngress) ReconcileWithMetrics(m metric.Collector, rOpts *ReconcileOptions) error {
+	ctx := context.Background()
+	ctx = albctx.SetEventf(ctx, a.Eventf)
+	ctx = albctx.SetLogger(ctx, log.New(a.id))
+	err := a.Reconcile(ctx, rOpts)
+	if err != nil {
+		m.IncReconcileErrorCount(a.ID())
+	}
+	return err
+}
+
 // IngressesByNamespace returns the count of ingresses per namespace
 func (a ALBIngresses) IngressesByNamespace() map[string]int {
 	ingressesByNamespace := map[string]int{}
 	for _, ingress := range a {
-		ingressesByNamespace[ingress.namespace]++
+		if ingress.groupName == "" {
+			ingressesByNamespace[ingress.namespace]++
+			continue
+		}
+		for _, ing := range ingress.members {
+			ingressesByNamespace[ing.Namespace]++
+		}
 	}
 	return ingressesByNamespace
 }
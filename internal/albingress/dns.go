@@ -0,0 +1,83 @@
+package albingress
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+)
+
+// reconcileRecords maintains the alias records of the hosts of the ingresses in the hosted zone matching
+// the scheme of the load balancer, and deletes them from the other hosted zone. Every record is deleted
+// once the ingresses are deleted.
+func (a *ALBIngress) reconcileRecords(ctx context.Context, rOpts *ReconcileOptions) error {
+	if rOpts.DNSController == nil {
+		return nil
+	}
+
+	var target *elbv2.LoadBalancer
+	if len(a.ingresses()) > 0 {
+		if a.loadBalancer == nil || a.loadBalancer.Serving() == nil {
+			// the load balancer wasn't created yet
+			return nil
+		}
+		target = a.loadBalancer.Serving()
+	}
+
+	cfg := a.store.GetConfig()
+	for _, z := range []struct {
+		id     string
+		scheme string
+	}{
+		{id: cfg.Route53PublicHostedZoneID, scheme: elbv2.LoadBalancerSchemeEnumInternetFacing},
+		{id: cfg.Route53PrivateHostedZoneID, scheme: elbv2.LoadBalancerSchemeEnumInternal},
+	} {
+		if z.id == "" {
+			continue
+		}
+		records := &dns.Records{ZoneID: z.id, Owner: a.id}
+		if target == nil || aws.StringValue(target.Scheme) != z.scheme {
+			if err := rOpts.DNSController.Delete(ctx, records); err != nil {
+				return errors.NewReconcileError(errors.StepDNS, err)
+			}
+			continue
+		}
+
+		records.Hosts = a.hosts()
+		records.DNSName = aws.StringValue(target.DNSName)
+		records.CanonicalHostedZoneID = aws.StringValue(target.CanonicalHostedZoneId)
+		records.IPv6 = aws.StringValue(target.IpAddressType) != elbv2.IpAddressTypeIpv4
+		if err := rOpts.DNSController.Reconcile(ctx, records); err != nil {
+			return errors.NewReconcileError(errors.StepDNS, err)
+		}
+	}
+	return nil
+}
+
+// hosts returns the hosts of the rules and TLS sections of the ingresses
+func (a *ALBIngress) hosts() []string {
+	seen := make(map[string]bool)
+	var hosts []string
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	for _, ing := range a.ingresses() {
+		for _, rule := range ing.Spec.Rules {
+			add(rule.Host)
+		}
+		for _, tls := range ing.Spec.TLS {
+			for _, host := range tls.Hosts {
+				add(host)
+			}
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -0,0 +1,108 @@
+package albingress
+
+import (
+	"encoding/json"
+	"fmt"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+)
+
+// Finalizer is added to every ingress managed by the controller. It is removed once the load balancer,
+// target groups and managed security groups of the ingress were deleted, so the ingress can't go away
+// while its AWS resources still exist.
+const Finalizer = "alb.ingress.kubernetes.io/finalizer"
+
+// hasFinalizer returns true if the ingress holds the controller finalizer
+func hasFinalizer(ing *extensions.Ingress) bool {
+	for _, f := range ing.Finalizers {
+		if f == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeleting returns true if the ingress was deleted and is only kept until its finalizers are removed
+func isDeleting(ing *extensions.Ingress) bool {
+	return ing.DeletionTimestamp != nil
+}
+
+// addFinalizer adds the controller finalizer to the ingress
+func addFinalizer(cfg *config.Configuration, ing *extensions.Ingress) error {
+	if hasFinalizer(ing) {
+		return nil
+	}
+	return updateFinalizers(cfg, ing, func(current *unstructured.Unstructured) bool {
+		finalizers := current.GetFinalizers()
+		for _, f := range finalizers {
+			if f == Finalizer {
+				return false
+			}
+		}
+		if current.GetDeletionTimestamp() != nil {
+			return false
+		}
+		current.SetFinalizers(append(finalizers, Finalizer))
+		return true
+	})
+}
+
+// removeFinalizer removes the controller finalizer from the ingress, if it still exists
+func removeFinalizer(cfg *config.Configuration, ing *extensions.Ingress) error {
+	return updateFinalizers(cfg, ing, func(current *unstructured.Unstructured) bool {
+		var finalizers []string
+		for _, f := range current.GetFinalizers() {
+			if f != Finalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		if len(finalizers) == len(current.GetFinalizers()) {
+			return false
+		}
+		current.SetFinalizers(finalizers)
+		return true
+	})
+}
+
+// updateFinalizers applies update to the latest version of the ingress, retrying on conflicts.
+// update returns false when the ingress doesn't need to be updated. The ingress is read with the
+// dynamic client, in the API version served by the API server, and only its finalizers are patched.
+func updateFinalizers(cfg *config.Configuration, ing *extensions.Ingress, update func(*unstructured.Unstructured) bool) error {
+	if cfg.DynamicClient == nil {
+		return fmt.Errorf("no kubernetes client configured")
+	}
+	ingClient := cfg.DynamicClient.Resource(cfg.IngressResource).Namespace(ing.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := ingClient.Get(ing.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if current.GetUID() != ing.UID || !update(current) {
+			return nil
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"finalizers":      current.GetFinalizers(),
+				"resourceVersion": current.GetResourceVersion(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = ingClient.Patch(current.GetName(), types.MergePatchType, patch)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,137 @@
+package albingress
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+)
+
+// ReconcileStatus is the outcome of the reconciliations of an ALBIngress. It is written to the
+// k8s.ReconcileStatusKey annotation of its ingresses, so their owners can see why the AWS resources
+// don't match them without access to the controller logs.
+type ReconcileStatus struct {
+	// LoadBalancerARN is the ARN of the load balancer serving the ingress
+	LoadBalancerARN string `json:"loadBalancerARN,omitempty"`
+
+	// LastReconcileTime is the last time the AWS resources were successfully reconciled
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LastError is the failure of the last reconciliation, it is cleared once a reconciliation succeeds
+	LastError *ReconcileErrorStatus `json:"lastError,omitempty"`
+}
+
+// ReconcileErrorStatus is the failure of a reconciliation
+type ReconcileErrorStatus struct {
+	// Reason is the reason of the step which failed, like FailedListener
+	Reason string `json:"reason"`
+
+	Message string `json:"message"`
+
+	Time metav1.Time `json:"time"`
+}
+
+// ReconcileStatus returns the outcome of the reconciliations of the ALBIngress
+func (a *ALBIngress) ReconcileStatus() ReconcileStatus {
+	return a.status
+}
+
+// setReconciled records a successful reconciliation
+func (a *ALBIngress) setReconciled() {
+	a.status.LoadBalancerARN = a.loadBalancerARN()
+	now := metav1.Now()
+	a.status.LastReconcileTime = &now
+	a.status.LastError = nil
+}
+
+// setReconcileErrors records a failed reconciliation. The reason is the one of the first error,
+// the message holds every error.
+func (a *ALBIngress) setReconcileErrors(errs ...error) {
+	if len(errs) == 0 {
+		return
+	}
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	a.status.LoadBalancerARN = a.loadBalancerARN()
+	a.status.LastError = &ReconcileErrorStatus{
+		Reason:  errors.Reason(errs[0]),
+		Message: strings.Join(messages, "; "),
+		Time:    metav1.Now(),
+	}
+}
+
+func (a *ALBIngress) loadBalancerARN() string {
+	if a.loadBalancer == nil {
+		return ""
+	}
+	return aws.StringValue(a.loadBalancer.ARN())
+}
+
+// DebugInfo is the state of an ALBIngress, served by the /debug/ingresses endpoint
+type DebugInfo struct {
+	ID string `json:"id"`
+
+	// Ingresses are the ingresses served by the ALBIngress, Deleting the deleted ingresses waiting
+	// for the removal of their AWS resources
+	Ingresses []string `json:"ingresses,omitempty"`
+	Deleting  []string `json:"deleting,omitempty"`
+
+	// Valid is false when the desired state couldn't be assembled from the ingresses
+	Valid bool `json:"valid"`
+
+	// Reconciled is true when the AWS resources were reconciled by the last attempt
+	Reconciled bool `json:"reconciled"`
+
+	// NextAttempt is the earliest time of the next attempt of a failing ALBIngress, unset when it
+	// is reconciled at the next sync
+	NextAttempt *metav1.Time `json:"nextAttempt,omitempty"`
+
+	ReconcileStatus
+}
+
+// DebugInfo returns the state of the ALBIngress, waiting for its reconciliation in progress
+func (a *ALBIngress) DebugInfo() DebugInfo {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	info := DebugInfo{
+		ID:              a.id,
+		Valid:           a.valid,
+		Reconciled:      a.reconciled,
+		ReconcileStatus: a.status,
+	}
+	for _, ing := range a.ingresses() {
+		info.Ingresses = append(info.Ingresses, k8s.MetaNamespaceKey(ing))
+	}
+	for _, ing := range a.deleting {
+		info.Deleting = append(info.Deleting, k8s.MetaNamespaceKey(ing))
+	}
+	if wait := a.RetryIn(); wait > 0 {
+		next := metav1.NewTime(time.Now().Add(wait))
+		info.NextAttempt = &next
+	}
+	return info
+}
+
+// RetryIn returns how long a failing ALBIngress waits before its next attempt, 0 when it is
+// reconciled at the next sync
+func (a *ALBIngress) RetryIn() time.Duration {
+	if wait := a.nextAttempt + a.prevAttempt - a.backoff.GetElapsedTime(); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Retry resets the backoff of the ALBIngress, so it is reconciled at the next sync
+func (a *ALBIngress) Retry() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.logger.Infof("Reconcile requested, resetting backoff")
+	a.resetBackoff()
+}
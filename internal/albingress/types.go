@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
@@ -33,12 +34,19 @@ type ALBIngress struct {
 	store        store.Storer
 	recorder     record.EventRecorder
 	ingress      *extensions.Ingress
+	groupName    string
+	members      []*extensions.Ingress // members of the ingress group, in group order
+	deleting     []*extensions.Ingress // deleted ingresses waiting for their AWS resources to be removed
 	lock         *sync.Mutex
 	annotations  *annotations.Ingress
 	loadBalancer *lb.LoadBalancer
 	valid        bool
 	logger       *log.Logger
 	reconciled   bool
+	status       ReconcileStatus
+
+	// forceReconcile holds the force-reconcile annotations of the ingresses when they were last assembled
+	forceReconcile string
 }
 
 type ReconcileOptions struct {
@@ -48,6 +56,8 @@ type ReconcileOptions struct {
 	TgAttributesController  tg.AttributesController
 	TgTargetsController     tg.TargetsController
 	TagsController          tags.Controller
+	// DNSController is nil unless the Route53 records of the ingress hosts are managed
+	DNSController dns.Controller
 }
 
 func (a *ALBIngress) ID() string {
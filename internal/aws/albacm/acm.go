@@ -1,10 +1,14 @@
 package albacm
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
-	"fmt"
 )
 
 // ACMsvc is a pointer to the awsutil ACM service
@@ -34,3 +38,110 @@ func (a *ACM) Status() func() error {
 		return nil
 	}
 }
+
+// FindCertificateArns returns the ARNs of issued certificates matching hosts, in the order of the
+// hosts they match. A certificate covering a host by name is preferred over a wildcard certificate.
+// Hosts without a matching certificate are skipped.
+func (a *ACM) FindCertificateArns(hosts []string) ([]*string, error) {
+	certificates, err := a.issuedCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	var arns []*string
+	seen := make(map[string]bool)
+	for _, host := range hosts {
+		arn := bestMatch(certificates, strings.ToLower(host))
+		if arn == "" || seen[arn] {
+			continue
+		}
+		seen[arn] = true
+		arns = append(arns, aws.String(arn))
+	}
+	return arns, nil
+}
+
+type certificate struct {
+	arn     string
+	domains []string
+}
+
+// issuedCertificates lists the issued certificates with a key type supported by ALB, sorted by
+// ARN so the same certificate is chosen among equal matches.
+func (a *ACM) issuedCertificates() ([]*certificate, error) {
+	var certificates []*certificate
+	var describeErr error
+
+	in := &acm.ListCertificatesInput{
+		CertificateStatuses: aws.StringSlice([]string{acm.CertificateStatusIssued}),
+		Includes: &acm.Filters{
+			KeyTypes: aws.StringSlice([]string{
+				acm.KeyAlgorithmRsa1024,
+				acm.KeyAlgorithmRsa2048,
+				acm.KeyAlgorithmRsa3072,
+				acm.KeyAlgorithmRsa4096,
+				acm.KeyAlgorithmEcPrime256v1,
+				acm.KeyAlgorithmEcSecp384r1,
+				acm.KeyAlgorithmEcSecp521r1,
+			}),
+		},
+	}
+	err := a.ListCertificatesPages(in, func(p *acm.ListCertificatesOutput, lastPage bool) bool {
+		for _, summary := range p.CertificateSummaryList {
+			c := &certificate{arn: aws.StringValue(summary.CertificateArn)}
+			names := append([]*string{summary.DomainName}, summary.SubjectAlternativeNameSummaries...)
+
+			// the summary only lists the first names of certificates with many alternative names
+			if aws.BoolValue(summary.HasAdditionalSubjectAlternativeNames) {
+				o, err := a.DescribeCertificate(&acm.DescribeCertificateInput{CertificateArn: summary.CertificateArn})
+				if err != nil {
+					describeErr = err
+					return false
+				}
+				names = append([]*string{o.Certificate.DomainName}, o.Certificate.SubjectAlternativeNames...)
+			}
+
+			for _, name := range names {
+				c.domains = append(c.domains, strings.ToLower(aws.StringValue(name)))
+			}
+			certificates = append(certificates, c)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[acm.ListCertificates]: %v", err)
+	}
+	if describeErr != nil {
+		return nil, fmt.Errorf("[acm.DescribeCertificate]: %v", describeErr)
+	}
+
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].arn < certificates[j].arn })
+	return certificates, nil
+}
+
+// bestMatch returns the ARN of the certificate matching host, preferring an exact match over a
+// wildcard match, or an empty string when no certificate matches.
+func bestMatch(certificates []*certificate, host string) string {
+	var wildcard string
+	for _, c := range certificates {
+		for _, domain := range c.domains {
+			switch {
+			case domain == host:
+				return c.arn
+			case wildcard == "" && matchesWildcard(domain, host):
+				wildcard = c.arn
+			}
+		}
+	}
+	return wildcard
+}
+
+// matchesWildcard returns true if domain is a wildcard name such as *.example.com covering host.
+// As with TLS, the wildcard only covers a single label.
+func matchesWildcard(domain, host string) bool {
+	if !strings.HasPrefix(domain, "*.") {
+		return false
+	}
+	i := strings.Index(host, ".")
+	return i > 0 && host[i:] == domain[1:]
+}
@@ -0,0 +1,71 @@
+package albacm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+)
+
+type mockACM struct {
+	acmiface.ACMAPI
+	summaries []*acm.CertificateSummary
+	details   map[string]*acm.CertificateDetail
+}
+
+func (m *mockACM) ListCertificatesPages(in *acm.ListCertificatesInput, fn func(*acm.ListCertificatesOutput, bool) bool) error {
+	fn(&acm.ListCertificatesOutput{CertificateSummaryList: m.summaries}, true)
+	return nil
+}
+
+func (m *mockACM) DescribeCertificate(in *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	return &acm.DescribeCertificateOutput{Certificate: m.details[*in.CertificateArn]}, nil
+}
+
+func TestFindCertificateArns(t *testing.T) {
+	a := &ACM{&mockACM{
+		summaries: []*acm.CertificateSummary{
+			{
+				CertificateArn: aws.String("arn:wildcard"),
+				DomainName:     aws.String("*.example.com"),
+			},
+			{
+				CertificateArn:                  aws.String("arn:exact"),
+				DomainName:                      aws.String("www.example.com"),
+				SubjectAlternativeNameSummaries: aws.StringSlice([]string{"www.example.com"}),
+			},
+			{
+				CertificateArn:                       aws.String("arn:many"),
+				DomainName:                           aws.String("a.example.org"),
+				SubjectAlternativeNameSummaries:      aws.StringSlice([]string{"a.example.org"}),
+				HasAdditionalSubjectAlternativeNames: aws.Bool(true),
+			},
+		},
+		details: map[string]*acm.CertificateDetail{
+			"arn:many": {
+				DomainName:              aws.String("a.example.org"),
+				SubjectAlternativeNames: aws.StringSlice([]string{"a.example.org", "z.example.org"}),
+			},
+		},
+	}}
+
+	for _, tc := range []struct {
+		hosts    []string
+		expected []string
+	}{
+		{hosts: []string{"WWW.example.com"}, expected: []string{"arn:exact"}},
+		{hosts: []string{"api.example.com", "www.example.com", "shop.example.com"}, expected: []string{"arn:wildcard", "arn:exact"}},
+		{hosts: []string{"a.b.example.com", "example.com"}, expected: []string{}},
+		{hosts: []string{"z.example.org"}, expected: []string{"arn:many"}},
+	} {
+		arns, err := a.FindCertificateArns(tc.hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := aws.StringValueSlice(arns); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("hosts %v: expected certificates %v, got %v", tc.hosts, tc.expected, actual)
+		}
+	}
+}
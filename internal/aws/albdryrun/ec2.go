@@ -0,0 +1,162 @@
+package albdryrun
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// NewEC2 returns an EC2API recording the mutating calls in the plan instead of sending them to svc
+func NewEC2(svc ec2iface.EC2API, plan *Plan) ec2iface.EC2API {
+	return &ec2API{EC2API: svc, plan: plan}
+}
+
+type ec2API struct {
+	ec2iface.EC2API
+	plan *Plan
+}
+
+func (e *ec2API) record(operation, action string, resource *string, before, after interface{}) {
+	e.plan.record(&Change{
+		Service:   ec2.ServiceName,
+		Operation: operation,
+		Action:    action,
+		Resource:  aws.StringValue(resource),
+		Before:    before,
+		After:     after,
+	})
+}
+
+// currentSecurityGroup returns the security group in AWS, or nil if it can't be described
+func (e *ec2API) currentSecurityGroup(id *string) *ec2.SecurityGroup {
+	o, err := e.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{id}})
+	if err != nil || len(o.SecurityGroups) == 0 {
+		return nil
+	}
+	return o.SecurityGroups[0]
+}
+
+func (e *ec2API) CreateSecurityGroup(in *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	id := aws.String(e.plan.newGroupID())
+
+	e.plan.mu.Lock()
+	e.plan.securityGroups[*id] = &ec2.SecurityGroup{
+		GroupId:     id,
+		GroupName:   in.GroupName,
+		Description: in.Description,
+		VpcId:       in.VpcId,
+	}
+	e.plan.mu.Unlock()
+
+	e.record("CreateSecurityGroup", ActionCreate, in.GroupName, nil, in)
+	return &ec2.CreateSecurityGroupOutput{GroupId: id}, nil
+}
+
+func (e *ec2API) DeleteSecurityGroup(in *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	e.record("DeleteSecurityGroup", ActionDelete, in.GroupId, e.currentSecurityGroup(in.GroupId), nil)
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func (e *ec2API) DeleteSecurityGroupWithContext(_ aws.Context, in *ec2.DeleteSecurityGroupInput, _ ...request.Option) (*ec2.DeleteSecurityGroupOutput, error) {
+	return e.DeleteSecurityGroup(in)
+}
+
+func (e *ec2API) AuthorizeSecurityGroupIngress(in *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	e.record("AuthorizeSecurityGroupIngress", ActionModify, in.GroupId, nil, in.IpPermissions)
+
+	e.plan.mu.Lock()
+	if sg, ok := e.plan.securityGroups[aws.StringValue(in.GroupId)]; ok {
+		sg.IpPermissions = append(sg.IpPermissions, in.IpPermissions...)
+	}
+	e.plan.mu.Unlock()
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (e *ec2API) RevokeSecurityGroupIngress(in *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	e.record("RevokeSecurityGroupIngress", ActionModify, in.GroupId, in.IpPermissions, nil)
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
+func (e *ec2API) CreateTags(in *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	for _, id := range in.Resources {
+		e.record("CreateTags", ActionModify, id, nil, in.Tags)
+	}
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (e *ec2API) DeleteTags(in *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	for _, id := range in.Resources {
+		e.record("DeleteTags", ActionModify, id, in.Tags, nil)
+	}
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+func (e *ec2API) ModifyNetworkInterfaceAttribute(in *ec2.ModifyNetworkInterfaceAttributeInput) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	e.record("ModifyNetworkInterfaceAttribute", ActionModify, in.NetworkInterfaceId, nil, in)
+	return &ec2.ModifyNetworkInterfaceAttributeOutput{}, nil
+}
+
+// The security groups created during the dry run don't exist in AWS, they are described from the plan.
+
+func (e *ec2API) DescribeSecurityGroups(in *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if groups, ok := e.planSecurityGroups(in); ok {
+		return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: groups}, nil
+	}
+	return e.EC2API.DescribeSecurityGroups(in)
+}
+
+func (e *ec2API) DescribeSecurityGroupsRequest(in *ec2.DescribeSecurityGroupsInput) (*request.Request, *ec2.DescribeSecurityGroupsOutput) {
+	req, out := e.EC2API.DescribeSecurityGroupsRequest(in)
+	if groups, ok := e.planSecurityGroups(in); ok {
+		localRequest(req, func() { out.SecurityGroups = groups })
+	}
+	return req, out
+}
+
+// planSecurityGroups returns the security groups of the plan when in only asks for security groups
+// created during the dry run
+func (e *ec2API) planSecurityGroups(in *ec2.DescribeSecurityGroupsInput) ([]*ec2.SecurityGroup, bool) {
+	if len(in.GroupIds) == 0 {
+		return nil, false
+	}
+	for _, id := range in.GroupIds {
+		if !isDryRunGroupID(id) {
+			return nil, false
+		}
+	}
+
+	e.plan.mu.Lock()
+	defer e.plan.mu.Unlock()
+	var groups []*ec2.SecurityGroup
+	for _, id := range in.GroupIds {
+		if sg, ok := e.plan.securityGroups[*id]; ok {
+			groups = append(groups, sg)
+		}
+	}
+	return groups, true
+}
+
+// localRequest answers req with fill instead of sending it to AWS
+func localRequest(req *request.Request, fill func()) {
+	req.Handlers.Sign.Clear()
+	req.Handlers.Send.Clear()
+	req.Handlers.ValidateResponse.Clear()
+	req.Handlers.UnmarshalMeta.Clear()
+	req.Handlers.UnmarshalError.Clear()
+	req.Handlers.Unmarshal.Clear()
+	req.Handlers.Retry.Clear()
+	req.Handlers.AfterRetry.Clear()
+	req.Handlers.Send.PushBack(func(r *request.Request) {
+		r.HTTPResponse = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}
+	})
+	req.Handlers.Unmarshal.PushBack(func(*request.Request) { fill() })
+}
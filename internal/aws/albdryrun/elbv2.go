@@ -0,0 +1,384 @@
+package albdryrun
+
+import (
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+)
+
+// NewELBV2 returns an ELBV2API recording the mutating calls in the plan instead of sending them to svc
+func NewELBV2(svc elbv2iface.ELBV2API, plan *Plan) elbv2iface.ELBV2API {
+	return &elbv2API{ELBV2API: svc, plan: plan}
+}
+
+type elbv2API struct {
+	elbv2iface.ELBV2API
+	plan *Plan
+}
+
+func (e *elbv2API) record(operation, action string, resource *string, before, after interface{}) {
+	e.plan.record(&Change{
+		Service:   elbv2.ServiceName,
+		Operation: operation,
+		Action:    action,
+		Resource:  aws.StringValue(resource),
+		Before:    before,
+		After:     after,
+	})
+}
+
+// currentLoadBalancer returns the load balancer in AWS, or nil if it can't be described
+func (e *elbv2API) currentLoadBalancer(arn *string) *elbv2.LoadBalancer {
+	o, err := e.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: []*string{arn}})
+	if err != nil || len(o.LoadBalancers) == 0 {
+		return nil
+	}
+	return o.LoadBalancers[0]
+}
+
+// currentTargetGroup returns the target group in AWS, or nil if it can't be described
+func (e *elbv2API) currentTargetGroup(arn *string) *elbv2.TargetGroup {
+	o, err := e.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{arn}})
+	if err != nil || len(o.TargetGroups) == 0 {
+		return nil
+	}
+	return o.TargetGroups[0]
+}
+
+// currentListener returns the listener in AWS, or nil if it can't be described
+func (e *elbv2API) currentListener(arn *string) *elbv2.Listener {
+	if IsDryRunARN(arn) {
+		return nil
+	}
+	o, err := e.DescribeListeners(&elbv2.DescribeListenersInput{ListenerArns: []*string{arn}})
+	if err != nil || len(o.Listeners) == 0 {
+		return nil
+	}
+	return o.Listeners[0]
+}
+
+// currentRule returns the rule in AWS, or nil if it can't be described
+func (e *elbv2API) currentRule(arn *string) *elbv2.Rule {
+	if IsDryRunARN(arn) {
+		return nil
+	}
+	o, err := e.DescribeRules(&elbv2.DescribeRulesInput{RuleArns: []*string{arn}})
+	if err != nil || len(o.Rules) == 0 {
+		return nil
+	}
+	return o.Rules[0]
+}
+
+func (e *elbv2API) CreateLoadBalancer(in *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+	lbType := aws.StringValue(in.Type)
+	if lbType == "" {
+		lbType = elbv2.LoadBalancerTypeEnumApplication
+	}
+	arn := aws.String(e.plan.newARN("loadbalancer/"+map[string]string{
+		elbv2.LoadBalancerTypeEnumApplication: "app",
+		elbv2.LoadBalancerTypeEnumNetwork:     "net",
+	}[lbType], aws.StringValue(in.Name)))
+
+	lb := &elbv2.LoadBalancer{
+		LoadBalancerArn:  arn,
+		LoadBalancerName: in.Name,
+		DNSName:          aws.String(aws.StringValue(in.Name) + ".dry-run"),
+		Scheme:           in.Scheme,
+		IpAddressType:    in.IpAddressType,
+		Type:             aws.String(lbType),
+		SecurityGroups:   in.SecurityGroups,
+		State:            &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)},
+	}
+	if lb.IpAddressType == nil {
+		lb.IpAddressType = aws.String(elbv2.IpAddressTypeIpv4)
+	}
+	for _, subnet := range in.Subnets {
+		lb.AvailabilityZones = append(lb.AvailabilityZones, &elbv2.AvailabilityZone{SubnetId: subnet})
+	}
+
+	e.plan.mu.Lock()
+	e.plan.loadBalancers[*arn] = lb
+	e.plan.mu.Unlock()
+
+	e.record("CreateLoadBalancer", ActionCreate, in.Name, nil, in)
+	return &elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{lb}}, nil
+}
+
+func (e *elbv2API) DeleteLoadBalancer(in *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+	e.record("DeleteLoadBalancer", ActionDelete, in.LoadBalancerArn, e.currentLoadBalancer(in.LoadBalancerArn), nil)
+	return &elbv2.DeleteLoadBalancerOutput{}, nil
+}
+
+func (e *elbv2API) SetSubnets(in *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error) {
+	e.record("SetSubnets", ActionModify, in.LoadBalancerArn, e.currentLoadBalancer(in.LoadBalancerArn), in)
+	return &elbv2.SetSubnetsOutput{}, nil
+}
+
+func (e *elbv2API) SetIpAddressType(in *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
+	e.record("SetIpAddressType", ActionModify, in.LoadBalancerArn, e.currentLoadBalancer(in.LoadBalancerArn), in)
+	return &elbv2.SetIpAddressTypeOutput{IpAddressType: in.IpAddressType}, nil
+}
+
+func (e *elbv2API) SetSecurityGroups(in *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error) {
+	e.record("SetSecurityGroups", ActionModify, in.LoadBalancerArn, e.currentLoadBalancer(in.LoadBalancerArn), in)
+	e.plan.mu.Lock()
+	if lb, ok := e.plan.loadBalancers[aws.StringValue(in.LoadBalancerArn)]; ok {
+		lb.SecurityGroups = in.SecurityGroups
+	}
+	e.plan.mu.Unlock()
+	return &elbv2.SetSecurityGroupsOutput{SecurityGroupIds: in.SecurityGroups}, nil
+}
+
+func (e *elbv2API) ModifyLoadBalancerAttributes(in *elbv2.ModifyLoadBalancerAttributesInput) (*elbv2.ModifyLoadBalancerAttributesOutput, error) {
+	var before interface{}
+	if o, err := e.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: in.LoadBalancerArn}); err == nil && len(o.Attributes) > 0 {
+		before = o.Attributes
+	}
+	e.record("ModifyLoadBalancerAttributes", ActionModify, in.LoadBalancerArn, before, in.Attributes)
+	return &elbv2.ModifyLoadBalancerAttributesOutput{Attributes: in.Attributes}, nil
+}
+
+func (e *elbv2API) CreateTargetGroup(in *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+	arn := aws.String(e.plan.newARN("targetgroup", aws.StringValue(in.Name)))
+	tg := &elbv2.TargetGroup{
+		TargetGroupArn:             arn,
+		TargetGroupName:            in.Name,
+		Port:                       in.Port,
+		Protocol:                   in.Protocol,
+		TargetType:                 in.TargetType,
+		IpAddressType:              in.IpAddressType,
+		VpcId:                      in.VpcId,
+		HealthCheckPath:            in.HealthCheckPath,
+		HealthCheckPort:            in.HealthCheckPort,
+		HealthCheckProtocol:        in.HealthCheckProtocol,
+		HealthCheckIntervalSeconds: in.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  in.HealthCheckTimeoutSeconds,
+		HealthyThresholdCount:      in.HealthyThresholdCount,
+		UnhealthyThresholdCount:    in.UnhealthyThresholdCount,
+		Matcher:                    in.Matcher,
+	}
+
+	e.plan.mu.Lock()
+	e.plan.targetGroups[*arn] = tg
+	e.plan.mu.Unlock()
+
+	e.record("CreateTargetGroup", ActionCreate, in.Name, nil, in)
+	return &elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{tg}}, nil
+}
+
+func (e *elbv2API) ModifyTargetGroup(in *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) {
+	before := e.currentTargetGroup(in.TargetGroupArn)
+	e.record("ModifyTargetGroup", ActionModify, in.TargetGroupArn, before, in)
+
+	tg := &elbv2.TargetGroup{}
+	if before != nil {
+		*tg = *before
+	}
+	tg.TargetGroupArn = in.TargetGroupArn
+	tg.HealthCheckPath = in.HealthCheckPath
+	tg.HealthCheckPort = in.HealthCheckPort
+	tg.HealthCheckProtocol = in.HealthCheckProtocol
+	tg.HealthCheckIntervalSeconds = in.HealthCheckIntervalSeconds
+	tg.HealthCheckTimeoutSeconds = in.HealthCheckTimeoutSeconds
+	tg.HealthyThresholdCount = in.HealthyThresholdCount
+	tg.UnhealthyThresholdCount = in.UnhealthyThresholdCount
+	tg.Matcher = in.Matcher
+	return &elbv2.ModifyTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{tg}}, nil
+}
+
+func (e *elbv2API) ModifyTargetGroupAttributes(in *elbv2.ModifyTargetGroupAttributesInput) (*elbv2.ModifyTargetGroupAttributesOutput, error) {
+	var before interface{}
+	if o, err := e.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: in.TargetGroupArn}); err == nil && len(o.Attributes) > 0 {
+		before = o.Attributes
+	}
+	e.record("ModifyTargetGroupAttributes", ActionModify, in.TargetGroupArn, before, in.Attributes)
+	return &elbv2.ModifyTargetGroupAttributesOutput{Attributes: in.Attributes}, nil
+}
+
+func (e *elbv2API) DeleteTargetGroup(in *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+	e.record("DeleteTargetGroup", ActionDelete, in.TargetGroupArn, e.currentTargetGroup(in.TargetGroupArn), nil)
+	return &elbv2.DeleteTargetGroupOutput{}, nil
+}
+
+func (e *elbv2API) RegisterTargets(in *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	e.record("RegisterTargets", ActionModify, in.TargetGroupArn, nil, in.Targets)
+	return &elbv2.RegisterTargetsOutput{}, nil
+}
+
+func (e *elbv2API) DeregisterTargets(in *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+	e.record("DeregisterTargets", ActionModify, in.TargetGroupArn, in.Targets, nil)
+	return &elbv2.DeregisterTargetsOutput{}, nil
+}
+
+func (e *elbv2API) CreateListener(in *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	arn := aws.String(e.plan.newARN("listener", aws.StringValue(in.Protocol)))
+	e.record("CreateListener", ActionCreate, in.LoadBalancerArn, nil, in)
+	return &elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{{
+		ListenerArn:     arn,
+		LoadBalancerArn: in.LoadBalancerArn,
+		Port:            in.Port,
+		Protocol:        in.Protocol,
+		Certificates:    in.Certificates,
+		SslPolicy:       in.SslPolicy,
+		DefaultActions:  in.DefaultActions,
+	}}}, nil
+}
+
+func (e *elbv2API) ModifyListener(in *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
+	e.record("ModifyListener", ActionModify, in.ListenerArn, e.currentListener(in.ListenerArn), in)
+	return &elbv2.ModifyListenerOutput{Listeners: []*elbv2.Listener{{
+		ListenerArn:    in.ListenerArn,
+		Port:           in.Port,
+		Protocol:       in.Protocol,
+		Certificates:   in.Certificates,
+		SslPolicy:      in.SslPolicy,
+		DefaultActions: in.DefaultActions,
+	}}}, nil
+}
+
+func (e *elbv2API) DeleteListener(in *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
+	e.record("DeleteListener", ActionDelete, in.ListenerArn, e.currentListener(in.ListenerArn), nil)
+	return &elbv2.DeleteListenerOutput{}, nil
+}
+
+func (e *elbv2API) AddListenerCertificates(in *elbv2.AddListenerCertificatesInput) (*elbv2.AddListenerCertificatesOutput, error) {
+	e.record("AddListenerCertificates", ActionModify, in.ListenerArn, nil, in.Certificates)
+	return &elbv2.AddListenerCertificatesOutput{Certificates: in.Certificates}, nil
+}
+
+func (e *elbv2API) RemoveListenerCertificates(in *elbv2.RemoveListenerCertificatesInput) (*elbv2.RemoveListenerCertificatesOutput, error) {
+	e.record("RemoveListenerCertificates", ActionModify, in.ListenerArn, in.Certificates, nil)
+	return &elbv2.RemoveListenerCertificatesOutput{}, nil
+}
+
+func (e *elbv2API) CreateRule(in *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error) {
+	arn := aws.String(e.plan.newARN("listener-rule", "rule"))
+	e.record("CreateRule", ActionCreate, in.ListenerArn, nil, in)
+	return &elbv2.CreateRuleOutput{Rules: []*elbv2.Rule{{
+		RuleArn:    arn,
+		Priority:   aws.String(strconv.FormatInt(aws.Int64Value(in.Priority), 10)),
+		Conditions: in.Conditions,
+		Actions:    in.Actions,
+	}}}, nil
+}
+
+func (e *elbv2API) ModifyRule(in *elbv2.ModifyRuleInput) (*elbv2.ModifyRuleOutput, error) {
+	e.record("ModifyRule", ActionModify, in.RuleArn, e.currentRule(in.RuleArn), in)
+	return &elbv2.ModifyRuleOutput{Rules: []*elbv2.Rule{{
+		RuleArn:    in.RuleArn,
+		Conditions: in.Conditions,
+		Actions:    in.Actions,
+	}}}, nil
+}
+
+func (e *elbv2API) DeleteRule(in *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error) {
+	e.record("DeleteRule", ActionDelete, in.RuleArn, e.currentRule(in.RuleArn), nil)
+	return &elbv2.DeleteRuleOutput{}, nil
+}
+
+func (e *elbv2API) SetRulePriorities(in *elbv2.SetRulePrioritiesInput) (*elbv2.SetRulePrioritiesOutput, error) {
+	e.record("SetRulePriorities", ActionModify, nil, nil, in.RulePriorities)
+	return &elbv2.SetRulePrioritiesOutput{}, nil
+}
+
+func (e *elbv2API) AddTags(in *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
+	for _, arn := range in.ResourceArns {
+		e.record("AddTags", ActionModify, arn, nil, in.Tags)
+	}
+	return &elbv2.AddTagsOutput{}, nil
+}
+
+func (e *elbv2API) RemoveTags(in *elbv2.RemoveTagsInput) (*elbv2.RemoveTagsOutput, error) {
+	for _, arn := range in.ResourceArns {
+		e.record("RemoveTags", ActionModify, arn, in.TagKeys, nil)
+	}
+	return &elbv2.RemoveTagsOutput{}, nil
+}
+
+// The resources created during the dry run don't exist in AWS, they are described from the plan.
+
+func (e *elbv2API) DescribeLoadBalancers(in *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	if len(in.LoadBalancerArns) == 1 && IsDryRunARN(in.LoadBalancerArns[0]) {
+		e.plan.mu.Lock()
+		defer e.plan.mu.Unlock()
+		if lb, ok := e.plan.loadBalancers[*in.LoadBalancerArns[0]]; ok {
+			return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{lb}}, nil
+		}
+		return &elbv2.DescribeLoadBalancersOutput{}, nil
+	}
+	return e.ELBV2API.DescribeLoadBalancers(in)
+}
+
+func (e *elbv2API) DescribeLoadBalancerAttributes(in *elbv2.DescribeLoadBalancerAttributesInput) (*elbv2.DescribeLoadBalancerAttributesOutput, error) {
+	if IsDryRunARN(in.LoadBalancerArn) {
+		return &elbv2.DescribeLoadBalancerAttributesOutput{}, nil
+	}
+	return e.ELBV2API.DescribeLoadBalancerAttributes(in)
+}
+
+func (e *elbv2API) DescribeTargetGroups(in *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	if len(in.TargetGroupArns) == 1 && IsDryRunARN(in.TargetGroupArns[0]) {
+		e.plan.mu.Lock()
+		defer e.plan.mu.Unlock()
+		if tg, ok := e.plan.targetGroups[*in.TargetGroupArns[0]]; ok {
+			return &elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{tg}}, nil
+		}
+		return &elbv2.DescribeTargetGroupsOutput{}, nil
+	}
+	if IsDryRunARN(in.LoadBalancerArn) {
+		return &elbv2.DescribeTargetGroupsOutput{}, nil
+	}
+	return e.ELBV2API.DescribeTargetGroups(in)
+}
+
+func (e *elbv2API) DescribeTargetGroupAttributes(in *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+	if IsDryRunARN(in.TargetGroupArn) {
+		return &elbv2.DescribeTargetGroupAttributesOutput{}, nil
+	}
+	return e.ELBV2API.DescribeTargetGroupAttributes(in)
+}
+
+func (e *elbv2API) DescribeTargetHealth(in *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	if IsDryRunARN(in.TargetGroupArn) {
+		return &elbv2.DescribeTargetHealthOutput{}, nil
+	}
+	return e.ELBV2API.DescribeTargetHealth(in)
+}
+
+func (e *elbv2API) DescribeTags(in *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	var arns []*string
+	for _, arn := range in.ResourceArns {
+		if !IsDryRunARN(arn) {
+			arns = append(arns, arn)
+		}
+	}
+	if len(arns) == 0 {
+		return &elbv2.DescribeTagsOutput{}, nil
+	}
+	return e.ELBV2API.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: arns})
+}
+
+func (e *elbv2API) DescribeRules(in *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error) {
+	if IsDryRunARN(in.ListenerArn) {
+		return &elbv2.DescribeRulesOutput{}, nil
+	}
+	return e.ELBV2API.DescribeRules(in)
+}
+
+func (e *elbv2API) DescribeListenerCertificates(in *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error) {
+	if IsDryRunARN(in.ListenerArn) {
+		return &elbv2.DescribeListenerCertificatesOutput{}, nil
+	}
+	return e.ELBV2API.DescribeListenerCertificates(in)
+}
+
+func (e *elbv2API) DescribeListenersPagesWithContext(ctx aws.Context, in *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool, opts ...request.Option) error {
+	if IsDryRunARN(in.LoadBalancerArn) {
+		fn(&elbv2.DescribeListenersOutput{}, true)
+		return nil
+	}
+	return e.ELBV2API.DescribeListenersPagesWithContext(ctx, in, fn, opts...)
+}
@@ -0,0 +1,29 @@
+package albdryrun
+
+import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albrgt"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albroute53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albshield"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafregional"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafv2"
+)
+
+// Install wraps the AWS clients of the controller, so their mutating calls are recorded in the plan
+// instead of being sent to AWS. The clients must have been created beforehand.
+func Install(plan *Plan) {
+	elbv2svc := albelbv2.ELBV2svc.(*albelbv2.ELBV2)
+	elbv2svc.ELBV2API = NewELBV2(elbv2svc.ELBV2API, plan)
+
+	ec2svc := albec2.EC2svc.(*albec2.EC2)
+	ec2svc.EC2API = NewEC2(ec2svc.EC2API, plan)
+
+	rgtsvc := albrgt.RGTsvc.(*albrgt.RGT)
+	rgtsvc.ResourceGroupsTaggingAPIAPI = NewRGT(rgtsvc.ResourceGroupsTaggingAPIAPI, plan)
+
+	albwafregional.WAFRegionalsvc.WAFRegionalAPI = NewWAFRegional(albwafregional.WAFRegionalsvc.WAFRegionalAPI, plan)
+	albwafv2.WAFV2svc.WAFV2API = NewWAFV2(albwafv2.WAFV2svc.WAFV2API, plan)
+	albshield.Shieldsvc.ShieldAPI = NewShield(albshield.Shieldsvc.ShieldAPI, plan)
+	albroute53.Route53svc.Route53API = NewRoute53(albroute53.Route53svc.Route53API, plan)
+}
@@ -0,0 +1,155 @@
+package albdryrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+)
+
+const (
+	// ActionCreate is a resource that would be created
+	ActionCreate = "create"
+	// ActionModify is a resource that would be modified
+	ActionModify = "modify"
+	// ActionDelete is a resource that would be deleted
+	ActionDelete = "delete"
+
+	// arnPrefix is the prefix of the ARNs of the resources created during a dry run. The region
+	// is replaced so they can never match a real resource.
+	arnPrefix = "arn:aws:elasticloadbalancing:dry-run:000000000000:"
+	// groupIDPrefix is the prefix of the ids of the security groups created during a dry run
+	groupIDPrefix = "sg-dry-run-"
+	// protectionIDPrefix is the prefix of the ids of the Shield protections created during a dry run
+	protectionIDPrefix = "dry-run-protection-"
+)
+
+// Change is a mutating AWS call skipped during a dry run
+type Change struct {
+	Service   string      `json:"service"`
+	Operation string      `json:"operation"`
+	Action    string      `json:"action"`
+	Resource  string      `json:"resource"`
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+}
+
+// Plan records the changes the controller would apply to AWS. The changes of a sync are
+// collected between Begin and Complete, the last completed sync is served over HTTP.
+type Plan struct {
+	mu sync.Mutex
+
+	changes []*Change
+	last    []*Change
+	lastRun time.Time
+
+	// resources created during the sync in progress, so they can be described
+	loadBalancers  map[string]*elbv2.LoadBalancer
+	targetGroups   map[string]*elbv2.TargetGroup
+	securityGroups map[string]*ec2.SecurityGroup
+	count          int
+}
+
+// NewPlan returns an empty Plan
+func NewPlan() *Plan {
+	p := &Plan{}
+	p.reset()
+	return p
+}
+
+func (p *Plan) reset() {
+	p.changes = nil
+	p.loadBalancers = make(map[string]*elbv2.LoadBalancer)
+	p.targetGroups = make(map[string]*elbv2.TargetGroup)
+	p.securityGroups = make(map[string]*ec2.SecurityGroup)
+	p.count = 0
+}
+
+// Begin starts the plan of a new sync
+func (p *Plan) Begin() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reset()
+}
+
+// Complete ends the plan of the sync in progress and makes it available over HTTP
+func (p *Plan) Complete() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.last = p.changes
+	p.lastRun = time.Now()
+	glog.Infof("Dry run completed, %d changes planned", len(p.last))
+	p.reset()
+}
+
+// Changes returns the changes of the last completed sync
+func (p *Plan) Changes() []*Change {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.last
+}
+
+// ServeHTTP writes the changes of the last completed sync as JSON
+func (p *Plan) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	body := struct {
+		Time    time.Time `json:"time"`
+		Changes []*Change `json:"changes"`
+	}{p.lastRun, p.last}
+	b, err := json.MarshalIndent(body, "", "  ")
+	p.mu.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func (p *Plan) record(c *Change) {
+	glog.Infof("Dry run, skipping %s %s of %s", c.Service, c.Operation, c.Resource)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, c)
+}
+
+// newARN returns an ARN for a resource created during the dry run
+func (p *Plan) newARN(resource, name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	return fmt.Sprintf("%s%s/%s/%d", arnPrefix, resource, name, p.count)
+}
+
+// newGroupID returns the id of a security group created during the dry run
+func (p *Plan) newGroupID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	return fmt.Sprintf("%s%d", groupIDPrefix, p.count)
+}
+
+// newProtectionID returns the id of a Shield protection created during the dry run
+func (p *Plan) newProtectionID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	return fmt.Sprintf("%s%d", protectionIDPrefix, p.count)
+}
+
+// IsDryRunARN returns true if the ARN is the ARN of a resource created during a dry run
+func IsDryRunARN(arn *string) bool {
+	return arn != nil && strings.HasPrefix(*arn, arnPrefix)
+}
+
+// isDryRunGroupID returns true if the id is the id of a security group created during a dry run
+func isDryRunGroupID(id *string) bool {
+	return id != nil && strings.HasPrefix(*id, groupIDPrefix)
+}
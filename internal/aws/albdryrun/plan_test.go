@@ -0,0 +1,138 @@
+package albdryrun
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+)
+
+const lbArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb/1234"
+
+func TestCreateLoadBalancer(t *testing.T) {
+	plan := NewPlan()
+	plan.Begin()
+	svc := NewELBV2(&mocks.ELBV2API{}, plan)
+
+	o, err := svc.CreateLoadBalancer(&elbv2.CreateLoadBalancerInput{
+		Name:    aws.String("lb"),
+		Scheme:  aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+		Subnets: aws.StringSlice([]string{"subnet-a", "subnet-b"}),
+	})
+	assert.NoError(t, err)
+	lb := o.LoadBalancers[0]
+	assert.True(t, IsDryRunARN(lb.LoadBalancerArn))
+	assert.Len(t, lb.AvailabilityZones, 2)
+
+	// resources created during the dry run are described from the plan
+	d, err := svc.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: []*string{lb.LoadBalancerArn}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.LoadBalancer{lb}, d.LoadBalancers)
+	a, err := svc.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: lb.LoadBalancerArn})
+	assert.NoError(t, err)
+	assert.Empty(t, a.Attributes)
+
+	plan.Complete()
+	changes := plan.Changes()
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "CreateLoadBalancer", changes[0].Operation)
+	assert.Equal(t, ActionCreate, changes[0].Action)
+	assert.Equal(t, "lb", changes[0].Resource)
+	assert.Nil(t, changes[0].Before)
+}
+
+func TestDeleteLoadBalancer(t *testing.T) {
+	current := &elbv2.LoadBalancer{LoadBalancerArn: aws.String(lbArn), LoadBalancerName: aws.String("lb")}
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("DescribeLoadBalancers", &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: []*string{aws.String(lbArn)}}).
+		Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{current}}, nil)
+
+	plan := NewPlan()
+	plan.Begin()
+	_, err := NewELBV2(elbv2svc, plan).DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(lbArn)})
+	assert.NoError(t, err)
+	plan.Complete()
+
+	assert.Equal(t, []*Change{{
+		Service:   elbv2.ServiceName,
+		Operation: "DeleteLoadBalancer",
+		Action:    ActionDelete,
+		Resource:  lbArn,
+		Before:    current,
+	}}, plan.Changes())
+	elbv2svc.AssertNotCalled(t, "DeleteLoadBalancer")
+	elbv2svc.AssertExpectations(t)
+}
+
+func TestPlanBeginDiscardsPendingChanges(t *testing.T) {
+	plan := NewPlan()
+	plan.Begin()
+	svc := NewELBV2(&mocks.ELBV2API{}, plan)
+	svc.RegisterTargets(&elbv2.RegisterTargetsInput{TargetGroupArn: aws.String("tg")})
+	plan.Complete()
+	assert.Len(t, plan.Changes(), 1)
+
+	// the changes of the last completed sync stay available while the next sync runs
+	plan.Begin()
+	svc.RegisterTargets(&elbv2.RegisterTargetsInput{TargetGroupArn: aws.String("tg")})
+	svc.DeregisterTargets(&elbv2.DeregisterTargetsInput{TargetGroupArn: aws.String("tg")})
+	assert.Len(t, plan.Changes(), 1)
+	plan.Complete()
+	assert.Len(t, plan.Changes(), 2)
+}
+
+func TestDescribeSecurityGroupsRequest(t *testing.T) {
+	// the endpoint is unreachable, requests sent to AWS fail
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Endpoint:    aws.String("http://127.0.0.1:1"),
+		Credentials: credentials.AnonymousCredentials,
+		MaxRetries:  aws.Int(0),
+	}))
+	plan := NewPlan()
+	plan.Begin()
+	svc := NewEC2(ec2.New(sess), plan)
+
+	o, err := svc.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{GroupName: aws.String("sg"), Description: aws.String("sg")})
+	assert.NoError(t, err)
+
+	req, out := svc.DescribeSecurityGroupsRequest(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{o.GroupId}})
+	assert.NoError(t, req.Send())
+	assert.Len(t, out.SecurityGroups, 1)
+	assert.Equal(t, o.GroupId, out.SecurityGroups[0].GroupId)
+
+	req, _ = svc.DescribeSecurityGroupsRequest(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice([]string{"sg-1234"})})
+	assert.Error(t, req.Send())
+}
+
+func TestServeHTTP(t *testing.T) {
+	plan := NewPlan()
+	plan.Begin()
+	NewELBV2(&mocks.ELBV2API{}, plan).CreateTargetGroup(&elbv2.CreateTargetGroupInput{Name: aws.String("tg")})
+	plan.Complete()
+
+	w := httptest.NewRecorder()
+	plan.ServeHTTP(w, httptest.NewRequest("GET", "/plan", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body struct {
+		Changes []struct {
+			Operation string
+			Resource  string
+			After     map[string]interface{}
+		}
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Changes, 1)
+	assert.Equal(t, "CreateTargetGroup", body.Changes[0].Operation)
+	assert.Equal(t, "tg", body.Changes[0].Resource)
+	assert.Equal(t, "tg", body.Changes[0].After["Name"])
+}
@@ -0,0 +1,43 @@
+package albdryrun
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+)
+
+// NewRGT returns a ResourceGroupsTaggingAPIAPI recording the tag changes in the plan instead of sending them to svc
+func NewRGT(svc resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, plan *Plan) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	return &rgtAPI{ResourceGroupsTaggingAPIAPI: svc, plan: plan}
+}
+
+type rgtAPI struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	plan *Plan
+}
+
+func (r *rgtAPI) TagResources(in *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	for _, arn := range in.ResourceARNList {
+		r.plan.record(&Change{
+			Service:   resourcegroupstaggingapi.ServiceName,
+			Operation: "TagResources",
+			Action:    ActionModify,
+			Resource:  aws.StringValue(arn),
+			After:     in.Tags,
+		})
+	}
+	return &resourcegroupstaggingapi.TagResourcesOutput{}, nil
+}
+
+func (r *rgtAPI) UntagResources(in *resourcegroupstaggingapi.UntagResourcesInput) (*resourcegroupstaggingapi.UntagResourcesOutput, error) {
+	for _, arn := range in.ResourceARNList {
+		r.plan.record(&Change{
+			Service:   resourcegroupstaggingapi.ServiceName,
+			Operation: "UntagResources",
+			Action:    ActionModify,
+			Resource:  aws.StringValue(arn),
+			Before:    in.TagKeys,
+		})
+	}
+	return &resourcegroupstaggingapi.UntagResourcesOutput{}, nil
+}
@@ -0,0 +1,39 @@
+package albdryrun
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// NewRoute53 returns a Route53API recording the changes of resource record sets in the plan instead of sending them to svc
+func NewRoute53(svc route53iface.Route53API, plan *Plan) route53iface.Route53API {
+	return &route53API{Route53API: svc, plan: plan}
+}
+
+type route53API struct {
+	route53iface.Route53API
+	plan *Plan
+}
+
+func (r *route53API) ChangeResourceRecordSets(in *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	for _, change := range in.ChangeBatch.Changes {
+		c := &Change{
+			Service:   route53.ServiceName,
+			Operation: "ChangeResourceRecordSets",
+			Resource:  aws.StringValue(change.ResourceRecordSet.Name) + " " + aws.StringValue(change.ResourceRecordSet.Type),
+		}
+		switch aws.StringValue(change.Action) {
+		case route53.ChangeActionCreate:
+			c.Action, c.After = ActionCreate, change.ResourceRecordSet
+		case route53.ChangeActionUpsert:
+			c.Action, c.After = ActionModify, change.ResourceRecordSet
+		case route53.ChangeActionDelete:
+			c.Action, c.Before = ActionDelete, change.ResourceRecordSet
+		}
+		r.plan.record(c)
+	}
+	return &route53.ChangeResourceRecordSetsOutput{
+		ChangeInfo: &route53.ChangeInfo{Status: aws.String(route53.ChangeStatusInsync)},
+	}, nil
+}
@@ -0,0 +1,38 @@
+package albdryrun
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
+)
+
+// NewShield returns a ShieldAPI recording the protections created and deleted in the plan instead of sending them to svc
+func NewShield(svc shieldiface.ShieldAPI, plan *Plan) shieldiface.ShieldAPI {
+	return &shieldAPI{ShieldAPI: svc, plan: plan}
+}
+
+type shieldAPI struct {
+	shieldiface.ShieldAPI
+	plan *Plan
+}
+
+func (s *shieldAPI) CreateProtection(in *shield.CreateProtectionInput) (*shield.CreateProtectionOutput, error) {
+	s.plan.record(&Change{
+		Service:   shield.ServiceName,
+		Operation: "CreateProtection",
+		Action:    ActionCreate,
+		Resource:  aws.StringValue(in.ResourceArn),
+		After:     in,
+	})
+	return &shield.CreateProtectionOutput{ProtectionId: aws.String(s.plan.newProtectionID())}, nil
+}
+
+func (s *shieldAPI) DeleteProtection(in *shield.DeleteProtectionInput) (*shield.DeleteProtectionOutput, error) {
+	s.plan.record(&Change{
+		Service:   shield.ServiceName,
+		Operation: "DeleteProtection",
+		Action:    ActionDelete,
+		Resource:  aws.StringValue(in.ProtectionId),
+	})
+	return &shield.DeleteProtectionOutput{}, nil
+}
@@ -0,0 +1,52 @@
+package albdryrun
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/aws/aws-sdk-go/service/wafregional/wafregionaliface"
+)
+
+// NewWAFRegional returns a WAFRegionalAPI recording the Web ACL associations in the plan instead of sending them to svc
+func NewWAFRegional(svc wafregionaliface.WAFRegionalAPI, plan *Plan) wafregionaliface.WAFRegionalAPI {
+	return &wafRegionalAPI{WAFRegionalAPI: svc, plan: plan}
+}
+
+type wafRegionalAPI struct {
+	wafregionaliface.WAFRegionalAPI
+	plan *Plan
+}
+
+// currentWebACL returns the Web ACL associated to the resource, or nil if it can't be described
+func (w *wafRegionalAPI) currentWebACL(arn *string) interface{} {
+	if IsDryRunARN(arn) {
+		return nil
+	}
+	o, err := w.GetWebACLForResource(&wafregional.GetWebACLForResourceInput{ResourceArn: arn})
+	if err != nil || o.WebACLSummary == nil {
+		return nil
+	}
+	return o.WebACLSummary
+}
+
+func (w *wafRegionalAPI) AssociateWebACL(in *wafregional.AssociateWebACLInput) (*wafregional.AssociateWebACLOutput, error) {
+	w.plan.record(&Change{
+		Service:   wafregional.ServiceName,
+		Operation: "AssociateWebACL",
+		Action:    ActionModify,
+		Resource:  aws.StringValue(in.ResourceArn),
+		Before:    w.currentWebACL(in.ResourceArn),
+		After:     in.WebACLId,
+	})
+	return &wafregional.AssociateWebACLOutput{}, nil
+}
+
+func (w *wafRegionalAPI) DisassociateWebACL(in *wafregional.DisassociateWebACLInput) (*wafregional.DisassociateWebACLOutput, error) {
+	w.plan.record(&Change{
+		Service:   wafregional.ServiceName,
+		Operation: "DisassociateWebACL",
+		Action:    ActionModify,
+		Resource:  aws.StringValue(in.ResourceArn),
+		Before:    w.currentWebACL(in.ResourceArn),
+	})
+	return &wafregional.DisassociateWebACLOutput{}, nil
+}
@@ -0,0 +1,52 @@
+package albdryrun
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+)
+
+// NewWAFV2 returns a WAFV2API recording the Web ACL associations in the plan instead of sending them to svc
+func NewWAFV2(svc wafv2iface.WAFV2API, plan *Plan) wafv2iface.WAFV2API {
+	return &wafV2API{WAFV2API: svc, plan: plan}
+}
+
+type wafV2API struct {
+	wafv2iface.WAFV2API
+	plan *Plan
+}
+
+// currentWebACL returns the ARN of the Web ACL associated to the resource, or nil if it can't be described
+func (w *wafV2API) currentWebACL(arn *string) interface{} {
+	if IsDryRunARN(arn) {
+		return nil
+	}
+	o, err := w.GetWebACLForResource(&wafv2.GetWebACLForResourceInput{ResourceArn: arn})
+	if err != nil || o.WebACL == nil {
+		return nil
+	}
+	return o.WebACL.ARN
+}
+
+func (w *wafV2API) AssociateWebACL(in *wafv2.AssociateWebACLInput) (*wafv2.AssociateWebACLOutput, error) {
+	w.plan.record(&Change{
+		Service:   wafv2.ServiceName,
+		Operation: "AssociateWebACL",
+		Action:    ActionModify,
+		Resource:  aws.StringValue(in.ResourceArn),
+		Before:    w.currentWebACL(in.ResourceArn),
+		After:     in.WebACLArn,
+	})
+	return &wafv2.AssociateWebACLOutput{}, nil
+}
+
+func (w *wafV2API) DisassociateWebACL(in *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error) {
+	w.plan.record(&Change{
+		Service:   wafv2.ServiceName,
+		Operation: "DisassociateWebACL",
+		Action:    ActionModify,
+		Resource:  aws.StringValue(in.ResourceArn),
+		Before:    w.currentWebACL(in.ResourceArn),
+	})
+	return &wafv2.DisassociateWebACLOutput{}, nil
+}
@@ -35,6 +35,11 @@ func (d *Dummy) ClusterLoadBalancers() ([]*elbv2.LoadBalancer, error) {
 	return nil, nil
 }
 
+// ClusterNetworkLoadBalancers ...
+func (d *Dummy) ClusterNetworkLoadBalancers() ([]*elbv2.LoadBalancer, error) {
+	return nil, nil
+}
+
 // ClusterTargetGroups ...
 func (d *Dummy) ClusterTargetGroups() (map[string][]*elbv2.TargetGroup, error) {
 	return nil, nil
@@ -54,6 +59,24 @@ func (d *Dummy) DescribeListenersForLoadBalancer(loadBalancerArn *string) ([]*el
 	return nil, nil
 }
 
+// DescribeListenerCertificatesForListener ...
+func (d *Dummy) DescribeListenerCertificatesForListener(listenerArn *string) ([]*elbv2.Certificate, error) {
+	if v, ok := d.outputs["DescribeListenerCertificatesForListener"]; ok {
+		return v.([]*elbv2.Certificate), d.outputs.error("DescribeListenerCertificatesForListenerError")
+	}
+	return nil, d.outputs.error("DescribeListenerCertificatesForListenerError")
+}
+
+// AddListenerCertificates ...
+func (d *Dummy) AddListenerCertificates(in *elbv2.AddListenerCertificatesInput) (*elbv2.AddListenerCertificatesOutput, error) {
+	return &elbv2.AddListenerCertificatesOutput{Certificates: in.Certificates}, d.outputs.error("AddListenerCertificatesError")
+}
+
+// RemoveListenerCertificates ...
+func (d *Dummy) RemoveListenerCertificates(in *elbv2.RemoveListenerCertificatesInput) (*elbv2.RemoveListenerCertificatesOutput, error) {
+	return &elbv2.RemoveListenerCertificatesOutput{}, d.outputs.error("RemoveListenerCertificatesError")
+}
+
 // Status ...
 func (d *Dummy) Status() func() error { return nil }
 
@@ -83,6 +106,12 @@ func (d *Dummy) DeleteRule(in *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput,
 	return d.outputs["DeleteRuleOutput"].(*elbv2.DeleteRuleOutput), d.outputs.error("DeleteRuleError")
 }
 
+// SetRulePriorities ...
+func (d *Dummy) SetRulePriorities(in *elbv2.SetRulePrioritiesInput) (*elbv2.SetRulePrioritiesOutput, error) {
+	d.outputs["SetRulePrioritiesInput"] = in
+	return &elbv2.SetRulePrioritiesOutput{}, d.outputs.error("SetRulePrioritiesError")
+}
+
 // GetLoadBalancerByArn ...
 func (d *Dummy) GetLoadBalancerByArn(arn string) (*elbv2.LoadBalancer, error) {
 	return d.outputs["GetLoadBalancerByArn"].(*elbv2.LoadBalancer), d.outputs.error("GetLoadBalancerByArn")
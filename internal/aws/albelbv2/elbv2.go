@@ -27,10 +27,12 @@ const (
 type ELBV2API interface {
 	elbv2iface.ELBV2API
 	ClusterLoadBalancers() ([]*elbv2.LoadBalancer, error)
+	ClusterNetworkLoadBalancers() ([]*elbv2.LoadBalancer, error)
 	ClusterTargetGroups() (map[string][]*elbv2.TargetGroup, error)
 	RemoveTargetGroup(arn *string) error
 	RemoveListener(arn *string) error
 	DescribeListenersForLoadBalancer(loadBalancerArn *string) ([]*elbv2.Listener, error)
+	DescribeListenerCertificatesForListener(listenerArn *string) ([]*elbv2.Certificate, error)
 	Status() func() error
 	SetField(string, interface{})
 
@@ -118,6 +120,27 @@ func (e *ELBV2) ClusterLoadBalancers() ([]*elbv2.LoadBalancer, error) {
 	return loadbalancers, err
 }
 
+// ClusterNetworkLoadBalancers looks up all ELBV2 (NLB) instances in AWS that are part of the cluster.
+func (e *ELBV2) ClusterNetworkLoadBalancers() ([]*elbv2.LoadBalancer, error) {
+	var loadbalancers []*elbv2.LoadBalancer
+
+	rgt, err := albrgt.RGTsvc.GetClusterResources()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get AWS tags. Error: %s", err.Error())
+	}
+
+	err = e.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(page *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+		for _, loadBalancer := range page.LoadBalancers {
+			if _, ok := rgt.NetworkLoadBalancers[*loadBalancer.LoadBalancerArn]; ok {
+				loadbalancers = append(loadbalancers, loadBalancer)
+			}
+		}
+		return true
+	})
+
+	return loadbalancers, err
+}
+
 // ClusterTargetGroups fetches all target groups that are part of the cluster.
 func (e *ELBV2) ClusterTargetGroups() (map[string][]*elbv2.TargetGroup, error) {
 	output := make(map[string][]*elbv2.TargetGroup)
@@ -160,6 +183,31 @@ func (e *ELBV2) DescribeListenersForLoadBalancer(loadBalancerArn *string) ([]*el
 	return listeners, nil
 }
 
+// DescribeListenerCertificatesForListener looks up the certificates of a listener in AWS, excluding
+// its default certificate which is reported with the listener itself.
+func (e *ELBV2) DescribeListenerCertificatesForListener(listenerArn *string) ([]*elbv2.Certificate, error) {
+	var certificates []*elbv2.Certificate
+
+	in := &elbv2.DescribeListenerCertificatesInput{ListenerArn: listenerArn}
+	for {
+		o, err := e.DescribeListenerCertificates(in)
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range o.Certificates {
+			if !aws.BoolValue(certificate.IsDefault) {
+				certificates = append(certificates, certificate)
+			}
+		}
+		if o.NextMarker == nil {
+			break
+		}
+		in.Marker = o.NextMarker
+	}
+
+	return certificates, nil
+}
+
 // Status validates ELBV2 connectivity
 func (e *ELBV2) Status() func() error {
 	return func() error {
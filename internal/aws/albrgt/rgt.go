@@ -40,21 +40,23 @@ func NewRGT(awsSession *session.Session, clusterName string) {
 }
 
 type Resources struct {
-	LoadBalancers map[string]util.ELBv2Tags
-	Listeners     map[string]util.ELBv2Tags
-	ListenerRules map[string]util.ELBv2Tags
-	TargetGroups  map[string]util.ELBv2Tags
-	Subnets       map[string]util.EC2Tags
+	LoadBalancers        map[string]util.ELBv2Tags
+	NetworkLoadBalancers map[string]util.ELBv2Tags
+	Listeners            map[string]util.ELBv2Tags
+	ListenerRules        map[string]util.ELBv2Tags
+	TargetGroups         map[string]util.ELBv2Tags
+	Subnets              map[string]util.EC2Tags
 }
 
-// GetClusterResources looks up all ELBV2 (ALB) resources in AWS that are part of the cluster.
+// GetClusterResources looks up all ELBV2 (ALB and NLB) resources in AWS that are part of the cluster.
 func (r *RGT) GetClusterResources() (*Resources, error) {
 	resources := &Resources{
-		LoadBalancers: make(map[string]util.ELBv2Tags),
-		Listeners:     make(map[string]util.ELBv2Tags),
-		ListenerRules: make(map[string]util.ELBv2Tags),
-		TargetGroups:  make(map[string]util.ELBv2Tags),
-		Subnets:       make(map[string]util.EC2Tags),
+		LoadBalancers:        make(map[string]util.ELBv2Tags),
+		NetworkLoadBalancers: make(map[string]util.ELBv2Tags),
+		Listeners:            make(map[string]util.ELBv2Tags),
+		ListenerRules:        make(map[string]util.ELBv2Tags),
+		TargetGroups:         make(map[string]util.ELBv2Tags),
+		Subnets:              make(map[string]util.EC2Tags),
 	}
 
 	paramSet := []*resourcegroupstaggingapi.GetResourcesInput{
@@ -110,6 +112,8 @@ func (r *RGT) GetClusterResources() (*Resources, error) {
 				switch {
 				case strings.Contains(*rtm.ResourceARN, ":loadbalancer/app/"):
 					resources.LoadBalancers[*rtm.ResourceARN] = rgtTagAsELBV2Tag(rtm.Tags)
+				case strings.Contains(*rtm.ResourceARN, ":loadbalancer/net/"):
+					resources.NetworkLoadBalancers[*rtm.ResourceARN] = rgtTagAsELBV2Tag(rtm.Tags)
 				case strings.Contains(*rtm.ResourceARN, ":listener/app/"):
 					resources.Listeners[*rtm.ResourceARN] = rgtTagAsELBV2Tag(rtm.Tags)
 				case strings.Contains(*rtm.ResourceARN, ":listener-rule/app/"):
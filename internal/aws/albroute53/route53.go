@@ -0,0 +1,23 @@
+package albroute53
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// Route53svc is a pointer to the awsutil Route53 service
+var Route53svc *Route53
+
+// Route53 is our extension to AWS's route53.Route53
+type Route53 struct {
+	route53iface.Route53API
+}
+
+// NewRoute53 returns a Route53 based off of the provided aws.Config. Route53 is a global service, its
+// calls are sent to us-east-1 whatever the region of the session.
+func NewRoute53(awsSession *session.Session) {
+	Route53svc = &Route53{
+		route53.New(awsSession),
+	}
+}
@@ -0,0 +1,71 @@
+package albshield
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
+)
+
+// Shieldsvc is a pointer to the awsutil Shield service
+var Shieldsvc *Shield
+
+// Shield is our extension to AWS's shield.Shield
+type Shield struct {
+	shieldiface.ShieldAPI
+}
+
+// NewShield returns an Shield based off of the provided aws.Config. Shield is a global service, its
+// calls are sent to us-east-1 whatever the region of the session.
+func NewShield(awsSession *session.Session) {
+	Shieldsvc = &Shield{
+		shield.New(awsSession),
+	}
+}
+
+// GetProtectionID returns the ID of the Shield Advanced protection of the resource, nil if the
+// resource isn't protected.
+func (s *Shield) GetProtectionID(resourceArn *string) (*string, error) {
+	result, err := s.DescribeProtection(&shield.DescribeProtectionInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == shield.ErrCodeResourceNotFoundException {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if result.Protection == nil {
+		return nil, nil
+	}
+	return result.Protection.Id, nil
+}
+
+// Protect creates a Shield Advanced protection of the resource, it returns the ID of the protection.
+func (s *Shield) Protect(name *string, resourceArn *string) (*string, error) {
+	result, err := s.CreateProtection(&shield.CreateProtectionInput{
+		Name:        name,
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		// the protection may have been created outside of the controller
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == shield.ErrCodeResourceAlreadyExistsException {
+			return s.GetProtectionID(resourceArn)
+		}
+		return nil, err
+	}
+
+	return result.ProtectionId, nil
+}
+
+// Unprotect deletes the Shield Advanced protection with the ID.
+func (s *Shield) Unprotect(protectionID *string) error {
+	_, err := s.DeleteProtection(&shield.DeleteProtectionInput{
+		ProtectionId: protectionID,
+	})
+
+	return err
+}
@@ -0,0 +1,115 @@
+package albwafv2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+)
+
+// WAFV2svc is a pointer to the awsutil WAFV2 service
+var WAFV2svc *WAFV2
+
+// WAFV2 is our extension to AWS's wafv2.WAFV2
+type WAFV2 struct {
+	wafv2iface.WAFV2API
+}
+
+// NewWAFV2 returns an WAFV2 based off of the provided aws.Config
+func NewWAFV2(awsSession *session.Session) {
+	WAFV2svc = &WAFV2{
+		wafv2.New(awsSession),
+	}
+}
+
+// WebACLID identifies a WAFv2 Web ACL in the GetWebACL calls
+type WebACLID struct {
+	Name  string
+	ID    string
+	Scope string
+}
+
+// ParseWebACLARN returns the name, id and scope of the Web ACL of a WAFv2 Web ACL ARN, like
+// arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111.
+// Only regional Web ACLs can be associated with load balancers.
+func ParseWebACLARN(webACLArn string) (*WebACLID, error) {
+	a, err := arn.Parse(webACLArn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAFv2 Web ACL ARN %s: %s", webACLArn, err.Error())
+	}
+	parts := strings.Split(a.Resource, "/")
+	if a.Service != "wafv2" || len(parts) != 4 || parts[1] != "webacl" {
+		return nil, fmt.Errorf("invalid WAFv2 Web ACL ARN %s", webACLArn)
+	}
+	if parts[0] != "regional" {
+		return nil, fmt.Errorf("WAFv2 Web ACL %s is not a regional Web ACL", webACLArn)
+	}
+	return &WebACLID{Name: parts[2], ID: parts[3], Scope: wafv2.ScopeRegional}, nil
+}
+
+// WebACLExists checks whether the Web ACL of the provided ARN exists in AWS.
+func (a *WAFV2) WebACLExists(webACLArn *string) (bool, error) {
+	id, err := ParseWebACLARN(aws.StringValue(webACLArn))
+	if err != nil {
+		return false, err
+	}
+
+	_, err = a.GetWebACL(&wafv2.GetWebACLInput{
+		Name:  aws.String(id.Name),
+		Id:    aws.String(id.ID),
+		Scope: aws.String(id.Scope),
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetWebACLARN returns the ARN of the Web ACL associated to the resource, nil if there is none.
+func (a *WAFV2) GetWebACLARN(resourceArn *string) (*string, error) {
+	result, err := a.GetWebACLForResource(&wafv2.GetWebACLForResourceInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.WebACL == nil {
+		return nil, nil
+	}
+	return result.WebACL.ARN, nil
+}
+
+// Associate WAFv2 Web ACL to resource.
+func (a *WAFV2) Associate(resourceArn *string, webACLArn *string) (*wafv2.AssociateWebACLOutput, error) {
+	result, err := a.AssociateWebACL(&wafv2.AssociateWebACLInput{
+		ResourceArn: resourceArn,
+		WebACLArn:   webACLArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Disassociate WAFv2 Web ACL from resource.
+func (a *WAFV2) Disassociate(resourceArn *string) (*wafv2.DisassociateWebACLOutput, error) {
+	result, err := a.DisassociateWebACL(&wafv2.DisassociateWebACLInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package albwafv2
+
+import (
+	"testing"
+)
+
+func TestParseWebACLARN(t *testing.T) {
+	for _, tc := range []struct {
+		arn      string
+		expected *WebACLID
+		err      bool
+	}{
+		{
+			arn:      "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			expected: &WebACLID{Name: "my-acl", ID: "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111", Scope: "REGIONAL"},
+		},
+		{
+			arn: "arn:aws:wafv2:us-east-1:123456789012:global/webacl/my-acl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			err: true,
+		},
+		{
+			arn: "arn:aws:wafv2:us-west-2:123456789012:regional/ipset/my-set/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			err: true,
+		},
+		{
+			arn: "arn:aws:waf-regional:us-west-2:123456789012:webacl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			err: true,
+		},
+		{
+			arn: "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			err: true,
+		},
+	} {
+		id, err := ParseWebACLARN(tc.arn)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", tc.arn, id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.arn, err)
+			continue
+		}
+		if *id != *tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tc.arn, tc.expected, id)
+		}
+	}
+}
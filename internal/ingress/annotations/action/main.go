@@ -1,8 +1,11 @@
 package action
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	extensions "k8s.io/api/extensions/v1beta1"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -17,7 +21,29 @@ const UseActionAnnotation = "use-annotation"
 const default404ServiceName = "Default 404"
 
 type Config struct {
-	Actions map[string]*elbv2.Action
+	Actions map[string]*Action
+}
+
+// Action is the annotation representation of an elbv2.Action. The target groups of a forward
+// action reference Services, their ARNs are only known once the target groups are created.
+type Action struct {
+	Type                *string
+	FixedResponseConfig *elbv2.FixedResponseActionConfig
+	RedirectConfig      *elbv2.RedirectActionConfig
+	ForwardConfig       *ForwardActionConfig
+}
+
+// ForwardActionConfig distributes requests across the target groups of one or more Services.
+type ForwardActionConfig struct {
+	TargetGroups                []*TargetGroupTuple
+	TargetGroupStickinessConfig *elbv2.TargetGroupStickinessConfig
+}
+
+// TargetGroupTuple is a weighted Service backend of a forward action.
+type TargetGroupTuple struct {
+	ServiceName *string
+	ServicePort *intstr.IntOrString
+	Weight      *int64
 }
 
 type action struct {
@@ -31,19 +57,19 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 
 // Parse parses the annotations contained in the resource
 func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
-	actions := make(map[string]*elbv2.Action)
+	actions := make(map[string]*Action)
 	annos, err := parser.GetStringAnnotations("actions", ing)
 	if err != nil {
 		return nil, err
 	}
 
 	for serviceName, raw := range annos {
-		var data *elbv2.Action
+		var data *Action
 		err := json.Unmarshal([]byte(raw), &data)
 		if err != nil {
 			return nil, err
 		}
-		err = data.Validate()
+		err = data.ELBV2Action(nil).Validate()
 		if err != nil {
 			return nil, err
 		}
@@ -56,6 +82,15 @@ func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 			if data.RedirectConfig == nil {
 				return nil, fmt.Errorf("%v is type redirect but did not include a valid RedirectConfig configuration", serviceName)
 			}
+		case "forward":
+			if data.ForwardConfig == nil || len(data.ForwardConfig.TargetGroups) == 0 {
+				return nil, fmt.Errorf("%v is type forward but did not include a valid ForwardConfig configuration", serviceName)
+			}
+			for _, t := range data.ForwardConfig.TargetGroups {
+				if t.ServiceName == nil || t.ServicePort == nil {
+					return nil, fmt.Errorf("%v is type forward but a target group did not include a ServiceName and ServicePort", serviceName)
+				}
+			}
 		default:
 			return nil, fmt.Errorf("an invalid action type %v was configured in %v", *data.Type, serviceName)
 		}
@@ -69,7 +104,7 @@ func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 }
 
 // GetAction returns the action named serviceName configured by an annotation
-func (c *Config) GetAction(serviceName string) (*elbv2.Action, error) {
+func (c *Config) GetAction(serviceName string) (*Action, error) {
 	if serviceName == default404ServiceName {
 		return default404Action(), nil
 	}
@@ -82,13 +117,177 @@ func (c *Config) GetAction(serviceName string) (*elbv2.Action, error) {
 	return action, nil
 }
 
+// Backends returns the Service backends the action forwards requests to.
+func (a *Action) Backends() []extensions.IngressBackend {
+	if a.ForwardConfig == nil {
+		return nil
+	}
+	var backends []extensions.IngressBackend
+	for _, t := range a.ForwardConfig.TargetGroups {
+		backends = append(backends, extensions.IngressBackend{
+			ServiceName: aws.StringValue(t.ServiceName),
+			ServicePort: *t.ServicePort,
+		})
+	}
+	return backends
+}
+
+// ELBV2Action returns the elbv2.Action described by the annotation. targetGroupArn resolves the
+// ARN of the target group created for a backend, when it is nil the ARNs are left empty.
+func (a *Action) ELBV2Action(targetGroupArn func(extensions.IngressBackend) *string) *elbv2.Action {
+	out := &elbv2.Action{
+		Type:                a.Type,
+		FixedResponseConfig: a.FixedResponseConfig,
+		RedirectConfig:      a.RedirectConfig,
+	}
+	if a.ForwardConfig == nil {
+		return out
+	}
+
+	out.ForwardConfig = &elbv2.ForwardActionConfig{
+		TargetGroupStickinessConfig: a.ForwardConfig.TargetGroupStickinessConfig,
+	}
+	for i, backend := range a.Backends() {
+		t := &elbv2.TargetGroupTuple{Weight: a.ForwardConfig.TargetGroups[i].Weight}
+		if targetGroupArn != nil {
+			t.TargetGroupArn = targetGroupArn(backend)
+		}
+		out.ForwardConfig.TargetGroups = append(out.ForwardConfig.TargetGroups, t)
+	}
+	return out
+}
+
+// TargetGroupArns returns the ARNs of all target groups a receives requests for.
+func TargetGroupArns(a *elbv2.Action) []*string {
+	var arns []*string
+	if a.TargetGroupArn != nil {
+		arns = append(arns, a.TargetGroupArn)
+	}
+	if a.ForwardConfig != nil {
+		for _, t := range a.ForwardConfig.TargetGroups {
+			if t.TargetGroupArn != nil {
+				arns = append(arns, t.TargetGroupArn)
+			}
+		}
+	}
+	return arns
+}
+
+// ActionsEqual returns true if a1 and a2 configure the same actions. The load balancer reports
+// defaults and state that are not part of the desired configuration, these are ignored: the forward
+// configuration of single target group actions and the order of actions besides their sequence. OIDC
+// client secrets are compared by hash when both are known, the load balancer doesn't report them, see
+// KeepClientSecrets. An unknown client secret equals any secret.
+func ActionsEqual(a1 []*elbv2.Action, a2 []*elbv2.Action) bool {
+	if len(a1) != len(a2) {
+		return false
+	}
+	n1, n2 := normalizeActions(a1), normalizeActions(a2)
+	for i := range n1 {
+		if c1, c2 := n1[i].AuthenticateOidcConfig, n2[i].AuthenticateOidcConfig; c1 != nil && c2 != nil && (c1.ClientSecret == nil || c2.ClientSecret == nil) {
+			c1.ClientSecret, c2.ClientSecret = nil, nil
+		}
+		if !util.DeepEqual(n1[i], n2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithoutSecrets returns a copy of actions safe for logging, OIDC client secrets are removed.
+func WithoutSecrets(actions []*elbv2.Action) []*elbv2.Action {
+	out := make([]*elbv2.Action, len(actions))
+	for i, a := range actions {
+		c := *a
+		if c.AuthenticateOidcConfig != nil && c.AuthenticateOidcConfig.ClientSecret != nil {
+			oidc := *c.AuthenticateOidcConfig
+			oidc.ClientSecret = aws.String("<redacted>")
+			c.AuthenticateOidcConfig = &oidc
+		}
+		out[i] = &c
+	}
+	return out
+}
+
+// KeepClientSecrets copies the OIDC client secrets of the desired actions to the actions reported by
+// the load balancer once they were created or modified, so that a rotated secret is told apart. The
+// secrets of actions read from the load balancer are unknown, a secret rotated while the controller
+// wasn't running is applied with the next modification of the action.
+func KeepClientSecrets(reported []*elbv2.Action, desired []*elbv2.Action) {
+	for _, d := range desired {
+		if d.AuthenticateOidcConfig == nil {
+			continue
+		}
+		for _, r := range reported {
+			if r.AuthenticateOidcConfig != nil && aws.Int64Value(r.Order) == aws.Int64Value(d.Order) {
+				r.AuthenticateOidcConfig.ClientSecret = d.AuthenticateOidcConfig.ClientSecret
+			}
+		}
+	}
+}
+
+func normalizeActions(actions []*elbv2.Action) []*elbv2.Action {
+	sorted := make([]*elbv2.Action, len(actions))
+	copy(sorted, actions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return aws.Int64Value(sorted[i].Order) < aws.Int64Value(sorted[j].Order)
+	})
+
+	out := make([]*elbv2.Action, len(sorted))
+	for i, a := range sorted {
+		out[i] = normalize(a)
+	}
+	return out
+}
+
+func normalize(a *elbv2.Action) *elbv2.Action {
+	out := *a
+	out.Order = nil
+
+	if out.AuthenticateCognitoConfig != nil {
+		c := *out.AuthenticateCognitoConfig
+		if len(c.AuthenticationRequestExtraParams) == 0 {
+			c.AuthenticationRequestExtraParams = nil
+		}
+		out.AuthenticateCognitoConfig = &c
+	}
+	if out.AuthenticateOidcConfig != nil {
+		c := *out.AuthenticateOidcConfig
+		if c.ClientSecret != nil {
+			sum := sha256.Sum256([]byte(*c.ClientSecret))
+			c.ClientSecret = aws.String(hex.EncodeToString(sum[:]))
+		}
+		c.UseExistingClientSecret = nil
+		if len(c.AuthenticationRequestExtraParams) == 0 {
+			c.AuthenticationRequestExtraParams = nil
+		}
+		out.AuthenticateOidcConfig = &c
+	}
+
+	if out.ForwardConfig == nil {
+		return &out
+	}
+
+	fc := *out.ForwardConfig
+	if fc.TargetGroupStickinessConfig != nil && !aws.BoolValue(fc.TargetGroupStickinessConfig.Enabled) {
+		fc.TargetGroupStickinessConfig = nil
+	}
+	if len(fc.TargetGroups) == 1 && fc.TargetGroupStickinessConfig == nil {
+		out.TargetGroupArn = fc.TargetGroups[0].TargetGroupArn
+		out.ForwardConfig = nil
+		return &out
+	}
+	out.ForwardConfig = &fc
+	return &out
+}
+
 // Use returns true if the parameter requested an annotation configured action
 func Use(s string) bool {
 	return s == UseActionAnnotation
 }
 
-func default404Action() *elbv2.Action {
-	return &elbv2.Action{
+func default404Action() *Action {
+	return &Action{
 		Type: aws.String("fixed-response"),
 		FixedResponseConfig: &elbv2.FixedResponseActionConfig{
 			ContentType: aws.String("text/plain"),
@@ -106,7 +305,7 @@ func Default404Backend() *extensions.IngressBackend {
 	}
 }
 
-func setDefaults(d *elbv2.Action) *elbv2.Action {
+func setDefaults(d *Action) *Action {
 	if d.RedirectConfig != nil {
 		if d.RedirectConfig.Host == nil {
 			d.RedirectConfig.Host = aws.String("#{host}")
@@ -124,27 +323,40 @@ func setDefaults(d *elbv2.Action) *elbv2.Action {
 			d.RedirectConfig.Query = aws.String("#{query}")
 		}
 	}
+	if d.ForwardConfig != nil {
+		for _, t := range d.ForwardConfig.TargetGroups {
+			if t.Weight == nil {
+				t.Weight = aws.Int64(1)
+			}
+			if t.ServicePort != nil && t.ServicePort.Type == intstr.String {
+				// numeric ports are often quoted, e.g. "80"
+				p := intstr.Parse(t.ServicePort.StrVal)
+				t.ServicePort = &p
+			}
+		}
+	}
 	return d
 }
 
 func Dummy() *Config {
+	blue, green := intstr.FromInt(80), intstr.FromString("http")
 	return &Config{
-		Actions: map[string]*elbv2.Action{
-			"redirect": setDefaults(&elbv2.Action{
+		Actions: map[string]*Action{
+			"redirect": setDefaults(&Action{
 				Type: aws.String(elbv2.ActionTypeEnumRedirect),
 				RedirectConfig: &elbv2.RedirectActionConfig{
 					Protocol:   aws.String(elbv2.ProtocolEnumHttps),
 					StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
 				},
 			}),
-			"redirect-path2": setDefaults(&elbv2.Action{
+			"redirect-path2": setDefaults(&Action{
 				Type: aws.String(elbv2.ActionTypeEnumRedirect),
 				RedirectConfig: &elbv2.RedirectActionConfig{
 					Path:       aws.String("/#{path}2"),
 					StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
 				},
 			}),
-			"fixed-response-action": setDefaults(&elbv2.Action{
+			"fixed-response-action": setDefaults(&Action{
 				Type: aws.String(elbv2.ActionTypeEnumFixedResponse),
 				FixedResponseConfig: &elbv2.FixedResponseActionConfig{
 					ContentType: aws.String("text/plain"),
@@ -152,6 +364,19 @@ func Dummy() *Config {
 					MessageBody: aws.String("message body"),
 				},
 			}),
+			"weighted-routing": setDefaults(&Action{
+				Type: aws.String(elbv2.ActionTypeEnumForward),
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []*TargetGroupTuple{
+						{ServiceName: aws.String("service-blue"), ServicePort: &blue, Weight: aws.Int64(90)},
+						{ServiceName: aws.String("service-green"), ServicePort: &green, Weight: aws.Int64(10)},
+					},
+					TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+						Enabled:         aws.Bool(true),
+						DurationSeconds: aws.Int64(300),
+					},
+				},
+			}),
 		},
 	}
 }
@@ -3,8 +3,12 @@ package action
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
@@ -56,3 +60,139 @@ func TestInvalidIngressActions(t *testing.T) {
 		t.Errorf("invalid annotation configuration was provided but an error was not returned: %v", err)
 	}
 }
+
+func TestWeightedForwardAction(t *testing.T) {
+	ing := dummy.NewIngress()
+
+	data := map[string]string{}
+	data[parser.GetAnnotationWithPrefix("actions.weighted")] = `{"Type": "forward", "ForwardConfig": {"TargetGroups": [
+	{"ServiceName": "blue", "ServicePort": "80", "Weight": 90}, {"ServiceName": "green", "ServicePort": "http", "Weight": 10}],
+	"TargetGroupStickinessConfig": {"Enabled": true, "DurationSeconds": 200}}}`
+	ing.SetAnnotations(data)
+
+	ai, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a, err := ai.(*Config).GetAction("weighted")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	backends := a.Backends()
+	if len(backends) != 2 {
+		t.Fatalf("expected 2 backends, got %v", len(backends))
+	}
+	if backends[0].ServiceName != "blue" || backends[0].ServicePort != intstr.FromInt(80) {
+		t.Errorf("expected backend blue:80, got %v:%v", backends[0].ServiceName, backends[0].ServicePort.String())
+	}
+	if backends[1].ServiceName != "green" || backends[1].ServicePort != intstr.FromString("http") {
+		t.Errorf("expected backend green:http, got %v:%v", backends[1].ServiceName, backends[1].ServicePort.String())
+	}
+
+	out := a.ELBV2Action(func(b extensions.IngressBackend) *string {
+		return aws.String(b.ServiceName + "-arn")
+	})
+	if *out.ForwardConfig.TargetGroups[0].TargetGroupArn != "blue-arn" || *out.ForwardConfig.TargetGroups[0].Weight != 90 {
+		t.Errorf("unexpected first target group %v", log.Prettify(out.ForwardConfig.TargetGroups[0]))
+	}
+	if *out.ForwardConfig.TargetGroups[1].TargetGroupArn != "green-arn" || *out.ForwardConfig.TargetGroups[1].Weight != 10 {
+		t.Errorf("unexpected second target group %v", log.Prettify(out.ForwardConfig.TargetGroups[1]))
+	}
+	if !*out.ForwardConfig.TargetGroupStickinessConfig.Enabled {
+		t.Errorf("expected target group stickiness to be enabled")
+	}
+}
+
+func TestInvalidWeightedForwardAction(t *testing.T) {
+	for _, raw := range []string{
+		`{"Type": "forward"}`,
+		`{"Type": "forward", "ForwardConfig": {"TargetGroups": []}}`,
+		`{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"ServicePort": "80", "Weight": 90}]}}`,
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("actions.weighted"): raw})
+
+		_, err := NewParser(mockBackend{}).Parse(ing)
+		if err == nil {
+			t.Errorf("invalid annotation configuration %v was provided but an error was not returned", raw)
+		}
+	}
+}
+
+func TestActionsEqual(t *testing.T) {
+	forward := []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String("arn")}}
+	reported := []*elbv2.Action{{
+		Type:           aws.String(elbv2.ActionTypeEnumForward),
+		Order:          aws.Int64(1),
+		TargetGroupArn: aws.String("arn"),
+		ForwardConfig: &elbv2.ForwardActionConfig{
+			TargetGroups:                []*elbv2.TargetGroupTuple{{TargetGroupArn: aws.String("arn"), Weight: aws.Int64(1)}},
+			TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{Enabled: aws.Bool(false)},
+		},
+	}}
+	if !ActionsEqual(forward, reported) {
+		t.Errorf("expected a single target group forward action to equal its reported form")
+	}
+
+	weighted := []*elbv2.Action{{
+		Type: aws.String(elbv2.ActionTypeEnumForward),
+		ForwardConfig: &elbv2.ForwardActionConfig{
+			TargetGroups: []*elbv2.TargetGroupTuple{
+				{TargetGroupArn: aws.String("arn"), Weight: aws.Int64(90)},
+				{TargetGroupArn: aws.String("arn2"), Weight: aws.Int64(10)},
+			},
+		},
+	}}
+	if ActionsEqual(forward, weighted) {
+		t.Errorf("expected a weighted forward action to differ from a single target group forward action")
+	}
+}
+
+func TestActionsEqualWithAuthentication(t *testing.T) {
+	desired := []*elbv2.Action{
+		{
+			Type:  aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+			Order: aws.Int64(1),
+			AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+				ClientId:                         aws.String("client-id"),
+				ClientSecret:                     aws.String("client-secret"),
+				AuthenticationRequestExtraParams: map[string]*string{},
+			},
+		},
+		{Type: aws.String(elbv2.ActionTypeEnumForward), Order: aws.Int64(2), TargetGroupArn: aws.String("arn")},
+	}
+	// the load balancer does not report the client secret, and may list actions in any order
+	reported := []*elbv2.Action{
+		{Type: aws.String(elbv2.ActionTypeEnumForward), Order: aws.Int64(2), TargetGroupArn: aws.String("arn")},
+		{
+			Type:  aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+			Order: aws.Int64(1),
+			AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+				ClientId: aws.String("client-id"),
+			},
+		},
+	}
+	if !ActionsEqual(desired, reported) {
+		t.Errorf("expected actions of an unknown client secret to equal their reported form")
+	}
+	KeepClientSecrets(reported, desired)
+	if !ActionsEqual(desired, reported) {
+		t.Errorf("expected authenticate and forward actions to equal their reported form")
+	}
+	rotated := WithoutSecrets(desired)
+	rotated[0].AuthenticateOidcConfig.ClientSecret = aws.String("rotated-secret")
+	if ActionsEqual(rotated, reported) {
+		t.Errorf("expected a rotated client secret to differ")
+	}
+
+	if secret := WithoutSecrets(desired)[0].AuthenticateOidcConfig.ClientSecret; *secret == "client-secret" {
+		t.Errorf("expected the client secret to be removed")
+	}
+	if *desired[0].AuthenticateOidcConfig.ClientSecret != "client-secret" {
+		t.Errorf("expected the original actions to be unchanged")
+	}
+}
@@ -26,9 +26,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/group"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/listener"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/nlb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/rule"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
@@ -44,9 +48,13 @@ const DeniedKeyName = "Denied"
 type Ingress struct {
 	metav1.ObjectMeta
 	Action       *action.Config
+	Auth         *auth.Config
+	Conditions   *conditions.Config
+	Group        *group.Config
 	HealthCheck  *healthcheck.Config
 	TargetGroup  *targetgroup.Config
 	LoadBalancer *loadbalancer.Config
+	NLB          *nlb.Config
 	Rule         *rule.Config
 	Listener     *listener.Config
 	Tags         *tags.Config
@@ -56,6 +64,9 @@ type Ingress struct {
 func NewIngressDummy() *Ingress {
 	return &Ingress{
 		Action:       action.Dummy(),
+		Auth:         &auth.Config{},
+		Conditions:   conditions.Dummy(),
+		Group:        &group.Config{},
 		HealthCheck:  &healthcheck.Config{},
 		TargetGroup:  targetgroup.Dummy(),
 		LoadBalancer: loadbalancer.Dummy(),
@@ -73,7 +84,9 @@ func (s *Service) Merge(b *Ingress, cfg *config.Configuration) *Service {
 	return &Service{
 		ObjectMeta:   s.ObjectMeta,
 		Action:       s.Action,
+		Auth:         s.Auth.Merge(b.Auth),
 		LoadBalancer: s.LoadBalancer,
+		NLB:          s.NLB,
 		Tags:         s.Tags,
 		Error:        s.Error,
 		HealthCheck:  s.HealthCheck.Merge(b.HealthCheck, cfg),
@@ -85,6 +98,7 @@ func (s *Service) Merge(b *Ingress, cfg *config.Configuration) *Service {
 
 func NewServiceDummy() *Service {
 	return &Service{
+		Auth:        &auth.Config{},
 		HealthCheck: &healthcheck.Config{},
 		TargetGroup: targetgroup.Dummy(),
 		Rule:        &rule.Config{},
@@ -103,6 +117,9 @@ func NewIngressAnnotationExtractor(cfg resolver.Resolver) Extractor {
 	return Extractor{
 		map[string]parser.IngressAnnotation{
 			"Action":       action.NewParser(cfg),
+			"Auth":         auth.NewParser(cfg),
+			"Conditions":   conditions.NewParser(cfg),
+			"Group":        group.NewParser(cfg),
 			"HealthCheck":  healthcheck.NewParser(cfg),
 			"TargetGroup":  targetgroup.NewParser(cfg),
 			"LoadBalancer": loadbalancer.NewParser(cfg),
@@ -117,10 +134,12 @@ func NewIngressAnnotationExtractor(cfg resolver.Resolver) Extractor {
 func NewServiceAnnotationExtractor(cfg resolver.Resolver) Extractor {
 	return Extractor{
 		map[string]parser.IngressAnnotation{
+			"Auth":        auth.NewParser(cfg),
 			"HealthCheck": healthcheck.NewParser(cfg),
 			"TargetGroup": targetgroup.NewParser(cfg),
 			"Rule":        rule.NewParser(cfg),
 			"Listener":    listener.NewParser(cfg),
+			"NLB":         nlb.NewParser(cfg),
 			"Tags":        tags.NewParser(cfg),
 		},
 	}
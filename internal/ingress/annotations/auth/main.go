@@ -0,0 +1,238 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+// Supported authentication types
+const (
+	TypeNone    = "none"
+	TypeCognito = "cognito"
+	TypeOIDC    = "oidc"
+)
+
+// Default values ALB applies to authenticate actions, set explicitly so the desired
+// configuration matches the configuration reported by ALB.
+const (
+	DefaultScope                    = "openid"
+	DefaultSessionCookieName        = "AWSELBAuthSessionCookie"
+	DefaultSessionTimeout           = 604800
+	DefaultOnUnauthenticatedRequest = elbv2.AuthenticateOidcActionConditionalBehaviorEnumAuthenticate
+)
+
+// Keys of the Kubernetes Secret holding the OIDC client credentials
+const (
+	SecretClientIDKey     = "clientId"
+	SecretClientSecretKey = "clientSecret"
+)
+
+// Config contains the authentication settings applied to the rules of an ingress or service
+type Config struct {
+	Type                     *string
+	IdpCognito               *IdpCognito
+	IdpOidc                  *IdpOidc
+	Scope                    *string
+	SessionTimeout           *int64
+	OnUnauthenticatedRequest *string
+}
+
+// IdpCognito is the Amazon Cognito user pool used by authenticate-cognito actions
+type IdpCognito struct {
+	UserPoolArn                      string
+	UserPoolClientId                 string
+	UserPoolDomain                   string
+	AuthenticationRequestExtraParams map[string]string
+}
+
+// IdpOidc is the OpenID Connect provider used by authenticate-oidc actions. The client ID and
+// secret are read from the Kubernetes Secret SecretName in the namespace of the ingress.
+type IdpOidc struct {
+	Issuer                           string
+	AuthorizationEndpoint            string
+	TokenEndpoint                    string
+	UserInfoEndpoint                 string
+	SecretName                       string
+	AuthenticationRequestExtraParams map[string]string
+}
+
+type auth struct {
+	r resolver.Resolver
+}
+
+// NewParser creates a new authentication annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return auth{r}
+}
+
+// Parse parses the annotations contained in the resource
+func (a auth) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	cfg := &Config{}
+
+	if t, err := parser.GetStringAnnotation("auth-type", ing); err == nil {
+		switch *t {
+		case TypeNone, TypeCognito, TypeOIDC:
+			cfg.Type = t
+		default:
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("auth-type %q must be one of %v, %v or %v", *t, TypeNone, TypeCognito, TypeOIDC))
+		}
+	}
+
+	if raw, err := parser.GetStringAnnotation("auth-idp-cognito", ing); err == nil {
+		if err := json.Unmarshal([]byte(*raw), &cfg.IdpCognito); err != nil {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("auth-idp-cognito is not valid: %v", err.Error()))
+		}
+	}
+
+	if raw, err := parser.GetStringAnnotation("auth-idp-oidc", ing); err == nil {
+		if err := json.Unmarshal([]byte(*raw), &cfg.IdpOidc); err != nil {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("auth-idp-oidc is not valid: %v", err.Error()))
+		}
+	}
+
+	cfg.Scope, _ = parser.GetStringAnnotation("auth-scope", ing)
+
+	timeout, err := parser.GetInt64Annotation("auth-session-timeout", ing)
+	if err != nil && !errors.IsMissingAnnotations(err) {
+		return nil, err
+	}
+	cfg.SessionTimeout = timeout
+
+	if behavior, err := parser.GetStringAnnotation("auth-on-unauthenticated-request", ing); err == nil {
+		switch *behavior {
+		case elbv2.AuthenticateOidcActionConditionalBehaviorEnumAuthenticate,
+			elbv2.AuthenticateOidcActionConditionalBehaviorEnumAllow,
+			elbv2.AuthenticateOidcActionConditionalBehaviorEnumDeny:
+			cfg.OnUnauthenticatedRequest = behavior
+		default:
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("auth-on-unauthenticated-request %q must be one of authenticate, allow or deny", *behavior))
+		}
+	}
+
+	return cfg, nil
+}
+
+// Merge merges two config, settings of a take precedence
+func (a *Config) Merge(b *Config) *Config {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	c := &Config{
+		Type:                     parser.MergeString(a.Type, b.Type, ""),
+		IdpCognito:               a.IdpCognito,
+		IdpOidc:                  a.IdpOidc,
+		Scope:                    parser.MergeString(a.Scope, b.Scope, ""),
+		SessionTimeout:           parser.MergeInt64(a.SessionTimeout, b.SessionTimeout, 0),
+		OnUnauthenticatedRequest: parser.MergeString(a.OnUnauthenticatedRequest, b.OnUnauthenticatedRequest, ""),
+	}
+	if c.IdpCognito == nil {
+		c.IdpCognito = b.IdpCognito
+	}
+	if c.IdpOidc == nil {
+		c.IdpOidc = b.IdpOidc
+	}
+	return c
+}
+
+// Enabled returns true if requests must be authenticated
+func (a *Config) Enabled() bool {
+	return a != nil && a.Type != nil && *a.Type != TypeNone
+}
+
+// SecretName returns the name of the Secret holding the OIDC client credentials, empty if requests
+// aren't authenticated by an OIDC provider
+func (a *Config) SecretName() string {
+	if !a.Enabled() || aws.StringValue(a.Type) != TypeOIDC || a.IdpOidc == nil {
+		return ""
+	}
+	return a.IdpOidc.SecretName
+}
+
+// Action returns the authenticate action described by the configuration. clientCredentials
+// returns the OIDC client ID and secret stored in the Secret of the given name.
+func (a *Config) Action(clientCredentials func(secretName string) (string, string, error)) (*elbv2.Action, error) {
+	scope := aws.String(DefaultScope)
+	if a.Scope != nil {
+		scope = a.Scope
+	}
+	timeout := aws.Int64(DefaultSessionTimeout)
+	if a.SessionTimeout != nil {
+		timeout = a.SessionTimeout
+	}
+	behavior := aws.String(DefaultOnUnauthenticatedRequest)
+	if a.OnUnauthenticatedRequest != nil {
+		behavior = a.OnUnauthenticatedRequest
+	}
+
+	switch aws.StringValue(a.Type) {
+	case TypeCognito:
+		if a.IdpCognito == nil {
+			return nil, fmt.Errorf("auth-type is %v but auth-idp-cognito is not configured", TypeCognito)
+		}
+		return &elbv2.Action{
+			Type: aws.String(elbv2.ActionTypeEnumAuthenticateCognito),
+			AuthenticateCognitoConfig: &elbv2.AuthenticateCognitoActionConfig{
+				UserPoolArn:                      aws.String(a.IdpCognito.UserPoolArn),
+				UserPoolClientId:                 aws.String(a.IdpCognito.UserPoolClientId),
+				UserPoolDomain:                   aws.String(a.IdpCognito.UserPoolDomain),
+				AuthenticationRequestExtraParams: aws.StringMap(a.IdpCognito.AuthenticationRequestExtraParams),
+				Scope:                            scope,
+				SessionCookieName:                aws.String(DefaultSessionCookieName),
+				SessionTimeout:                   timeout,
+				OnUnauthenticatedRequest:         behavior,
+			},
+		}, nil
+	case TypeOIDC:
+		if a.IdpOidc == nil {
+			return nil, fmt.Errorf("auth-type is %v but auth-idp-oidc is not configured", TypeOIDC)
+		}
+		clientID, clientSecret, err := clientCredentials(a.IdpOidc.SecretName)
+		if err != nil {
+			return nil, err
+		}
+		return &elbv2.Action{
+			Type: aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+			AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+				Issuer:                           aws.String(a.IdpOidc.Issuer),
+				AuthorizationEndpoint:            aws.String(a.IdpOidc.AuthorizationEndpoint),
+				TokenEndpoint:                    aws.String(a.IdpOidc.TokenEndpoint),
+				UserInfoEndpoint:                 aws.String(a.IdpOidc.UserInfoEndpoint),
+				ClientId:                         aws.String(clientID),
+				ClientSecret:                     aws.String(clientSecret),
+				AuthenticationRequestExtraParams: aws.StringMap(a.IdpOidc.AuthenticationRequestExtraParams),
+				Scope:                            scope,
+				SessionCookieName:                aws.String(DefaultSessionCookieName),
+				SessionTimeout:                   timeout,
+				OnUnauthenticatedRequest:         behavior,
+			},
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestParse(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("auth-type"):                       "cognito",
+		parser.GetAnnotationWithPrefix("auth-idp-cognito"):                `{"UserPoolArn": "arn:aws:cognito-idp:us-west-2:123456789012:userpool/pool", "UserPoolClientId": "client", "UserPoolDomain": "example"}`,
+		parser.GetAnnotationWithPrefix("auth-scope"):                      "email openid",
+		parser.GetAnnotationWithPrefix("auth-session-timeout"):            "3600",
+		parser.GetAnnotationWithPrefix("auth-on-unauthenticated-request"): "deny",
+	})
+
+	ai, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := ai.(*Config)
+
+	if !a.Enabled() {
+		t.Errorf("expected authentication to be enabled")
+	}
+	if a.IdpCognito.UserPoolClientId != "client" {
+		t.Errorf("expected UserPoolClientId client, got %v", a.IdpCognito.UserPoolClientId)
+	}
+	if *a.Scope != "email openid" || *a.SessionTimeout != 3600 || *a.OnUnauthenticatedRequest != "deny" {
+		t.Errorf("unexpected settings %v, %v, %v", *a.Scope, *a.SessionTimeout, *a.OnUnauthenticatedRequest)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, annotations := range []map[string]string{
+		{parser.GetAnnotationWithPrefix("auth-type"): "basic"},
+		{parser.GetAnnotationWithPrefix("auth-idp-oidc"): `{"Issuer": `},
+		{parser.GetAnnotationWithPrefix("auth-session-timeout"): "a day"},
+		{parser.GetAnnotationWithPrefix("auth-on-unauthenticated-request"): "redirect"},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(annotations)
+
+		_, err := NewParser(mockBackend{}).Parse(ing)
+		if err == nil {
+			t.Errorf("invalid annotations %v were provided but an error was not returned", annotations)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	svc := &Config{Type: aws.String(TypeNone)}
+	ing := &Config{Type: aws.String(TypeCognito), IdpCognito: &IdpCognito{UserPoolClientId: "client"}, Scope: aws.String("email")}
+
+	m := svc.Merge(ing)
+	if m.Enabled() {
+		t.Errorf("expected the service to disable authentication")
+	}
+	if m.IdpCognito == nil || *m.Scope != "email" {
+		t.Errorf("expected settings missing on the service to be taken from the ingress")
+	}
+}
+
+func TestAction(t *testing.T) {
+	credentials := func(secretName string) (string, string, error) {
+		if secretName != "oidc-secret" {
+			return "", "", fmt.Errorf("unexpected secret %v", secretName)
+		}
+		return "client-id", "client-secret", nil
+	}
+
+	a := &Config{
+		Type: aws.String(TypeOIDC),
+		IdpOidc: &IdpOidc{
+			Issuer:                "https://idp.example.com",
+			AuthorizationEndpoint: "https://idp.example.com/authorize",
+			TokenEndpoint:         "https://idp.example.com/token",
+			UserInfoEndpoint:      "https://idp.example.com/userinfo",
+			SecretName:            "oidc-secret",
+		},
+	}
+
+	action, err := a.Action(credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *action.Type != elbv2.ActionTypeEnumAuthenticateOidc {
+		t.Errorf("expected an %v action, got %v", elbv2.ActionTypeEnumAuthenticateOidc, *action.Type)
+	}
+	c := action.AuthenticateOidcConfig
+	if *c.ClientId != "client-id" || *c.ClientSecret != "client-secret" {
+		t.Errorf("expected client credentials from the secret, got %v/%v", *c.ClientId, *c.ClientSecret)
+	}
+	if *c.Scope != DefaultScope || *c.SessionTimeout != DefaultSessionTimeout || *c.OnUnauthenticatedRequest != DefaultOnUnauthenticatedRequest || *c.SessionCookieName != DefaultSessionCookieName {
+		t.Errorf("expected default settings, got %v", c)
+	}
+
+	_, err = (&Config{Type: aws.String(TypeCognito)}).Action(credentials)
+	if err == nil {
+		t.Errorf("expected an error when the cognito identity provider is missing")
+	}
+}
@@ -18,6 +18,7 @@ package class
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/golang/glog"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +30,12 @@ const (
 	// The controller only processes Ingresses with this annotation either
 	// unset, or set to either the configured value or the empty string.
 	IngressKey = "kubernetes.io/ingress.class"
+
+	// DefaultClassKey marks the IngressClass of the Ingresses without class
+	DefaultClassKey = "ingressclass.kubernetes.io/is-default-class"
+
+	// ControllerName is the controller of the IngressClass resources served by the controller
+	ControllerName = "ingress.k8s.aws/alb"
 )
 
 var (
@@ -41,15 +48,95 @@ var (
 	IngressClass = "alb"
 )
 
-// IsValid returns true if the given Ingress either doesn't specify
-// the ingress.class annotation, or it's set to the configured in the
-// ingress controller.
+// Resource is an IngressClass resource of the cluster
+type Resource struct {
+	Name       string
+	Controller string
+	// Default is true when the IngressClass is the class of the Ingresses without class
+	Default bool
+	// Params is the name of the IngressClassParams referenced by the parameters of the IngressClass
+	Params string
+}
+
+var (
+	resourcesLock sync.RWMutex
+	resources     map[string]Resource
+)
+
+// SetResources replaces the IngressClass resources known to the controller
+func SetResources(rs []Resource) {
+	m := make(map[string]Resource, len(rs))
+	for _, r := range rs {
+		m[r.Name] = r
+	}
+
+	resourcesLock.Lock()
+	defer resourcesLock.Unlock()
+	resources = m
+}
+
+// GetResource returns the IngressClass resource with the name
+func GetResource(name string) (Resource, bool) {
+	resourcesLock.RLock()
+	defer resourcesLock.RUnlock()
+	r, ok := resources[name]
+	return r, ok
+}
+
+// defaultResource returns the IngressClass resource marked as default. When several are marked,
+// no class is used by default, as done by the API server.
+func defaultResource() (Resource, bool) {
+	resourcesLock.RLock()
+	defer resourcesLock.RUnlock()
+
+	var def Resource
+	n := 0
+	for _, r := range resources {
+		if r.Default {
+			def = r
+			n++
+		}
+	}
+	return def, n == 1
+}
+
+type annotated interface {
+	GetAnnotations() map[string]string
+}
+
+// Name returns the class of the object, from its spec.ingressClassName or ingress.class annotation.
+// Objects without class belong to the default IngressClass resource, or to the ingress class of
+// the controller.
+func Name(ing annotated) string {
+	if name := ing.GetAnnotations()[IngressKey]; name != "" {
+		return name
+	}
+	if r, ok := defaultResource(); ok {
+		return r.Name
+	}
+	if IngressClass == "" {
+		return DefaultClass
+	}
+	return IngressClass
+}
+
+// IsValid returns true if the given Ingress belongs to an IngressClass resource of the controller,
+// or, when there is no IngressClass resource with its class, if it either doesn't specify the
+// ingress.class annotation or it's set to the class configured in the ingress controller.
 func IsValid(ing *extensions.Ingress) bool {
 	ingress, ok := ing.GetAnnotations()[IngressKey]
 	if !ok {
 		glog.V(3).Infof("annotation %v is not present in ingress %v/%v", IngressKey, ing.Namespace, ing.Name)
 	}
 
+	if ingress == "" {
+		if r, ok := defaultResource(); ok {
+			return r.Controller == ControllerName
+		}
+	} else if r, ok := GetResource(ingress); ok {
+		return r.Controller == ControllerName
+	}
+
 	// we have 2 valid combinations
 	// 1 - ingress with default class | blank annotation on ingress
 	// 2 - ingress with specific class | same annotation on ingress
@@ -68,3 +68,58 @@ func TestIsValidClass(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidClassResource(t *testing.T) {
+	defer SetResources(nil)
+
+	tests := []struct {
+		resources []Resource
+		ingress   string
+		isValid   bool
+	}{
+		{nil, "", true},
+		{[]Resource{{Name: "internal", Controller: ControllerName}}, "internal", true},
+		{[]Resource{{Name: "alb", Controller: "k8s.io/ingress-nginx"}}, "alb", false},
+		{[]Resource{{Name: "internal", Controller: ControllerName, Default: true}}, "", true},
+		{[]Resource{{Name: "nginx", Controller: "k8s.io/ingress-nginx", Default: true}}, "", false},
+		{[]Resource{
+			{Name: "nginx", Controller: "k8s.io/ingress-nginx", Default: true},
+			{Name: "internal", Controller: ControllerName, Default: true},
+		}, "", true},
+	}
+
+	for _, test := range tests {
+		SetResources(test.resources)
+		ing := &extensions.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   api.NamespaceDefault,
+				Annotations: map[string]string{IngressKey: test.ingress},
+			},
+		}
+
+		b := IsValid(ing)
+		if b != test.isValid {
+			t.Errorf("test %v - expected %v but %v was returned", test, test.isValid, b)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	defer SetResources(nil)
+
+	ing := &extensions.Ingress{ObjectMeta: meta_v1.ObjectMeta{Annotations: map[string]string{}}}
+	if n := Name(ing); n != IngressClass {
+		t.Errorf("expected %v but %v was returned", IngressClass, n)
+	}
+
+	SetResources([]Resource{{Name: "internal", Controller: ControllerName, Default: true}})
+	if n := Name(ing); n != "internal" {
+		t.Errorf("expected internal but %v was returned", n)
+	}
+
+	ing.Annotations[IngressKey] = "external"
+	if n := Name(ing); n != "external" {
+		t.Errorf("expected external but %v was returned", n)
+	}
+}
@@ -0,0 +1,121 @@
+package classparams
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Params are the IngressClassParams of the ingress class of an object, enforced on its
+// annotations. The zero value enforces nothing.
+type Params struct {
+	params *v1alpha1.IngressClassParams
+}
+
+// Get returns the IngressClassParams of the ingress class of ing
+func Get(r resolver.Resolver, ing parser.AnnotationInterface) Params {
+	return Params{r.GetIngressClassParams(class.Name(ing))}
+}
+
+// Spec returns the specification of the IngressClassParams, empty when they don't exist
+func (p Params) Spec() v1alpha1.IngressClassParamsSpec {
+	if p.params == nil {
+		return v1alpha1.IngressClassParamsSpec{}
+	}
+	return p.params.Spec
+}
+
+// String returns the value of the annotation, or value when the annotation is missing. An
+// annotation different from a non empty value is rejected.
+func (p Params) String(name string, ing parser.AnnotationInterface, value string) (*string, error) {
+	v, err := parser.GetStringAnnotation(name, ing)
+	if value == "" {
+		return v, err
+	}
+	if err != nil {
+		return &value, nil
+	}
+	if *v != value {
+		return nil, p.conflict(name, *v, value)
+	}
+	return v, nil
+}
+
+// StringSlice returns the value of the comma separated annotation, or the values joined when the
+// annotation is missing. An annotation with other items than non empty values is rejected, the
+// order of the items doesn't matter.
+func (p Params) StringSlice(name string, ing parser.AnnotationInterface, values []string) (*string, error) {
+	v, err := parser.GetStringAnnotation(name, ing)
+	if len(values) == 0 {
+		return v, err
+	}
+	joined := strings.Join(values, ",")
+	if err != nil {
+		return &joined, nil
+	}
+	if !sameItems(*v, values) {
+		return nil, p.conflict(name, *v, joined)
+	}
+	return v, nil
+}
+
+// MergeTags adds the tags of the IngressClassParams to tags. Tags set to a different value are rejected.
+func (p Params) MergeTags(tags map[string]string) error {
+	for k, v := range p.Spec().Tags {
+		if current, ok := tags[k]; ok && current != v {
+			return p.conflict("tags", k+"="+current, k+"="+v)
+		}
+		tags[k] = v
+	}
+	return nil
+}
+
+// CheckNamespace rejects objects outside of the namespaces allowed by the IngressClassParams
+func (p Params) CheckNamespace(ing parser.AnnotationInterface) error {
+	namespaces := p.Spec().Namespaces
+	o, ok := ing.(metav1.Object)
+	if len(namespaces) == 0 || !ok {
+		return nil
+	}
+	for _, ns := range namespaces {
+		if ns == o.GetNamespace() {
+			return nil
+		}
+	}
+	return errors.NewInvalidAnnotationContentReason(fmt.Sprintf("namespace %s is not allowed to use the ingress class by IngressClassParams %s",
+		o.GetNamespace(), p.params.Name))
+}
+
+func (p Params) conflict(name, value, enforced string) error {
+	return errors.NewInvalidAnnotationContentReason(fmt.Sprintf("%s is %q, IngressClassParams %s requires %q",
+		parser.GetAnnotationWithPrefix(name), value, p.params.Name, enforced))
+}
+
+func sameItems(annotation string, values []string) bool {
+	var items []string
+	for _, item := range strings.Split(annotation, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	expected := append([]string(nil), values...)
+	if len(items) != len(expected) {
+		return false
+	}
+
+	sort.Strings(items)
+	sort.Strings(expected)
+	for i := range items {
+		if items[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package classparams
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newParams(spec v1alpha1.IngressClassParamsSpec) Params {
+	return Get(resolver.Mock{IngressClassParams: &v1alpha1.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+		Spec:       spec,
+	}}, dummy.NewIngress())
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		annotation *string
+		value      string
+		expected   string
+		missing    bool
+		invalid    bool
+	}{
+		{name: "neither set", missing: true},
+		{name: "annotation only", annotation: aws.String("internet-facing"), expected: "internet-facing"},
+		{name: "params only", value: "internal", expected: "internal"},
+		{name: "same value", annotation: aws.String("internal"), value: "internal", expected: "internal"},
+		{name: "conflict", annotation: aws.String("internet-facing"), value: "internal", invalid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			annotations := map[string]string{}
+			if tc.annotation != nil {
+				annotations[parser.GetAnnotationWithPrefix("scheme")] = *tc.annotation
+			}
+			ing.SetAnnotations(annotations)
+
+			p := newParams(v1alpha1.IngressClassParamsSpec{Scheme: tc.value})
+			v, err := p.String("scheme", ing, p.Spec().Scheme)
+			switch {
+			case tc.invalid:
+				if !errors.IsInvalidContent(err) {
+					t.Errorf("expected an invalid content error, got %v", err)
+				}
+			case tc.missing:
+				if !errors.IsMissingAnnotations(err) {
+					t.Errorf("expected a missing annotation error, got %v", err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error %v", err)
+			case *v != tc.expected:
+				t.Errorf("expected %s, got %s", tc.expected, *v)
+			}
+		})
+	}
+}
+
+func TestStringSlice(t *testing.T) {
+	p := newParams(v1alpha1.IngressClassParamsSpec{Subnets: []string{"subnet-1", "subnet-2"}})
+	ing := dummy.NewIngress()
+
+	ing.SetAnnotations(map[string]string{})
+	v, err := p.StringSlice("subnets", ing, p.Spec().Subnets)
+	if err != nil || *v != "subnet-1,subnet-2" {
+		t.Errorf("expected the subnets of the params, got %v, %v", v, err)
+	}
+
+	ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("subnets"): "subnet-2, subnet-1"})
+	if _, err := p.StringSlice("subnets", ing, p.Spec().Subnets); err != nil {
+		t.Errorf("expected the subnets in another order to be accepted, got %v", err)
+	}
+
+	ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("subnets"): "subnet-1"})
+	if _, err := p.StringSlice("subnets", ing, p.Spec().Subnets); !errors.IsInvalidContent(err) {
+		t.Errorf("expected other subnets to be rejected, got %v", err)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	p := newParams(v1alpha1.IngressClassParamsSpec{Tags: map[string]string{"team": "platform"}})
+
+	tags := map[string]string{"app": "web"}
+	if err := p.MergeTags(tags); err != nil {
+		t.Fatal(err)
+	}
+	if tags["team"] != "platform" || tags["app"] != "web" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	if err := p.MergeTags(map[string]string{"team": "web"}); !errors.IsInvalidContent(err) {
+		t.Errorf("expected a conflicting tag to be rejected, got %v", err)
+	}
+}
+
+func TestCheckNamespace(t *testing.T) {
+	ing := dummy.NewIngress()
+
+	if err := (Params{}).CheckNamespace(ing); err != nil {
+		t.Errorf("expected every namespace to be allowed without params, got %v", err)
+	}
+	if err := newParams(v1alpha1.IngressClassParamsSpec{Namespaces: []string{"other", ing.Namespace}}).CheckNamespace(ing); err != nil {
+		t.Errorf("expected %s to be allowed, got %v", ing.Namespace, err)
+	}
+	if err := newParams(v1alpha1.IngressClassParamsSpec{Namespaces: []string{"other"}}).CheckNamespace(ing); !errors.IsInvalidContent(err) {
+		t.Errorf("expected %s to be rejected, got %v", ing.Namespace, err)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+// Condition fields supported by ALB rules
+const (
+	FieldHostHeader        = "host-header"
+	FieldPathPattern       = "path-pattern"
+	FieldHTTPHeader        = "http-header"
+	FieldHTTPRequestMethod = "http-request-method"
+	FieldQueryString       = "query-string"
+	FieldSourceIP          = "source-ip"
+)
+
+// Config contains the additional rule conditions configured for each backend service
+type Config struct {
+	Conditions map[string][]*elbv2.RuleCondition
+}
+
+type conditions struct {
+	r resolver.Resolver
+}
+
+// NewParser creates a new rule conditions annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return conditions{r}
+}
+
+// Parse parses the annotations contained in the resource
+func (c conditions) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	cfg := make(map[string][]*elbv2.RuleCondition)
+	annos, err := parser.GetStringAnnotations("conditions", ing)
+	if err != nil {
+		return nil, err
+	}
+
+	for serviceName, raw := range annos {
+		var data []*elbv2.RuleCondition
+		err := json.Unmarshal([]byte(raw), &data)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make(map[string]bool)
+		for _, condition := range data {
+			if condition == nil {
+				return nil, fmt.Errorf("conditions.%v contains an empty condition", serviceName)
+			}
+			if err := validate(condition); err != nil {
+				return nil, fmt.Errorf("conditions.%v: %v", serviceName, err.Error())
+			}
+
+			field := aws.StringValue(condition.Field)
+			if fields[field] && field != FieldHTTPHeader && field != FieldQueryString {
+				return nil, fmt.Errorf("conditions.%v contains more than one %v condition", serviceName, field)
+			}
+			fields[field] = true
+		}
+		cfg[serviceName] = data
+	}
+
+	return &Config{
+		Conditions: cfg,
+	}, nil
+}
+
+// validate ensures the condition carries the configuration matching its field
+func validate(c *elbv2.RuleCondition) error {
+	switch aws.StringValue(c.Field) {
+	case FieldHostHeader:
+		if len(c.Values) == 0 && (c.HostHeaderConfig == nil || len(c.HostHeaderConfig.Values) == 0) {
+			return fmt.Errorf("%v condition did not include a valid HostHeaderConfig configuration", FieldHostHeader)
+		}
+	case FieldPathPattern:
+		if len(c.Values) == 0 && (c.PathPatternConfig == nil || len(c.PathPatternConfig.Values) == 0) {
+			return fmt.Errorf("%v condition did not include a valid PathPatternConfig configuration", FieldPathPattern)
+		}
+	case FieldHTTPHeader:
+		if c.HttpHeaderConfig == nil || c.HttpHeaderConfig.HttpHeaderName == nil || len(c.HttpHeaderConfig.Values) == 0 {
+			return fmt.Errorf("%v condition did not include a valid HttpHeaderConfig configuration", FieldHTTPHeader)
+		}
+	case FieldHTTPRequestMethod:
+		if c.HttpRequestMethodConfig == nil || len(c.HttpRequestMethodConfig.Values) == 0 {
+			return fmt.Errorf("%v condition did not include a valid HttpRequestMethodConfig configuration", FieldHTTPRequestMethod)
+		}
+	case FieldQueryString:
+		if c.QueryStringConfig == nil || len(c.QueryStringConfig.Values) == 0 {
+			return fmt.Errorf("%v condition did not include a valid QueryStringConfig configuration", FieldQueryString)
+		}
+		for _, kv := range c.QueryStringConfig.Values {
+			if kv == nil || kv.Value == nil {
+				return fmt.Errorf("%v condition contains a key/value pair without a Value", FieldQueryString)
+			}
+		}
+	case FieldSourceIP:
+		if c.SourceIpConfig == nil || len(c.SourceIpConfig.Values) == 0 {
+			return fmt.Errorf("%v condition did not include a valid SourceIpConfig configuration", FieldSourceIP)
+		}
+	default:
+		return fmt.Errorf("an invalid condition field %v was configured", aws.StringValue(c.Field))
+	}
+	return nil
+}
+
+// GetConditions returns the conditions configured by an annotation for serviceName
+func (c *Config) GetConditions(serviceName string) []*elbv2.RuleCondition {
+	if c == nil {
+		return nil
+	}
+	return c.Conditions[serviceName]
+}
+
+// Values returns the values of a host-header or path-pattern condition, which may be set either
+// on the condition itself or on its field configuration.
+func Values(c *elbv2.RuleCondition) []*string {
+	switch aws.StringValue(c.Field) {
+	case FieldHostHeader:
+		if c.HostHeaderConfig != nil && len(c.HostHeaderConfig.Values) > 0 {
+			return c.HostHeaderConfig.Values
+		}
+	case FieldPathPattern:
+		if c.PathPatternConfig != nil && len(c.PathPatternConfig.Values) > 0 {
+			return c.PathPatternConfig.Values
+		}
+	}
+	return c.Values
+}
+
+func Dummy() *Config {
+	return &Config{
+		Conditions: map[string][]*elbv2.RuleCondition{
+			"header-routed-service": {
+				{
+					Field: aws.String(FieldHTTPHeader),
+					HttpHeaderConfig: &elbv2.HttpHeaderConditionConfig{
+						HttpHeaderName: aws.String("X-Api-Version"),
+						Values:         []*string{aws.String("v2")},
+					},
+				},
+			},
+		},
+	}
+}
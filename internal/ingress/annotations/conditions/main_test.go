@@ -0,0 +1,73 @@
+package conditions
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestIngressConditions(t *testing.T) {
+	ing := dummy.NewIngress()
+
+	data := map[string]string{}
+	data[parser.GetAnnotationWithPrefix("conditions.api")] = `[
+	{"Field": "http-header", "HttpHeaderConfig": {"HttpHeaderName": "X-Api-Version", "Values": ["v2"]}},
+	{"Field": "query-string", "QueryStringConfig": {"Values": [{"Key": "beta", "Value": "true"}]}},
+	{"Field": "http-request-method", "HttpRequestMethodConfig": {"Values": ["GET", "HEAD"]}},
+	{"Field": "source-ip", "SourceIpConfig": {"Values": ["10.0.0.0/8"]}},
+	{"Field": "host-header", "HostHeaderConfig": {"Values": ["api.example.com", "api.example.org"]}}]`
+	ing.SetAnnotations(data)
+
+	ci, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	c, ok := ci.(*Config)
+	if !ok {
+		t.Errorf("expected a Config type")
+		return
+	}
+
+	conditions := c.GetConditions("api")
+	if len(conditions) != 5 {
+		t.Fatalf("expected 5 conditions, got %v", len(conditions))
+	}
+	if *conditions[0].HttpHeaderConfig.HttpHeaderName != "X-Api-Version" {
+		t.Errorf("expected http-header condition on X-Api-Version, got %v", *conditions[0].HttpHeaderConfig.HttpHeaderName)
+	}
+	if hosts := Values(conditions[4]); len(hosts) != 2 || aws.StringValue(hosts[1]) != "api.example.org" {
+		t.Errorf("expected two host-header values, got %v", aws.StringValueSlice(hosts))
+	}
+	if c.GetConditions("other") != nil {
+		t.Errorf("expected no conditions for a service without annotation")
+	}
+}
+
+func TestInvalidIngressConditions(t *testing.T) {
+	for _, raw := range []string{
+		`{"Field": "http-header"}`,
+		`[{"Field": "cookie", "Values": ["a"]}]`,
+		`[{"Field": "http-header", "HttpHeaderConfig": {"Values": ["v2"]}}]`,
+		`[{"Field": "query-string", "QueryStringConfig": {"Values": [{"Key": "beta"}]}}]`,
+		`[{"Field": "http-request-method"}]`,
+		`[{"Field": "source-ip", "SourceIpConfig": {"Values": []}}]`,
+		`[{"Field": "source-ip", "SourceIpConfig": {"Values": ["10.0.0.0/8"]}}, {"Field": "source-ip", "SourceIpConfig": {"Values": ["192.168.0.0/16"]}}]`,
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("conditions.api"): raw})
+
+		_, err := NewParser(mockBackend{}).Parse(ing)
+		if err == nil {
+			t.Errorf("invalid annotation configuration %v was provided but an error was not returned", raw)
+		}
+	}
+}
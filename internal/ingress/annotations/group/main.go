@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package group
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+const (
+	minOrder = -1000
+	maxOrder = 1000
+)
+
+var groupNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// Config describes the ingress group an Ingress belongs to. Ingresses sharing a group name are
+// served by a single ALB.
+type Config struct {
+	Name  string
+	Order int64
+}
+
+type group struct {
+	r resolver.Resolver
+}
+
+// NewParser creates a new ingress group annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return group{r}
+}
+
+// Parse parses the annotations contained in the resource
+func (g group) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	cfg := &Config{}
+
+	if name, err := parser.GetStringAnnotation("group.name", ing); err == nil {
+		if !groupNameRegex.MatchString(*name) {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("group.name %q must consist of lower case alphanumeric characters or '-' and be at most 63 characters", *name))
+		}
+		cfg.Name = *name
+	}
+
+	if order, err := parser.GetInt64Annotation("group.order", ing); err == nil {
+		if *order < minOrder || *order > maxOrder {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("group.order %d must be between %d and %d", *order, minOrder, maxOrder))
+		}
+		cfg.Order = *order
+	} else if !errors.IsMissingAnnotations(err) {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Grouped returns true if the Ingress is a member of an ingress group
+func (c *Config) Grouped() bool {
+	return c != nil && c.Name != ""
+}
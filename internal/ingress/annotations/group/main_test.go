@@ -0,0 +1,71 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		Annotations    map[string]string
+		ExpectedResult *Config
+		ExpectError    bool
+	}{
+		{
+			Annotations:    map[string]string{},
+			ExpectedResult: &Config{},
+		},
+		{
+			Annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("group.name"): "shared",
+			},
+			ExpectedResult: &Config{Name: "shared"},
+		},
+		{
+			Annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("group.name"):  "shared",
+				parser.GetAnnotationWithPrefix("group.order"): "-10",
+			},
+			ExpectedResult: &Config{Name: "shared", Order: -10},
+		},
+		{
+			Annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("group.name"): "Not_Valid",
+			},
+			ExpectError: true,
+		},
+		{
+			Annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("group.name"):  "shared",
+				parser.GetAnnotationWithPrefix("group.order"): "1001",
+			},
+			ExpectError: true,
+		},
+		{
+			Annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("group.name"):  "shared",
+				parser.GetAnnotationWithPrefix("group.order"): "first",
+			},
+			ExpectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.Annotations)
+
+		actual, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.ExpectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedResult, actual)
+	}
+}
@@ -17,14 +17,21 @@ limitations under the License.
 package listener
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/classparams"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 )
 
 type Config struct {
 	SslPolicy      *string
 	CertificateArn *string
+	// AdditionalCertificateArns are the certificates following the default certificate in the
+	// certificate-arn annotation, served to clients based on SNI
+	AdditionalCertificateArns []*string
 }
 
 type listener struct {
@@ -42,27 +49,48 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 
 // Parse parses the annotations contained in the resource
 func (l listener) Parse(ing parser.AnnotationInterface) (interface{}, error) {
-	sslPolicy, err := parser.GetStringAnnotation("ssl-policy", ing)
+	params := classparams.Get(l.r, ing)
+	sslPolicy, err := params.String("ssl-policy", ing, params.Spec().SSLPolicy)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
 	if err != nil {
 		sslPolicy = aws.String(DefaultSslPolicy)
 	}
 
-	certificateArn, _ := parser.GetStringAnnotation("certificate-arn", ing)
-
-	if certificateArn == nil {
-		sslPolicy = nil
+	var certificateArn *string
+	var additionalCertificateArns []*string
+	if v, err := parser.GetStringAnnotation("certificate-arn", ing); err == nil {
+		for _, arn := range strings.Split(*v, ",") {
+			arn = strings.TrimSpace(arn)
+			if arn == "" {
+				continue
+			}
+			if certificateArn == nil {
+				certificateArn = aws.String(arn)
+				continue
+			}
+			additionalCertificateArns = append(additionalCertificateArns, aws.String(arn))
+		}
 	}
 
 	return &Config{
-		SslPolicy:      sslPolicy,
-		CertificateArn: certificateArn,
+		SslPolicy:                 sslPolicy,
+		CertificateArn:            certificateArn,
+		AdditionalCertificateArns: additionalCertificateArns,
 	}, nil
 }
 
 // Merge merges two config
 func (a *Config) Merge(b *Config) *Config {
-	return &Config{
-		SslPolicy:      parser.MergeString(a.SslPolicy, b.SslPolicy, ""),
-		CertificateArn: parser.MergeString(a.CertificateArn, b.CertificateArn, ""),
+	c := &Config{
+		SslPolicy:                 parser.MergeString(a.SslPolicy, b.SslPolicy, ""),
+		CertificateArn:            parser.MergeString(a.CertificateArn, b.CertificateArn, ""),
+		AdditionalCertificateArns: a.AdditionalCertificateArns,
+	}
+	// the additional certificates belong to the certificate-arn annotation providing the default
+	if c.CertificateArn == b.CertificateArn {
+		c.AdditionalCertificateArns = b.AdditionalCertificateArns
 	}
+	return c
 }
@@ -4,9 +4,37 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestParseCertificateArns(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("certificate-arn"): "arn:default, arn:second,,arn:third",
+	})
+
+	i, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &Config{
+		SslPolicy:                 aws.String(DefaultSslPolicy),
+		CertificateArn:            aws.String("arn:default"),
+		AdditionalCertificateArns: []*string{aws.String("arn:second"), aws.String("arn:third")},
+	}, i)
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Config
@@ -41,8 +69,47 @@ func TestMerge(t *testing.T) {
 				CertificateArn: aws.String("CertificateArnB"),
 			},
 		},
+		{
+			Source: &Config{
+				CertificateArn: aws.String(""),
+			},
+			Target: &Config{
+				CertificateArn:            aws.String("CertificateArnB"),
+				AdditionalCertificateArns: []*string{aws.String("CertificateArnC")},
+			},
+			ExpectedResult: &Config{
+				CertificateArn:            aws.String("CertificateArnB"),
+				AdditionalCertificateArns: []*string{aws.String("CertificateArnC")},
+			},
+		},
 	} {
 		actualResult := tc.Source.Merge(tc.Target)
 		assert.Equal(t, tc.ExpectedResult, actualResult)
 	}
 }
+
+func TestParseSslPolicyIngressClassParams(t *testing.T) {
+	params := &v1alpha1.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+		Spec:       v1alpha1.IngressClassParamsSpec{SSLPolicy: "ELBSecurityPolicy-TLS-1-2-2017-01"},
+	}
+	r := mockBackend{resolver.Mock{IngressClassParams: params}}
+
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{})
+	i, err := NewParser(r).Parse(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, aws.String("ELBSecurityPolicy-TLS-1-2-2017-01"), i.(*Config).SslPolicy)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("ssl-policy"): "ELBSecurityPolicy-TLS-1-2-2017-01",
+	})
+	_, err = NewParser(r).Parse(ing)
+	assert.NoError(t, err)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("ssl-policy"): "ELBSecurityPolicy-2016-08",
+	})
+	_, err = NewParser(r).Parse(ing)
+	assert.True(t, errors.IsInvalidContent(err), "expected the ssl-policy annotation to be rejected, got %v", err)
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafregional"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/classparams"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
@@ -44,6 +46,12 @@ type Config struct {
 	IPAddressType *string
 	WebACLId      *string
 
+	// WAFv2ACLArn is nil when the WAFv2 Web ACL association isn't managed, empty to disassociate it
+	WAFv2ACLArn *string
+
+	// ShieldAdvancedProtection is nil when the controller default applies
+	ShieldAdvancedProtection *bool
+
 	InboundCidrs   util.Cidrs
 	Ports          []PortData
 	SecurityGroups util.AWSStringSlice
@@ -67,6 +75,11 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 
 // Parse parses the annotations contained in the resource
 func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	params := classparams.Get(lb.r, ing)
+	if err := params.CheckNamespace(ing); err != nil {
+		return nil, err
+	}
+
 	// support legacy waf-acl-id annotation
 	webACLId, err := parser.GetStringAnnotation("waf-acl-id", ing)
 	if err == nil {
@@ -91,16 +104,44 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 		}
 	}
 
+	// the WAFv2 Web ACL association is left alone without the annotation, an empty or none value
+	// disassociates the WAFv2 Web ACL
+	wafv2ACLArn, err := parser.GetStringAnnotation("wafv2-acl-arn", ing)
+	if err == nil && (*wafv2ACLArn == "" || *wafv2ACLArn == "none") {
+		wafv2ACLArn = aws.String("")
+	} else if err == nil {
+		// a load balancer is associated with a single Web ACL, either of WAF Classic or of WAFv2
+		if webACLId != nil {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("WAF Classic Web ACL %s and WAFv2 Web ACL %s can't both be associated with the load balancer, only one of the web-acl-id and wafv2-acl-arn annotations can be set", *webACLId, *wafv2ACLArn))
+		}
+		b, err := albwafv2.WAFV2svc.WebACLExists(wafv2ACLArn)
+		if err != nil {
+			return nil, fmt.Errorf("WAFv2 Web ACL does not exist. ARN: %s, error: %s", *wafv2ACLArn, err.Error())
+		}
+		if b == false {
+			return nil, fmt.Errorf("WAFv2 Web ACL does not exist. ARN: %s", *wafv2ACLArn)
+		}
+	}
+
+	shieldAdvancedProtection, err := parser.GetBoolAnnotation("shield-advanced-protection", ing)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
+
 	ipAddressType, err := parser.GetStringAnnotation("ip-address-type", ing)
 	if err != nil {
 		ipAddressType = aws.String(DefaultIPAddressType)
 	}
 
-	if *ipAddressType != elbv2.IpAddressTypeIpv4 && *ipAddressType != elbv2.IpAddressTypeDualstack {
-		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("IP address type must be either `%v` or `%v`", elbv2.IpAddressTypeIpv4, elbv2.IpAddressTypeDualstack))
+	if *ipAddressType != elbv2.IpAddressTypeIpv4 && *ipAddressType != elbv2.IpAddressTypeDualstack && *ipAddressType != elbv2.IpAddressTypeDualstackWithoutPublicIpv4 {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("IP address type must be either `%v`, `%v` or `%v`", elbv2.IpAddressTypeIpv4, elbv2.IpAddressTypeDualstack, elbv2.IpAddressTypeDualstackWithoutPublicIpv4))
 	}
+	dualstack := *ipAddressType != elbv2.IpAddressTypeIpv4
 
-	scheme, err := parser.GetStringAnnotation("scheme", ing)
+	scheme, err := params.String("scheme", ing, params.Spec().Scheme)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
 	if err != nil {
 		scheme = aws.String(DefaultScheme)
 	}
@@ -109,7 +150,15 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ALB scheme must be either `%v` or `%v`", elbv2.LoadBalancerSchemeEnumInternal, elbv2.LoadBalancerSchemeEnumInternetFacing))
 	}
 
-	subnets, err := parseSubnets(ing, scheme)
+	if *ipAddressType == elbv2.IpAddressTypeDualstackWithoutPublicIpv4 && *scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("IP address type `%v` requires the `%v` scheme", elbv2.IpAddressTypeDualstackWithoutPublicIpv4, elbv2.LoadBalancerSchemeEnumInternetFacing))
+	}
+
+	v, err := params.StringSlice("subnets", ing, params.Spec().Subnets)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
+	subnets, err := parseSubnets(v, scheme)
 	if err != nil {
 		return nil, err
 	}
@@ -127,13 +176,20 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 		return nil, err
 	}
 
-	securityGroups, err := parseSecurityGroups(ing)
+	v, err = params.StringSlice("security-groups", ing, params.Spec().SecurityGroups)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
+	securityGroups, err := parseSecurityGroups(v)
 	if err != nil {
 		return nil, err
 	}
 
 	cidrs := util.Cidrs{}
-	c, err := parser.GetStringAnnotation("security-group-inbound-cidrs", ing)
+	c, err := params.StringSlice("security-group-inbound-cidrs", ing, params.Spec().InboundCIDRs)
+	if errors.IsInvalidContent(err) {
+		return nil, err
+	}
 	if err == nil {
 		for _, inboundCidr := range util.NewAWSStringSlice(*c) {
 			ip, _, err := net.ParseCIDR(*inboundCidr)
@@ -141,21 +197,28 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 				return nil, err
 			}
 
-			if ip.To4() == nil {
-				return nil, fmt.Errorf("CIDR must use an IPv4 address: %v", *inboundCidr)
+			// IPv6 clients only reach dualstack load balancers
+			if ip.To4() == nil && !dualstack {
+				return nil, fmt.Errorf("CIDR must use an IPv4 address unless the IP address type is dualstack: %v", *inboundCidr)
 			}
 			cidrs = append(cidrs, inboundCidr)
 		}
 	}
 	if len(cidrs) == 0 {
 		cidrs = append(cidrs, aws.String("0.0.0.0/0"))
+		if dualstack {
+			cidrs = append(cidrs, aws.String("::/0"))
+		}
 	}
 
 	return &Config{
 		WebACLId:      webACLId,
+		WAFv2ACLArn:   wafv2ACLArn,
 		Scheme:        scheme,
 		IPAddressType: ipAddressType,
 
+		ShieldAdvancedProtection: shieldAdvancedProtection,
+
 		Attributes:   attributes,
 		InboundCidrs: cidrs,
 		Ports:        ports,
@@ -165,10 +228,18 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 	}, nil
 }
 
-func parseSubnets(ing parser.AnnotationInterface, scheme *string) (util.Subnets, error) {
-	v, err := parser.GetStringAnnotation("subnets", ing)
+// ParseSubnets returns the subnets of the subnets annotation, or the subnets discovered for the
+// scheme when the annotation is absent.
+func ParseSubnets(ing parser.AnnotationInterface, scheme *string) (util.Subnets, error) {
+	v, _ := parser.GetStringAnnotation("subnets", ing)
+	return parseSubnets(v, scheme)
+}
+
+// parseSubnets resolves the comma separated subnet IDs or Name tags of v, or returns the subnets
+// discovered for the scheme when v is nil.
+func parseSubnets(v *string, scheme *string) (util.Subnets, error) {
 	// if the subnet annotation isn't specified, lookup appropriate subnets to use
-	if err != nil {
+	if v == nil {
 		subnets, err := albec2.ClusterSubnets(scheme)
 		return subnets, err
 	}
@@ -286,11 +357,10 @@ func parsePorts(ing parser.AnnotationInterface) ([]PortData, error) {
 	return lps, nil
 }
 
-func parseSecurityGroups(ing parser.AnnotationInterface) (sgs util.AWSStringSlice, err error) {
+func parseSecurityGroups(v *string) (sgs util.AWSStringSlice, err error) {
 	// no security groups specified means controller should manage them, if so return and sg will be
 	// created and managed during reconcile.
-	v, err := parser.GetStringAnnotation("security-groups", ing)
-	if err != nil {
+	if v == nil {
 		return sgs, nil
 	}
 
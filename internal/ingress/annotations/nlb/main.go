@@ -0,0 +1,181 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nlb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+)
+
+// LoadBalancerTypeNetwork is the load-balancer-type value selecting a network load balancer
+const LoadBalancerTypeNetwork = "nlb"
+
+const (
+	DefaultScheme               = elbv2.LoadBalancerSchemeEnumInternal
+	DefaultHealthCheckProtocol  = elbv2.ProtocolEnumTcp
+	DefaultHealthCheckPort      = "traffic-port"
+	DefaultHealthCheckPath      = "/"
+	DefaultHealthCheckInterval  = 30
+	DefaultHealthCheckThreshold = 3
+)
+
+// Health check limits of network load balancer target groups
+const (
+	healthCheckIntervalShort = 10
+	minHealthCheckThreshold  = 2
+	maxHealthCheckThreshold  = 10
+)
+
+// Config contains the network load balancer settings of a Service of type LoadBalancer
+type Config struct {
+	Scheme  *string
+	Subnets util.Subnets
+
+	// TLSPorts are the names or numbers of the service ports terminating TLS. An empty slice
+	// means every port terminates TLS when a certificate is configured.
+	TLSPorts []string
+
+	HealthCheckProtocol        *string
+	HealthCheckPort            *string
+	HealthCheckPath            *string
+	HealthCheckIntervalSeconds *int64
+	HealthCheckThresholdCount  *int64
+}
+
+type nlb struct {
+	r resolver.Resolver
+}
+
+// NewParser creates a new network load balancer annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return nlb{r}
+}
+
+// Parse parses the annotations contained in the resource. ErrMissingAnnotations is returned
+// unless the resource asks for a network load balancer.
+func (n nlb) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	lbType, err := parser.GetStringAnnotation("load-balancer-type", ing)
+	if err != nil {
+		return nil, err
+	}
+	if *lbType != LoadBalancerTypeNetwork {
+		return nil, errors.NewInvalidAnnotationContent("load-balancer-type", *lbType)
+	}
+
+	scheme, err := parser.GetStringAnnotation("scheme", ing)
+	if err != nil {
+		scheme = aws.String(DefaultScheme)
+	}
+	if *scheme != elbv2.LoadBalancerSchemeEnumInternal && *scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("NLB scheme must be either `%v` or `%v`", elbv2.LoadBalancerSchemeEnumInternal, elbv2.LoadBalancerSchemeEnumInternetFacing))
+	}
+
+	subnets, err := loadbalancer.ParseSubnets(ing, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if len(subnets) == 0 {
+		return nil, errors.NewInvalidAnnotationContentReason(`No subnets defined or were discoverable`)
+	}
+
+	var tlsPorts []string
+	if v, err := parser.GetStringAnnotation("tls-ports", ing); err == nil {
+		for _, p := range strings.Split(*v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				tlsPorts = append(tlsPorts, p)
+			}
+		}
+	}
+
+	protocol, err := parser.GetStringAnnotation("healthcheck-protocol", ing)
+	if err != nil {
+		protocol = aws.String(DefaultHealthCheckProtocol)
+	}
+	switch *protocol {
+	case elbv2.ProtocolEnumTcp, elbv2.ProtocolEnumHttp, elbv2.ProtocolEnumHttps:
+	default:
+		return nil, errors.NewInvalidAnnotationContent("healthcheck-protocol", *protocol)
+	}
+
+	port, err := parser.GetStringAnnotation("healthcheck-port", ing)
+	if err != nil {
+		port = aws.String(DefaultHealthCheckPort)
+	}
+
+	var path *string
+	if *protocol != elbv2.ProtocolEnumTcp {
+		path, err = parser.GetStringAnnotation("healthcheck-path", ing)
+		if err != nil {
+			path = aws.String(DefaultHealthCheckPath)
+		}
+	}
+
+	interval, err := parser.GetInt64Annotation("healthcheck-interval-seconds", ing)
+	if err != nil {
+		if !errors.IsMissingAnnotations(err) {
+			return nil, err
+		}
+		interval = aws.Int64(DefaultHealthCheckInterval)
+	}
+	if *interval != healthCheckIntervalShort && *interval != DefaultHealthCheckInterval {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthcheck-interval-seconds must be either %d or %d for network load balancers", healthCheckIntervalShort, DefaultHealthCheckInterval))
+	}
+
+	threshold, err := parser.GetInt64Annotation("healthy-threshold-count", ing)
+	if err != nil {
+		if !errors.IsMissingAnnotations(err) {
+			return nil, err
+		}
+		threshold = aws.Int64(DefaultHealthCheckThreshold)
+	}
+	if *threshold < minHealthCheckThreshold || *threshold > maxHealthCheckThreshold {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthy-threshold-count must be between %d and %d", minHealthCheckThreshold, maxHealthCheckThreshold))
+	}
+
+	return &Config{
+		Scheme:                     scheme,
+		Subnets:                    subnets,
+		TLSPorts:                   tlsPorts,
+		HealthCheckProtocol:        protocol,
+		HealthCheckPort:            port,
+		HealthCheckPath:            path,
+		HealthCheckIntervalSeconds: interval,
+		HealthCheckThresholdCount:  threshold,
+	}, nil
+}
+
+// IsTLSPort returns true if the service port with the given name and number terminates TLS
+func (c *Config) IsTLSPort(name string, port int32) bool {
+	if len(c.TLSPorts) == 0 {
+		return true
+	}
+	for _, p := range c.TLSPorts {
+		if p == name || p == fmt.Sprintf("%d", port) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package nlb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestParse(t *testing.T) {
+	svc := dummy.NewService()
+	svc.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("load-balancer-type"): "nlb",
+		parser.GetAnnotationWithPrefix("scheme"):             "internet-facing",
+		parser.GetAnnotationWithPrefix("subnets"):            "subnet-b, subnet-a",
+		parser.GetAnnotationWithPrefix("tls-ports"):          "https, 8443",
+	})
+
+	ci, err := NewParser(mockBackend{}).Parse(svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := ci.(*Config)
+
+	if *c.Scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+		t.Errorf("expected scheme internet-facing, got %v", *c.Scheme)
+	}
+	if len(c.Subnets) != 2 || *c.Subnets[0] != "subnet-a" {
+		t.Errorf("expected sorted subnets, got %v", c.Subnets)
+	}
+	if *c.HealthCheckProtocol != DefaultHealthCheckProtocol || c.HealthCheckPath != nil {
+		t.Errorf("expected a TCP health check without path")
+	}
+	if *c.HealthCheckIntervalSeconds != DefaultHealthCheckInterval || *c.HealthCheckThresholdCount != DefaultHealthCheckThreshold {
+		t.Errorf("expected default health check interval and threshold, got %v and %v", *c.HealthCheckIntervalSeconds, *c.HealthCheckThresholdCount)
+	}
+	if !c.IsTLSPort("https", 443) || !c.IsTLSPort("", 8443) || c.IsTLSPort("http", 80) {
+		t.Errorf("unexpected TLS ports %v", c.TLSPorts)
+	}
+}
+
+func TestParseMissing(t *testing.T) {
+	svc := dummy.NewService()
+	svc.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("scheme"): "internet-facing",
+	})
+
+	_, err := NewParser(mockBackend{}).Parse(svc)
+	if !errors.IsMissingAnnotations(err) {
+		t.Errorf("expected ErrMissingAnnotations for a service without load-balancer-type, got %v", err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, annotations := range []map[string]string{
+		{"load-balancer-type": "alb"},
+		{"load-balancer-type": "nlb", "scheme": "public"},
+		{"load-balancer-type": "nlb", "healthcheck-protocol": "UDP"},
+		{"load-balancer-type": "nlb", "healthcheck-interval-seconds": "15"},
+		{"load-balancer-type": "nlb", "healthy-threshold-count": "1"},
+	} {
+		data := map[string]string{parser.GetAnnotationWithPrefix("subnets"): "subnet-a"}
+		for k, v := range annotations {
+			data[parser.GetAnnotationWithPrefix(k)] = v
+		}
+		svc := dummy.NewService()
+		svc.SetAnnotations(data)
+
+		_, err := NewParser(mockBackend{}).Parse(svc)
+		if err == nil {
+			t.Errorf("invalid annotations %v were provided but an error was not returned", annotations)
+		}
+	}
+}
+
+func TestIsTLSPortDefault(t *testing.T) {
+	c := &Config{}
+	if !c.IsTLSPort("http", 80) {
+		t.Errorf("expected every port to terminate TLS when tls-ports is not set")
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package rule
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
@@ -24,6 +27,9 @@ import (
 
 type Config struct {
 	IgnoreHostHeader *bool
+
+	// Priorities maps a path, optionally prefixed by its host, to the priority of its rule
+	Priorities map[string]int64
 }
 
 type rule struct {
@@ -32,6 +38,9 @@ type rule struct {
 
 const (
 	DefaultIgnoreHostHeader = false
+
+	// MaxPriority is the highest priority of a listener rule
+	MaxPriority = 50000
 )
 
 // NewParser creates a new target group annotation parser
@@ -46,14 +55,58 @@ func (r rule) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 		ignoreHostHeader = aws.Bool(DefaultIgnoreHostHeader)
 	}
 
+	priorities, err := parsePriorities(ing)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		IgnoreHostHeader: ignoreHostHeader,
+		Priorities:       priorities,
 	}, nil
 }
 
+// parsePriorities parses the rule-priorities annotation, a JSON object such as
+// {"/api/*": 10, "example.com/static/*": 20}
+func parsePriorities(ing parser.AnnotationInterface) (map[string]int64, error) {
+	raw, err := parser.GetStringAnnotation("rule-priorities", ing)
+	if err != nil {
+		return nil, nil
+	}
+
+	var priorities map[string]int64
+	if err := json.Unmarshal([]byte(*raw), &priorities); err != nil {
+		return nil, fmt.Errorf("rule-priorities must be a JSON object of paths to priorities: %v", err.Error())
+	}
+	for path, p := range priorities {
+		if p < 1 || p > MaxPriority {
+			return nil, fmt.Errorf("rule-priorities of %v must be between 1 and %v, got %v", path, MaxPriority, p)
+		}
+	}
+	return priorities, nil
+}
+
+// Priority returns the priority configured for the rule of path on host, or 0 when the priority is
+// allocated by the controller. A priority configured for host and path takes precedence over one
+// configured for the path alone.
+func (a *Config) Priority(host, path string) int64 {
+	if a == nil {
+		return 0
+	}
+	if p, ok := a.Priorities[host+path]; ok && host != "" {
+		return p
+	}
+	return a.Priorities[path]
+}
+
 // Merge merges two config
 func (a *Config) Merge(b *Config) *Config {
+	priorities := a.Priorities
+	if priorities == nil {
+		priorities = b.Priorities
+	}
 	return &Config{
 		IgnoreHostHeader: parser.MergeBool(a.IgnoreHostHeader, b.IgnoreHostHeader, DefaultIgnoreHostHeader),
+		Priorities:       priorities,
 	}
 }
@@ -5,6 +5,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 )
 
 func TestMerge(t *testing.T) {
@@ -40,3 +43,41 @@ func TestMerge(t *testing.T) {
 		assert.Equal(t, tc.ExpectedResult, actualResult)
 	}
 }
+
+func TestParsePriorities(t *testing.T) {
+	for _, tc := range []struct {
+		Annotation string
+		Expected   map[string]int64
+		Pass       bool
+	}{
+		{
+			Annotation: `{"/api/*": 10, "www.example.com/static/*": 20}`,
+			Expected:   map[string]int64{"/api/*": 10, "www.example.com/static/*": 20},
+			Pass:       true,
+		},
+		{Annotation: `["/api/*"]`},
+		{Annotation: `{"/api/*": 0}`},
+		{Annotation: `{"/api/*": 50001}`},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("rule-priorities"): tc.Annotation})
+
+		cfg, err := NewParser(nil).Parse(ing)
+		if !tc.Pass {
+			assert.Error(t, err, tc.Annotation)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.Expected, cfg.(*Config).Priorities)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	cfg := &Config{Priorities: map[string]int64{"/api/*": 10, "www.example.com/api/*": 20}}
+
+	assert.Equal(t, int64(20), cfg.Priority("www.example.com", "/api/*"))
+	assert.Equal(t, int64(10), cfg.Priority("api.example.com", "/api/*"))
+	assert.Equal(t, int64(10), cfg.Priority("", "/api/*"))
+	assert.Equal(t, int64(0), cfg.Priority("www.example.com", "/static/*"))
+	assert.Equal(t, int64(0), (*Config)(nil).Priority("", "/api/*"))
+}
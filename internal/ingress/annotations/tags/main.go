@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/classparams"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
@@ -64,6 +65,10 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		}
 	}
 
+	if err := classparams.Get(tg.r, ing).MergeTags(lbtags); err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		LoadBalancer: lbtags,
 	}, nil
@@ -34,6 +34,7 @@ type Config struct {
 	Attributes              []*elbv2.TargetGroupAttribute
 	BackendProtocol         *string
 	HealthyThresholdCount   *int64
+	IPAddressType           *string
 	SuccessCodes            *string
 	TargetType              *string
 	UnhealthyThresholdCount *int64
@@ -48,6 +49,7 @@ const (
 	DefaultHealthyThresholdCount   = 2
 	DefaultUnhealthyThresholdCount = 2
 	DefaultSuccessCodes            = "200"
+	DefaultIPAddressType           = elbv2.TargetGroupIpAddressTypeEnumIpv4
 )
 
 // NewParser creates a new target group annotation parser
@@ -68,6 +70,15 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		return "", errors.NewInvalidAnnotationContent("target-type", *targetType)
 	}
 
+	ipAddressType, err := parser.GetStringAnnotation("target-group-ip-address-type", ing)
+	if err != nil {
+		ipAddressType = aws.String(DefaultIPAddressType)
+	}
+
+	if *ipAddressType != elbv2.TargetGroupIpAddressTypeEnumIpv4 && *ipAddressType != elbv2.TargetGroupIpAddressTypeEnumIpv6 {
+		return nil, errors.NewInvalidAnnotationContent("target-group-ip-address-type", *ipAddressType)
+	}
+
 	backendProtocol, err := parser.GetStringAnnotation("backend-protocol", ing)
 	if err != nil {
 		backendProtocol = aws.String(DefaultBackendProtocol)
@@ -101,6 +112,7 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 
 	return &Config{
 		TargetType:              targetType,
+		IPAddressType:           ipAddressType,
 		BackendProtocol:         backendProtocol,
 		HealthyThresholdCount:   healthyThresholdCount,
 		UnhealthyThresholdCount: unhealthyThresholdCount,
@@ -120,6 +132,7 @@ func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 		Attributes:              attributes,
 		BackendProtocol:         parser.MergeString(a.BackendProtocol, b.BackendProtocol, DefaultBackendProtocol),
 		TargetType:              parser.MergeString(a.TargetType, b.TargetType, cfg.DefaultTargetType),
+		IPAddressType:           parser.MergeString(a.IPAddressType, b.IPAddressType, DefaultIPAddressType),
 		SuccessCodes:            parser.MergeString(a.SuccessCodes, b.SuccessCodes, DefaultSuccessCodes),
 		HealthyThresholdCount:   parser.MergeInt64(a.HealthyThresholdCount, b.HealthyThresholdCount, DefaultHealthyThresholdCount),
 		UnhealthyThresholdCount: parser.MergeInt64(a.UnhealthyThresholdCount, b.UnhealthyThresholdCount, DefaultUnhealthyThresholdCount),
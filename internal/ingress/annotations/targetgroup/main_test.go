@@ -99,6 +99,23 @@ func TestMerge(t *testing.T) {
 				UnhealthyThresholdCount: aws.Int64(11),
 			},
 		},
+		{
+			Source: &Config{
+				TargetType:    aws.String("ip"),
+				IPAddressType: aws.String(DefaultIPAddressType),
+			},
+			Target: &Config{
+				TargetType:    aws.String("ip"),
+				IPAddressType: aws.String(elbv2.TargetGroupIpAddressTypeEnumIpv6),
+			},
+			Config: &config.Configuration{
+				DefaultTargetType: "instance",
+			},
+			ExpectedResult: &Config{
+				TargetType:    aws.String("ip"),
+				IPAddressType: aws.String(elbv2.TargetGroupIpAddressTypeEnumIpv6),
+			},
+		},
 	} {
 		actualResult := tc.Source.Merge(tc.Target, tc.Config)
 		assert.Equal(t, tc.ExpectedResult, actualResult)
@@ -59,8 +59,8 @@ func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, b
 	if err != nil {
 		return nil, err
 	}
-	if service.Spec.Type != corev1.ServiceTypeNodePort {
-		return nil, fmt.Errorf("%v service is not of type NodePort and target-type is instance", service.Name)
+	if service.Spec.Type != corev1.ServiceTypeNodePort && service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return nil, fmt.Errorf("%v service is not of type NodePort or LoadBalancer and target-type is instance", service.Name)
 	}
 	nodePort := servicePort.NodePort
 
@@ -128,22 +128,32 @@ func (resolver *endpointResolver) populateAZ(a []*elbv2.TargetDescription) error
 		return err
 	}
 
-	// Parse all CIDR blocks associated with the VPC
-	var ipv4Nets []*net.IPNet
+	// Parse all IPv4 and IPv6 CIDR blocks associated with the VPC
+	var vpcNets []*net.IPNet
 	for _, cblock := range vpc.CidrBlockAssociationSet {
 		_, parsed, err := net.ParseCIDR(*cblock.CidrBlock)
 		if err != nil {
 			return err
 		}
-		ipv4Nets = append(ipv4Nets, parsed)
+		vpcNets = append(vpcNets, parsed)
+	}
+	for _, cblock := range vpc.Ipv6CidrBlockAssociationSet {
+		if cblock.Ipv6CidrBlock == nil {
+			continue
+		}
+		_, parsed, err := net.ParseCIDR(*cblock.Ipv6CidrBlock)
+		if err != nil {
+			return err
+		}
+		vpcNets = append(vpcNets, parsed)
 	}
 
 	// Check if endpoints are in any of the blocks. If not the IP is outside the VPC
 	for i := range a {
 		found := false
 		aNet := net.ParseIP(*a[i].Id)
-		for _, ipv4Net := range ipv4Nets {
-			if ipv4Net.Contains(aNet) {
+		for _, vpcNet := range vpcNets {
+			if vpcNet.Contains(aNet) {
 				found = true
 				break
 			}
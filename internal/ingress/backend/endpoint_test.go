@@ -168,6 +168,65 @@ func TestResolveWithModeInstance(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "success scenario by LoadBalancer service",
+			ingress: &extensions.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			},
+			service: &api_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "service",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: api_v1.ServiceSpec{
+					Type: api_v1.ServiceTypeLoadBalancer,
+					Ports: []api_v1.ServicePort{
+						{
+							Port:     8080,
+							NodePort: nodePort,
+						},
+					},
+				},
+			},
+			nodes: []*api_v1.Node{
+				{
+					Spec: api_v1.NodeSpec{
+						ProviderID: nodeName1,
+					},
+				},
+				{
+					Spec: api_v1.NodeSpec{
+						ProviderID: nodeName2,
+					},
+				},
+				{
+					Spec: api_v1.NodeSpec{
+						ProviderID: nodeName3,
+					},
+				},
+			},
+			nodeHealthProbe: func(instanceID string) (bool, error) { return instanceID != nodeName2, nil },
+			expectedTargets: []*elbv2.TargetDescription{
+				{
+					Id:   &nodeName1,
+					Port: aws.Int64(nodePort),
+				},
+				{
+					Id:   &nodeName3,
+					Port: aws.Int64(nodePort),
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name: "failure scenario by service not found",
 			ingress: &extensions.Ingress{
@@ -598,3 +657,33 @@ func TestResolveWithModeIP(t *testing.T) {
 		})
 	}
 }
+
+func TestPopulateAZ(t *testing.T) {
+	ec2svc := &mocks.EC2API{}
+	ec2svc.On("GetVPCID").Return(aws.String("vpcid"), nil)
+	ec2svc.On("GetVPC", aws.String("vpcid")).Return(&ec2.Vpc{
+		CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+			{CidrBlock: aws.String("192.168.0.0/16")},
+		},
+		Ipv6CidrBlockAssociationSet: []*ec2.VpcIpv6CidrBlockAssociation{
+			{Ipv6CidrBlock: aws.String("2600:1f14:abc:de00::/56")},
+		},
+	}, nil)
+
+	targets := []*elbv2.TargetDescription{
+		{Id: aws.String("192.168.1.1")},
+		{Id: aws.String("10.0.0.1")},
+		{Id: aws.String("2600:1f14:abc:de01::1")},
+		{Id: aws.String("2600:1f14:abc:df00::1")},
+	}
+	resolver := &endpointResolver{ec2: ec2svc, store: store.NewDummy()}
+	if err := resolver.populateAZ(targets); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []*string{nil, aws.String("all"), nil, aws.String("all")} {
+		if !reflect.DeepEqual(targets[i].AvailabilityZone, expected) {
+			t.Errorf("%s: expected availability zone %v, got %v", *targets[i].Id, aws.StringValue(expected), aws.StringValue(targets[i].AvailabilityZone))
+		}
+	}
+}
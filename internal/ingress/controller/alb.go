@@ -20,8 +20,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash/crc32"
+	"sync/atomic"
 	"time"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
@@ -39,21 +41,28 @@ import (
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albacm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albdryrun"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albiam"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albrgt"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albroute53"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albsession"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albshield"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafregional"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albwafv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/status"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/webhook"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/nlbservice"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/sync"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/task"
 )
@@ -66,9 +75,12 @@ const (
 func NewALBController(config *config.Configuration, mc metric.Collector, cc *cache.Config) *ALBController {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(glog.Infof)
-	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
-		Interface: config.Client.CoreV1().Events(config.Namespace),
-	})
+	if !config.DryRun {
+		// events would report changes that are never applied
+		eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+			Interface: config.Client.CoreV1().Events(config.Namespace),
+		})
+	}
 
 	sess := albsession.NewSession(&aws.Config{MaxRetries: aws.Int(config.AWSAPIMaxRetries)}, config.AWSAPIDebug, mc, cc)
 	albelbv2.NewELBV2(sess)
@@ -78,6 +90,16 @@ func NewALBController(config *config.Configuration, mc metric.Collector, cc *cac
 	albiam.NewIAM(sess)
 	albrgt.NewRGT(sess, config.ClusterName)
 	albwafregional.NewWAFRegional(sess)
+	albwafv2.NewWAFV2(sess)
+	albshield.NewShield(sess)
+	albroute53.NewRoute53(sess)
+
+	var plan *albdryrun.Plan
+	if config.DryRun {
+		glog.Infof("Dry run enabled, changes to AWS resources will be recorded instead of applied")
+		plan = albdryrun.NewPlan()
+		albdryrun.Install(plan)
+	}
 
 	if len(config.ALBNamePrefix) > 12 {
 		glog.Fatalf("ALB Name prefix must be 12 characters or less")
@@ -105,35 +127,61 @@ func NewALBController(config *config.Configuration, mc metric.Collector, cc *cac
 
 		metricCollector: mc,
 		cache:           cc,
+		plan:            plan,
 	}
 
 	c.store = store.New(config, c.updateCh)
 	c.sgAssociationController = sg.NewAssociationController(c.store, albec2.EC2svc, albelbv2.ELBV2svc)
 	c.lbAttributesController = lb.NewAttributesController(albelbv2.ELBV2svc)
 	c.tgAttributesController = tg.NewAttributesController(albelbv2.ELBV2svc)
-	c.tgTargetsController = tg.NewTargetsController(albelbv2.ELBV2svc, backend.NewEndpointResolver(c.store, albec2.EC2svc))
+	c.endpointResolver = backend.NewEndpointResolver(c.store, albec2.EC2svc)
+	c.tgTargetsController = tg.NewTargetsController(albelbv2.ELBV2svc, c.endpointResolver)
 	c.tagsController = tags.NewController(albec2.EC2svc, albelbv2.ELBV2svc, albrgt.RGTsvc)
+	if config.Route53PublicHostedZoneID != "" || config.Route53PrivateHostedZoneID != "" {
+		c.dnsController = dns.NewController(albroute53.Route53svc, config.ClusterName)
+	}
+	c.ingressQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ingresses")
 	c.syncQueue = task.NewTaskQueue(c.syncIngress)
 	c.awsSyncQueue = task.NewTaskQueue(c.awsSync)
 	c.healthCheckQueue = task.NewTaskQueue(c.runHealthChecks)
-	c.syncStatus = status.NewStatusSyncer(status.Config{
-		Client:              config.Client,
-		IngressLister:       c.store,
-		ElectionID:          config.ElectionID,
-		IngressClass:        class.IngressClass,
-		DefaultIngressClass: class.DefaultClass,
-		RunningConfig:       c.runningConfig,
-	})
+	if !config.DryRun {
+		c.syncStatus = status.NewStatusSyncer(status.Config{
+			DynamicClient:    config.DynamicClient,
+			IngressResource:  config.IngressResource,
+			IngressLister:    c.store,
+			ALBIngressLister: c,
+		})
+	}
+
+	// a dry run never modifies anything, it must not take the lease from the replica applying changes
+	if config.EnableLeaderElection && !config.DryRun {
+		// we need to use the defined ingress class to allow multiple leaders
+		electionID := fmt.Sprintf("%v-%v", config.ElectionID, class.DefaultClass)
+		if class.IngressClass != "" {
+			electionID = fmt.Sprintf("%v-%v", config.ElectionID, class.IngressClass)
+		}
+
+		var err error
+		c.elector, err = newLeaderElector(config.Client, electionID, c.run, c.leadershipLost)
+		if err != nil {
+			glog.Fatal(err.Error())
+		}
+	}
 
 	return c
 }
 
 // ALBController describes a ALB Ingress controller.
 type ALBController struct {
+	// mutex is held for writing while the running configuration is rebuilt from AWS, and for reading
+	// while it is synced
 	mutex sync.RWMutex
 
 	recorder record.EventRecorder
 
+	// ingressQueue holds the IDs of the ALBIngresses to reconcile, syncQueue syncs the other objects
+	ingressQueue workqueue.RateLimitingInterface
+
 	syncQueue *task.Queue
 
 	awsSyncQueue *task.Queue
@@ -154,6 +202,8 @@ type ALBController struct {
 
 	// runningConfig contains the running configuration
 	runningConfig *ingress.Configuration
+	// ingressesLock protects the ALBIngresses of the running configuration, replaced by the ingress workers
+	ingressesLock sync.RWMutex
 
 	isShuttingDown bool
 
@@ -166,10 +216,31 @@ type ALBController struct {
 	tgAttributesController  tg.AttributesController
 	tgTargetsController     tg.TargetsController
 	tagsController          tags.Controller
+	endpointResolver        backend.EndpointResolver
+	// dnsController is nil unless a Route53 hosted zone is configured
+	dnsController dns.Controller
 
 	metricCollector metric.Collector
 
 	cache *cache.Config
+
+	// plan records the changes to AWS resources when running with --dry-run
+	plan *albdryrun.Plan
+
+	// elector runs the reconcile loop in the leader only, nil when leader election is disabled
+	elector *leaderElector
+	// leading is set once the reconcile loop runs in this replica
+	leading int32
+}
+
+// Plan returns the changes recorded during the last sync, or nil when the controller isn't running with --dry-run
+func (c *ALBController) Plan() *albdryrun.Plan {
+	return c.plan
+}
+
+// IngressValidator returns the validating admission webhook of the Ingresses of the controller
+func (c *ALBController) IngressValidator() *webhook.IngressValidator {
+	return webhook.NewIngressValidator(c.store)
 }
 
 // Start starts the controller running in the foreground.
@@ -178,17 +249,8 @@ func (c *ALBController) Start() {
 
 	c.store.Run(c.stopCh)
 
-	if c.syncStatus != nil {
-		go c.syncStatus.Run()
-	}
-
-	go c.syncQueue.Run(time.Second, c.stopCh)
-	go c.awsSyncQueue.Run(time.Second, c.stopCh)
 	go c.healthCheckQueue.Run(time.Second, c.stopCh)
 
-	// force initial sync with kubernetes
-	c.syncQueue.EnqueueTask(task.GetDummyObject("initial-sync"))
-
 	// force initial healthchecks
 	c.healthCheckQueue.EnqueueTask(task.GetDummyObject("initial"))
 
@@ -197,32 +259,26 @@ func (c *ALBController) Start() {
 		return false, nil
 	}, c.stopCh)
 
-	// force initial sync with aws
-	err := c.awsSync(nil)
-	if err != nil {
-		glog.Fatalf(err.Error())
+	if c.elector != nil {
+		// followers keep their caches in sync, so they can take over as soon as they are elected
+		go c.elector.Run()
+	} else {
+		go c.run(c.stopCh)
 	}
 
-	go wait.PollUntil(c.store.GetConfig().AWSSyncPeriod, func() (bool, error) {
-		c.awsSyncQueue.EnqueueTask(task.GetDummyObject("sync aws status"))
-		return false, nil
-	}, c.stopCh)
-
 	for {
 		select {
 		case event := <-c.updateCh.Out():
 			if c.isShuttingDown {
 				break
 			}
+			if !c.isLeading() {
+				// the leader syncs every object once elected
+				continue
+			}
 			if evt, ok := event.(store.Event); ok {
 				glog.V(3).Infof("Event %v received - object %v", evt.Type, evt.Obj)
-				if evt.Type == store.ConfigurationEvent {
-					// TODO: is this necessary? Consider removing this special case
-					c.syncQueue.EnqueueTask(task.GetDummyObject("configmap-change"))
-					continue
-				}
-
-				c.syncQueue.EnqueueSkippableTask(evt.Obj)
+				c.enqueueEvent(evt)
 			} else {
 				glog.Warningf("Unexpected event type received %T", event)
 			}
@@ -232,6 +288,56 @@ func (c *ALBController) Start() {
 	}
 }
 
+// run starts the reconcile loop. With leader election it only runs in the leader.
+func (c *ALBController) run(stopCh <-chan struct{}) {
+	if c.syncStatus != nil {
+		go c.syncStatus.Run(stopCh)
+	}
+
+	go c.syncQueue.Run(time.Second, stopCh)
+	go c.awsSyncQueue.Run(time.Second, stopCh)
+
+	// force initial sync with kubernetes
+	c.syncQueue.EnqueueTask(task.GetDummyObject("initial-sync"))
+
+	// force initial sync with aws
+	err := c.awsSync(nil)
+	if err != nil {
+		glog.Fatal(err.Error())
+	}
+	if c.plan == nil {
+		c.runIngressWorkers(stopCh)
+	}
+
+	go wait.PollUntil(c.store.GetConfig().AWSSyncPeriod, func() (bool, error) {
+		c.awsSyncQueue.EnqueueTask(task.GetDummyObject("sync aws status"))
+		return false, nil
+	}, stopCh)
+
+	// the network load balancers and TargetGroupBindings are synced periodically as well, e.g. to repair
+	// changes made in AWS or to delete the network load balancers replaced once their grace period elapsed
+	go wait.PollUntil(c.store.GetConfig().ResyncPeriod, func() (bool, error) {
+		c.syncQueue.EnqueueSkippableTask(task.GetDummyObject("resync"))
+		return false, nil
+	}, stopCh)
+
+	atomic.StoreInt32(&c.leading, 1)
+}
+
+// isLeading returns true if the reconcile loop runs in this replica
+func (c *ALBController) isLeading() bool {
+	return atomic.LoadInt32(&c.leading) == 1
+}
+
+// leadershipLost exits once another replica may have taken over, a sync in progress can't be
+// interrupted and the AWS resources must only be modified by the leader.
+func (c *ALBController) leadershipLost() {
+	if c.isShuttingDown {
+		return
+	}
+	glog.Fatal("Lost the leader election, exiting")
+}
+
 // Stop gracefully stops the NGINX master process.
 func (c *ALBController) Stop() error {
 	c.isShuttingDown = true
@@ -246,12 +352,22 @@ func (c *ALBController) Stop() error {
 	glog.Infof("Shutting down controller queues")
 	close(c.stopCh)
 	go c.syncQueue.Shutdown()
+	go c.ingressQueue.ShutDown()
 	go c.awsSyncQueue.Shutdown()
 	go c.healthCheckQueue.Shutdown()
 	if c.syncStatus != nil {
 		c.syncStatus.Shutdown()
 	}
 
+	if c.elector != nil {
+		// the lock is kept until the process exits, no sync may start once another replica
+		// can take over
+		c.mutex.Lock()
+		if err := c.elector.release(); err != nil {
+			glog.Errorf("Failed to release the leader lease: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -266,18 +382,36 @@ func (c *ALBController) awsSync(i interface{}) error {
 		return err
 	}
 
-	glog.V(3).Infof("Retrieved tag information on %v load balancers, %v target groups, %v listeners, %v rules, and %v subnets.",
+	glog.V(3).Infof("Retrieved tag information on %v load balancers, %v network load balancers, %v target groups, %v listeners, %v rules, and %v subnets.",
 		len(r.LoadBalancers),
+		len(r.NetworkLoadBalancers),
 		len(r.TargetGroups),
 		len(r.Listeners),
 		len(r.ListenerRules),
 		len(r.Subnets))
 
-	c.runningConfig.Ingresses = albingress.AssembleIngressesFromAWS(&albingress.AssembleIngressesFromAWSOptions{
+	c.assembleFromAWS()
+	if c.dnsController != nil {
+		// the records are listed again, e.g. to recreate the records deleted outside of the controller
+		c.dnsController.Invalidate()
+	}
+
+	// the ALBIngresses, network load balancers and TargetGroupBindings are reconciled again with their desired state
+	c.enqueueAllIngresses()
+	c.syncQueue.EnqueueTask(task.GetDummyObject("aws-sync"))
+	return nil
+}
+
+// assembleFromAWS rebuilds the running configuration from the resources in AWS
+func (c *ALBController) assembleFromAWS() {
+	c.setIngresses(albingress.AssembleIngressesFromAWS(&albingress.AssembleIngressesFromAWSOptions{
+		Recorder: c.recorder,
+		Store:    c.store,
+	}))
+	c.runningConfig.Services = nlbservice.AssembleServicesFromAWS(&nlbservice.AssembleServicesFromAWSOptions{
 		Recorder: c.recorder,
 		Store:    c.store,
 	})
-	return nil
 }
 
 func generateAlbNamePrefix(c string) string {
@@ -5,7 +5,11 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
 
 const (
@@ -16,11 +20,16 @@ const (
 
 	backendProtocol = elbv2.ProtocolEnumHttp
 	healthzPort     = 10254
+	webhookPort     = 9443
 
 	albNamePrefix           = "alb"
 	restrictSchemeNamespace = "default"
 	awsSyncPeriod           = 60 * time.Minute
 	awsAPIMaxRetries        = 10
+	ingressWorkers          = 10
+
+	albReplacementGracePeriod = 5 * time.Minute
+	nlbReplacementGracePeriod = 5 * time.Minute
 )
 
 // Configuration contains all the settings required by an Ingress controller
@@ -28,6 +37,10 @@ type Configuration struct {
 	APIServerHost  string
 	KubeConfigFile string
 	Client         clientset.Interface
+	DynamicClient  dynamic.Interface
+
+	// IngressResource is the Ingress resource consumed by the controller, the most recent one served by the API server
+	IngressResource schema.GroupVersionResource
 
 	HealthCheckPeriod time.Duration
 	ResyncPeriod      time.Duration
@@ -40,10 +53,34 @@ type Configuration struct {
 
 	DefaultBackendProtocol string
 
-	ElectionID string
+	// DefaultShieldAdvancedProtection protects the internet-facing ALBs with AWS Shield Advanced unless
+	// their shield-advanced-protection annotation is false
+	DefaultShieldAdvancedProtection bool
+
+	// ALBReplacementGracePeriod is how long an ALB replaced by a new ALB, e.g. after a scheme change, keeps
+	// serving once its replacement is active
+	ALBReplacementGracePeriod time.Duration
+
+	// NLBReplacementGracePeriod is how long the NLB of a Service replaced by a new NLB keeps serving once its
+	// replacement is active
+	NLBReplacementGracePeriod time.Duration
+
+	// Route53PublicHostedZoneID and Route53PrivateHostedZoneID are the hosted zones of the alias records of
+	// the hosts of internet-facing and internal ALBs, the records aren't managed without hosted zone
+	Route53PublicHostedZoneID  string
+	Route53PrivateHostedZoneID string
+
+	ElectionID           string
+	EnableLeaderElection bool
 
 	HealthzPort int
 
+	// WebhookPort is the port of the validating admission webhook, served with the certificate and key
+	// of WebhookCertFile and WebhookKeyFile. The webhook is disabled without certificate.
+	WebhookPort     int
+	WebhookCertFile string
+	WebhookKeyFile  string
+
 	ClusterName             string
 	ALBNamePrefix           string
 	RestrictScheme          bool
@@ -54,7 +91,16 @@ type Configuration struct {
 
 	EnableProfiling bool
 
+	EnableTargetGroupBinding bool
+
+	EnableIngressClassParams bool
+
+	DryRun bool
+
 	SyncRateLimit float32
+
+	// IngressWorkers is the number of ingresses reconciled in parallel
+	IngressWorkers int
 }
 
 // NewDefault returns a default controller configuration
@@ -63,6 +109,8 @@ func NewDefault() *Configuration {
 		HealthCheckPeriod: healthCheckPeriod,
 		ResyncPeriod:      resyncPeriod,
 
+		IngressResource: k8s.ExtensionsIngressResource,
+
 		// ConfigMapName string
 
 		// Namespace string
@@ -73,6 +121,10 @@ func NewDefault() *Configuration {
 		// ElectionID string
 
 		HealthzPort: healthzPort,
+		WebhookPort: webhookPort,
+
+		ALBReplacementGracePeriod: albReplacementGracePeriod,
+		NLBReplacementGracePeriod: nlbReplacementGracePeriod,
 
 		// ClusterName             string
 		ALBNamePrefix: albNamePrefix,
@@ -85,6 +137,6 @@ func NewDefault() *Configuration {
 		// EnableProfiling bool
 
 		// SyncRateLimit float32
-
+		IngressWorkers: ingressWorkers,
 	}
 }
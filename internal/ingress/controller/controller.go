@@ -18,6 +18,9 @@ package controller
 
 import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/nlbservice"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/targetgroupbinding"
 )
 
 func (c *ALBController) syncIngress(interface{}) error {
@@ -27,15 +30,68 @@ func (c *ALBController) syncIngress(interface{}) error {
 		return nil
 	}
 
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 
 	c.metricCollector.IncReconcileCount()
 
+	if c.plan != nil {
+		c.plan.Begin()
+		defer func() {
+			c.plan.Complete()
+			// nothing was applied, the next plan starts again from the resources in AWS
+			c.assembleFromAWS()
+		}()
+
+		// a dry run plans the changes of every ingress at once, instead of reconciling them in the ingress workers
+		c.syncIngresses()
+	}
+
+	// Services of type LoadBalancer asking for a network load balancer follow the same steps as the ingresses.
+	newServices := nlbservice.NewNLBServicesFromServices(&nlbservice.NewNLBServicesFromServicesOptions{
+		Recorder:    c.recorder,
+		Store:       c.store,
+		NLBServices: c.runningConfig.Services,
+		Metric:      c.metricCollector,
+	})
+	removedServices := c.runningConfig.Services.RemovedServices(newServices)
+	c.runningConfig.Services = newServices
+
+	removedServices.Reconcile(c.metricCollector, c.tgTargetsController, c.tagsController)
+	for _, s := range removedServices {
+		c.metricCollector.RemoveMetrics(s.ID())
+	}
+	c.runningConfig.Services.Reconcile(c.metricCollector, c.tgTargetsController, c.tagsController)
+
+	// TargetGroupBindings only register the endpoints of Services in target groups managed outside of the controller.
+	newBindings := targetgroupbinding.NewTargetGroupBindingsFromStore(&targetgroupbinding.NewTargetGroupBindingsFromStoreOptions{
+		Recorder:            c.recorder,
+		Store:               c.store,
+		TargetGroupBindings: c.runningConfig.TargetGroupBindings,
+		Metric:              c.metricCollector,
+	})
+	removedBindings := c.runningConfig.TargetGroupBindings.RemovedBindings(newBindings)
+	c.runningConfig.TargetGroupBindings = newBindings
+
+	removedBindings.Reconcile(c.metricCollector, c.store.GetConfig(), albelbv2.ELBV2svc, c.tgTargetsController, c.endpointResolver)
+	for _, b := range removedBindings {
+		if _, n := newBindings.FindByID(b.ID()); n == nil {
+			c.metricCollector.RemoveMetrics(b.ID())
+		}
+	}
+	c.runningConfig.TargetGroupBindings.Reconcile(c.metricCollector, c.store.GetConfig(), albelbv2.ELBV2svc, c.tgTargetsController, c.endpointResolver)
+
+	// TODO check for per-namespace errors and increment prometheus metric
+
+	return nil
+}
+
+// syncIngresses reconciles every ALBIngress
+func (c *ALBController) syncIngresses() {
 	newIngresses := albingress.NewALBIngressesFromIngresses(&albingress.NewALBIngressesFromIngressesOptions{
 		Recorder:     c.recorder,
 		Store:        c.store,
-		ALBIngresses: c.runningConfig.Ingresses,
+		ALBIngresses: c.ingresses(),
 		Metric:       c.metricCollector,
 	})
 
@@ -44,19 +100,15 @@ func (c *ALBController) syncIngress(interface{}) error {
 
 	// Sync the state, resulting in creation, modify, delete, or no action, for every ALBIngress
 	// instance known to the ALBIngress controller.
-	removedIngresses := c.runningConfig.Ingresses.RemovedIngresses(newIngresses)
+	removedIngresses := c.ingresses().RemovedIngresses(newIngresses)
 
 	// Update the list of ALBIngresses known to the ALBIngress controller to the newly generated list.
-	c.runningConfig.Ingresses = newIngresses
+	c.setIngresses(newIngresses)
 
 	// Reconcile the states
-	removedIngresses.Reconcile(c.metricCollector, c.sgAssociationController, c.lbAttributesController, c.tgAttributesController, c.tgTargetsController, c.tagsController)
+	removedIngresses.Reconcile(c.metricCollector, c.sgAssociationController, c.lbAttributesController, c.tgAttributesController, c.tgTargetsController, c.tagsController, c.dnsController)
 	for _, i := range removedIngresses {
 		c.metricCollector.RemoveMetrics(i.ID())
 	}
-	c.runningConfig.Ingresses.Reconcile(c.metricCollector, c.sgAssociationController, c.lbAttributesController, c.tgAttributesController, c.tgTargetsController, c.tagsController)
-
-	// TODO check for per-namespace errors and increment prometheus metric
-
-	return nil
+	newIngresses.Reconcile(c.metricCollector, c.sgAssociationController, c.lbAttributesController, c.tgAttributesController, c.tgTargetsController, c.tagsController, c.dnsController)
 }
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/golang/glog"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+)
+
+// ServeIngresses lists the state of every ALBIngress known to the controller as JSON
+func (c *ALBController) ServeIngresses(w http.ResponseWriter, r *http.Request) {
+	ingresses := c.ingresses()
+	infos := make([]albingress.DebugInfo, 0, len(ingresses))
+	for _, ing := range ingresses {
+		infos = append(infos, ing.DebugInfo())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+
+	b, err := json.MarshalIndent(infos, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// ServeReconcile resets the backoff of the ALBIngress serving the ingress of the namespace/name of
+// the ingress query parameter and triggers a sync, so the ingress is reconciled immediately
+func (c *ALBController) ServeReconcile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	key := r.URL.Query().Get("ingress")
+	if key == "" {
+		http.Error(w, "the ingress parameter must be set to the namespace/name of an ingress", http.StatusBadRequest)
+		return
+	}
+
+	_, ing := c.ingresses().FindByIngressID(key)
+	if ing == nil {
+		http.Error(w, fmt.Sprintf("ingress %s is not managed by the controller", key), http.StatusNotFound)
+		return
+	}
+
+	glog.Infof("Reconcile of ingress %s requested", key)
+	ing.Retry()
+	c.enqueueIngress(ing.ID())
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprintf(w, "%s will be reconciled shortly\n", ing.ID())
+}
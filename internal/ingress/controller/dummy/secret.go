@@ -0,0 +1,20 @@
+package dummy
+
+import (
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewSecret creates a dummy secret holding OIDC client credentials
+func NewSecret() *api.Secret {
+	return &api.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "oidc-secret",
+			Namespace: api.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			"clientId":     []byte("client-id"),
+			"clientSecret": []byte("client-secret"),
+		},
+	}
+}
@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/task"
+)
+
+// runIngressWorkers starts the workers reconciling the ALBIngresses of the ingress queue. The
+// queue never hands the same ALBIngress to two workers at once.
+func (c *ALBController) runIngressWorkers(stopCh <-chan struct{}) {
+	for i := 0; i < c.store.GetConfig().IngressWorkers; i++ {
+		go wait.Until(c.ingressWorker, time.Second, stopCh)
+	}
+}
+
+func (c *ALBController) ingressWorker() {
+	for c.processNextIngress() {
+	}
+}
+
+// processNextIngress reconciles the next ALBIngress of the ingress queue, it returns false once the
+// queue is shut down
+func (c *ALBController) processNextIngress() bool {
+	key, quit := c.ingressQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.ingressQueue.Done(key)
+
+	id := key.(string)
+	ing, err := c.syncALBIngress(id)
+	if err == nil {
+		c.ingressQueue.Forget(key)
+		return true
+	}
+
+	if ing != nil && ing.RetryIn() > 0 {
+		// the ALBIngress backs off its failures, it is retried once its backoff expired
+		glog.Warningf("requeuing %v in %v, err %v", id, ing.RetryIn(), err)
+		c.ingressQueue.Forget(key)
+		c.ingressQueue.AddAfter(key, ing.RetryIn())
+		return true
+	}
+	glog.Warningf("requeuing %v, err %v", id, err)
+	c.ingressQueue.AddRateLimited(key)
+	return true
+}
+
+// syncALBIngress assembles the ALBIngress with the id from the ingresses of the store and reconciles
+// it. An ALBIngress without ingresses left is removed, along with its AWS resources.
+func (c *ALBController) syncALBIngress(id string) (*albingress.ALBIngress, error) {
+	if c.ingressQueue.ShuttingDown() {
+		return nil, nil
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	c.metricCollector.IncReconcileCount()
+
+	_, existingIngress := c.ingresses().FindByID(id)
+	ing := albingress.NewALBIngressFromStore(&albingress.NewALBIngressFromStoreOptions{
+		ID:              id,
+		ExistingIngress: existingIngress,
+		Recorder:        c.recorder,
+		Store:           c.store,
+		Metric:          c.metricCollector,
+	})
+	c.setIngress(id, ing)
+
+	if ing != nil {
+		return ing, ing.ReconcileWithMetrics(c.metricCollector, c.reconcileOptions())
+	}
+
+	if existingIngress == nil {
+		return nil, nil
+	}
+	defer c.metricCollector.RemoveMetrics(id)
+	removed := albingress.ALBIngresses{existingIngress}.RemovedIngresses(nil)
+	if len(removed) == 0 {
+		// the load balancer was already deleted
+		return nil, nil
+	}
+	return existingIngress, existingIngress.ReconcileWithMetrics(c.metricCollector, c.reconcileOptions())
+}
+
+func (c *ALBController) reconcileOptions() *albingress.ReconcileOptions {
+	return &albingress.ReconcileOptions{
+		Store:                   c.store,
+		SgAssociationController: c.sgAssociationController,
+		LbAttributesController:  c.lbAttributesController,
+		TgAttributesController:  c.tgAttributesController,
+		TgTargetsController:     c.tgTargetsController,
+		TagsController:          c.tagsController,
+		DNSController:           c.dnsController,
+	}
+}
+
+// ingresses returns the ALBIngresses known to the controller
+func (c *ALBController) ingresses() albingress.ALBIngresses {
+	c.ingressesLock.RLock()
+	defer c.ingressesLock.RUnlock()
+	return c.runningConfig.Ingresses
+}
+
+// ALBIngresses returns the ALBIngresses known to the controller, for the status sync
+func (c *ALBController) ALBIngresses() albingress.ALBIngresses {
+	return c.ingresses()
+}
+
+// setIngress replaces the ALBIngress with the id in the running configuration, removing it when
+// ing is nil. The list is copied, so the list returned by ingresses is never modified.
+func (c *ALBController) setIngress(id string, ing *albingress.ALBIngress) {
+	c.ingressesLock.Lock()
+	defer c.ingressesLock.Unlock()
+
+	var ingresses albingress.ALBIngresses
+	for _, i := range c.runningConfig.Ingresses {
+		if i.ID() != id {
+			ingresses = append(ingresses, i)
+		}
+	}
+	if ing != nil {
+		ingresses = append(ingresses, ing)
+	}
+	c.runningConfig.Ingresses = ingresses
+
+	// Update the prometheus gauge
+	c.metricCollector.SetManagedIngresses(ingresses.IngressesByNamespace())
+}
+
+// setIngresses replaces the ALBIngresses of the running configuration
+func (c *ALBController) setIngresses(ingresses albingress.ALBIngresses) {
+	c.ingressesLock.Lock()
+	defer c.ingressesLock.Unlock()
+	c.runningConfig.Ingresses = ingresses
+}
+
+// enqueueIngress queues the ALBIngress with the id. A dry run syncs every ingress instead.
+func (c *ALBController) enqueueIngress(id string) {
+	if c.plan != nil {
+		c.syncQueue.EnqueueTask(task.GetDummyObject("ingress-" + id))
+		return
+	}
+	c.ingressQueue.Add(id)
+}
+
+// enqueueAllIngresses queues every ALBIngress known to the controller or serving the ingresses of the
+// store. A dry run syncs every ingress along with the other objects already.
+func (c *ALBController) enqueueAllIngresses() {
+	if c.plan != nil {
+		return
+	}
+	for _, ing := range c.ingresses() {
+		c.ingressQueue.Add(ing.ID())
+	}
+	for _, id := range albingress.IngressIDs(c.store) {
+		c.ingressQueue.Add(id)
+	}
+}
+
+// enqueueEvent queues the ALBIngresses affected by an event of the store. The other events sync the
+// network load balancers and TargetGroupBindings.
+func (c *ALBController) enqueueEvent(evt store.Event) {
+	if evt.Type == store.ConfigurationEvent {
+		c.enqueueAllIngresses()
+		c.syncQueue.EnqueueTask(task.GetDummyObject("configmap-change"))
+		return
+	}
+	if c.plan != nil {
+		c.syncQueue.EnqueueSkippableTask(evt.Obj)
+		return
+	}
+
+	obj := evt.Obj
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	switch o := obj.(type) {
+	case *extensions.Ingress:
+		// the ingress may have left the ALBIngress serving it, e.g. when its group changed
+		if _, existingIngress := c.ingresses().FindByIngressID(k8s.MetaNamespaceKey(o)); existingIngress != nil {
+			c.ingressQueue.Add(existingIngress.ID())
+		}
+		if class.IsValid(o) {
+			c.ingressQueue.Add(albingress.IngressID(c.store, o))
+		}
+		return
+	case *corev1.Service:
+		c.enqueueServiceIngresses(k8s.MetaNamespaceKey(o))
+	case *corev1.Endpoints:
+		c.enqueueServiceIngresses(k8s.MetaNamespaceKey(o))
+	case *corev1.Secret:
+		// only the authenticate actions of the ingresses depend on Secrets
+		for _, id := range albingress.SecretIngressIDs(c.store, k8s.MetaNamespaceKey(o)) {
+			c.ingressQueue.Add(id)
+		}
+		return
+	}
+	c.syncQueue.EnqueueSkippableTask(evt.Obj)
+}
+
+// enqueueServiceIngresses queues the ALBIngresses routing to the Service matching key
+func (c *ALBController) enqueueServiceIngresses(key string) {
+	for _, id := range albingress.ServiceIngressIDs(c.store, key) {
+		c.ingressQueue.Add(id)
+	}
+}
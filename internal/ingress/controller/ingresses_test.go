@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/task"
+)
+
+func newTestController(ingresses ...*extensions.Ingress) *ALBController {
+	s := store.NewDummy()
+	s.ListIngressesFunc = func() []*extensions.Ingress { return ingresses }
+	return &ALBController{
+		store:           s,
+		ingressQueue:    workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		syncQueue:       task.NewTaskQueue(func(interface{}) error { return nil }),
+		runningConfig:   new(ingress.Configuration),
+		metricCollector: &metric.DummyCollector{},
+	}
+}
+
+// queued returns the IDs of the ingress queue
+func queued(c *ALBController) []string {
+	var ids []string
+	for c.ingressQueue.Len() > 0 {
+		key, _ := c.ingressQueue.Get()
+		c.ingressQueue.Done(key)
+		ids = append(ids, key.(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestEnqueueEvent(t *testing.T) {
+	first := dummy.NewIngress()
+	first.Name = "first"
+	second := dummy.NewIngress()
+	second.Name = "second"
+	second.Spec.Rules = nil
+	c := newTestController(first, second)
+
+	for _, tc := range []struct {
+		name     string
+		event    store.Event
+		expected []string
+	}{
+		{
+			name:     "ingress update",
+			event:    store.Event{Type: store.UpdateEvent, Obj: second},
+			expected: []string{"default/second"},
+		},
+		{
+			name:     "deleted ingress",
+			event:    store.Event{Type: store.DeleteEvent, Obj: cache.DeletedFinalStateUnknown{Key: "default/first", Obj: first}},
+			expected: []string{"default/first"},
+		},
+		{
+			name:     "endpoints of a rule",
+			event:    store.Event{Type: store.UpdateEvent, Obj: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "2service"}}},
+			expected: []string{"default/first"},
+		},
+		{
+			name:     "unused service",
+			event:    store.Event{Type: store.CreateEvent, Obj: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unused"}}},
+			expected: nil,
+		},
+		{
+			name:     "configuration",
+			event:    store.Event{Type: store.ConfigurationEvent, Obj: &corev1.ConfigMap{}},
+			expected: []string{"default/first", "default/second"},
+		},
+	} {
+		c.enqueueEvent(tc.event)
+		if ids := queued(c); !equalIDs(ids, tc.expected) {
+			t.Errorf("%s: expected %v to be queued, got %v", tc.name, tc.expected, ids)
+		}
+	}
+}
+
+func TestEnqueueEventIngressGroupChange(t *testing.T) {
+	ing := dummy.NewIngress()
+	c := newTestController(ing)
+	c.setIngress("default/ingress1", albingress.NewALBIngress(&albingress.NewALBIngressOptions{Ingress: ing}))
+
+	// the ingress joined an ingress group, the ALBIngress it left removes its rules
+	s := c.store.(*store.Dummy)
+	s.GetIngressAnnotationsResponse = annotations.NewIngressDummy()
+	s.GetIngressAnnotationsResponse.Group = &group.Config{Name: "shared"}
+	c.enqueueEvent(store.Event{Type: store.UpdateEvent, Obj: ing})
+	if ids := queued(c); !equalIDs(ids, []string{"default/ingress1", "group:shared"}) {
+		t.Errorf("Expected the previous and the new ALBIngress to be queued, got %v", ids)
+	}
+
+	c.setIngress("default/ingress1", nil)
+	if len(c.ingresses()) != 0 {
+		t.Errorf("Expected the ALBIngress to be removed, got %d ALBIngresses", len(c.ingresses()))
+	}
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// leaderElector runs the work loop of the controller in a single replica at a time. The lease is
+// held in a ConfigMap named after the election id and the ingress class, in the namespace of the pod.
+type leaderElector struct {
+	elector *leaderelection.LeaderElector
+	lock    *releasableLock
+	client  clientset.Interface
+	meta    metav1.ObjectMeta
+}
+
+// newLeaderElector returns a leaderElector calling run once this replica is elected leader and
+// lost once it stopped leading.
+func newLeaderElector(client clientset.Interface, electionID string, run func(stopCh <-chan struct{}), lost func()) (*leaderElector, error) {
+	pod, err := k8s.GetPodDetails(client)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error obtaining pod information: %v", err)
+	}
+
+	broadcaster := record.NewBroadcaster()
+	hostname, _ := os.Hostname()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{
+		Component: "ingress-leader-elector",
+		Host:      hostname,
+	})
+
+	meta := metav1.ObjectMeta{Namespace: pod.Namespace, Name: electionID}
+	lock := &releasableLock{Interface: &resourcelock.ConfigMapLock{
+		ConfigMapMeta: meta,
+		Client:        client.CoreV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      pod.Name,
+			EventRecorder: recorder,
+		},
+	}}
+
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(stop <-chan struct{}) {
+				glog.Infof("I am the new leader")
+				run(stop)
+			},
+			OnStoppedLeading: func() {
+				glog.Infof("I am not leader anymore")
+				lost()
+			},
+			OnNewLeader: func(identity string) {
+				glog.Infof("new leader elected: %v", identity)
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error starting leader election: %v", err)
+	}
+
+	return &leaderElector{elector: le, lock: lock, client: client, meta: meta}, nil
+}
+
+// Run waits to be elected leader, then keeps renewing the lease. It returns once the lease is lost.
+func (l *leaderElector) Run() {
+	l.elector.Run()
+}
+
+// IsLeader returns true if this replica holds the lease
+func (l *leaderElector) IsLeader() bool {
+	return l.elector.IsLeader()
+}
+
+// release stops renewing the lease and gives it up if this replica holds it. The other replicas
+// wait for a lease they observed to expire, deleting the ConfigMap lets the first of them to retry
+// take over right away.
+func (l *leaderElector) release() error {
+	l.lock.release()
+	if !l.IsLeader() {
+		return nil
+	}
+
+	glog.Infof("Releasing leader lease %v", l.lock.Describe())
+	return l.client.CoreV1().ConfigMaps(l.meta.Namespace).Delete(l.meta.Name, &metav1.DeleteOptions{})
+}
+
+// releasableLock fails every operation once released, so the lease can't be renewed or acquired again.
+type releasableLock struct {
+	resourcelock.Interface
+	released int32
+}
+
+func (l *releasableLock) release() {
+	atomic.StoreInt32(&l.released, 1)
+}
+
+func (l *releasableLock) check() error {
+	if atomic.LoadInt32(&l.released) == 1 {
+		return fmt.Errorf("lease %v was released", l.Describe())
+	}
+	return nil
+}
+
+// Get returns the election record, unless the lock was released
+func (l *releasableLock) Get() (*resourcelock.LeaderElectionRecord, error) {
+	if err := l.check(); err != nil {
+		return nil, err
+	}
+	return l.Interface.Get()
+}
+
+// Create creates the election record, unless the lock was released
+func (l *releasableLock) Create(ler resourcelock.LeaderElectionRecord) error {
+	if err := l.check(); err != nil {
+		return err
+	}
+	return l.Interface.Create(ler)
+}
+
+// Update updates the election record, unless the lock was released
+func (l *releasableLock) Update(ler resourcelock.LeaderElectionRecord) error {
+	if err := l.check(); err != nil {
+		return err
+	}
+	return l.Interface.Update(ler)
+}
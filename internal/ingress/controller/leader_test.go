@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeaderElector(t *testing.T) {
+	os.Setenv("POD_NAME", "alb-ingress-controller-1")
+	os.Setenv("POD_NAMESPACE", "kube-system")
+	defer os.Unsetenv("POD_NAME")
+	defer os.Unsetenv("POD_NAMESPACE")
+
+	client := testclient.NewSimpleClientset(&apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "alb-ingress-controller-1", Namespace: "kube-system"},
+	})
+
+	started := make(chan struct{})
+	le, err := newLeaderElector(client, "ingress-controller-leader-alb", func(<-chan struct{}) {
+		close(started)
+	}, func() {})
+	assert.NoError(t, err)
+
+	go le.Run()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the only replica to be elected leader")
+	}
+	assert.True(t, le.IsLeader())
+
+	cm, err := client.CoreV1().ConfigMaps("kube-system").Get("ingress-controller-leader-alb", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, cm.Annotations["control-plane.alpha.kubernetes.io/leader"], "alb-ingress-controller-1")
+
+	// releasing deletes the lock, so another replica doesn't wait for the lease to expire
+	assert.NoError(t, le.release())
+	_, err = client.CoreV1().ConfigMaps("kube-system").Get("ingress-controller-leader-alb", metav1.GetOptions{})
+	assert.Error(t, err)
+
+	// the lease is not renewed anymore
+	_, err = le.lock.Get()
+	assert.Error(t, err)
+	err = wait.Poll(100*time.Millisecond, 3*time.Second, func() (bool, error) {
+		_, err := client.CoreV1().ConfigMaps("kube-system").Get("ingress-controller-leader-alb", metav1.GetOptions{})
+		return err == nil, nil
+	})
+	assert.Error(t, err, "expected the lock to stay deleted")
+}
+
+func TestLeaderElectorWithoutPod(t *testing.T) {
+	os.Unsetenv("POD_NAME")
+	_, err := newLeaderElector(testclient.NewSimpleClientset(), "ingress-controller-leader-alb", func(<-chan struct{}) {}, func() {})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 )
@@ -12,11 +13,16 @@ type Dummy struct {
 	cfg                           *config.Configuration
 	GetIngressAnnotationsResponse *annotations.Ingress
 	GetServiceAnnotationsResponse *annotations.Service
+	IngressClassParams            *v1alpha1.IngressClassParams
 
-	GetServiceFunc            func(string) (*corev1.Service, error)
-	ListNodesFunc             func() []*corev1.Node
-	GetNodeInstanceIDFunc     func(*corev1.Node) (string, error)
-	GetClusterInstanceIDsFunc func() ([]string, error)
+	GetSecretFunc               func(string) (*corev1.Secret, error)
+	GetServiceFunc              func(string) (*corev1.Service, error)
+	ListNodesFunc               func() []*corev1.Node
+	ListIngressesFunc           func() []*extensions.Ingress
+	ListServicesFunc            func() []*corev1.Service
+	ListTargetGroupBindingsFunc func() []*v1alpha1.TargetGroupBinding
+	GetNodeInstanceIDFunc       func(*corev1.Node) (string, error)
+	GetClusterInstanceIDsFunc   func() ([]string, error)
 
 	GetServiceEndpointsFunc func(string) (*corev1.Endpoints, error)
 }
@@ -26,6 +32,11 @@ func (d Dummy) GetConfigMap(key string) (*corev1.ConfigMap, error) {
 	return nil, nil
 }
 
+// GetSecret ...
+func (d Dummy) GetSecret(key string) (*corev1.Secret, error) {
+	return d.GetSecretFunc(key)
+}
+
 // GetService ...
 func (d Dummy) GetService(key string) (*corev1.Service, error) {
 	return d.GetServiceFunc(key)
@@ -53,7 +64,22 @@ func (d Dummy) ListNodes() []*corev1.Node {
 
 // ListIngresses ...
 func (d Dummy) ListIngresses() []*extensions.Ingress {
-	return nil
+	return d.ListIngressesFunc()
+}
+
+// ListServices ...
+func (d Dummy) ListServices() []*corev1.Service {
+	return d.ListServicesFunc()
+}
+
+// ListTargetGroupBindings ...
+func (d Dummy) ListTargetGroupBindings() []*v1alpha1.TargetGroupBinding {
+	return d.ListTargetGroupBindingsFunc()
+}
+
+// GetIngressClassParams ...
+func (d Dummy) GetIngressClassParams(className string) *v1alpha1.IngressClassParams {
+	return d.IngressClassParams
 }
 
 // GetIngressAnnotations ...
@@ -96,8 +122,12 @@ func (d *Dummy) GetClusterInstanceIDs() ([]string, error) {
 
 func NewDummy() *Dummy {
 	return &Dummy{
+		GetSecretFunc:                 func(_ string) (*corev1.Secret, error) { return dummy.NewSecret(), nil },
 		GetServiceFunc:                func(_ string) (*corev1.Service, error) { return dummy.NewService(), nil },
 		ListNodesFunc:                 func() []*corev1.Node { return nil },
+		ListIngressesFunc:             func() []*extensions.Ingress { return nil },
+		ListServicesFunc:              func() []*corev1.Service { return nil },
+		ListTargetGroupBindingsFunc:   func() []*v1alpha1.TargetGroupBinding { return nil },
 		GetNodeInstanceIDFunc:         func(*corev1.Node) (string, error) { return "", nil },
 		GetClusterInstanceIDsFunc:     func() ([]string, error) { return nil, nil },
 		GetServiceEndpointsFunc:       func(string) (*corev1.Endpoints, error) { return nil, nil },
@@ -17,8 +17,22 @@ limitations under the License.
 package store
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
 	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 )
 
 // IngressLister makes a Store that lists Ingress.
@@ -37,3 +51,169 @@ func (il IngressLister) ByKey(key string) (*extensions.Ingress, error) {
 	}
 	return i.(*extensions.Ingress), nil
 }
+
+// newIngressListWatch lists and watches the Ingresses of resource with the dynamic client, so the
+// Ingresses of API versions newer than the vendored API types can be converted.
+func newIngressListWatch(client dynamic.Interface, resource schema.GroupVersionResource, namespace string) *cache.ListWatch {
+	ingClient := client.Resource(resource).Namespace(namespace)
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (k8sruntime.Object, error) {
+			ul, err := ingClient.List(options)
+			if err != nil {
+				return nil, err
+			}
+			list := &extensions.IngressList{ListMeta: metav1.ListMeta{
+				ResourceVersion: ul.GetResourceVersion(),
+				Continue:        ul.GetContinue(),
+			}}
+			for i := range ul.Items {
+				ing, err := IngressFromUnstructured(&ul.Items[i])
+				if err != nil {
+					return nil, err
+				}
+				list.Items = append(list.Items, *ing)
+			}
+			return list, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			w, err := ingClient.Watch(options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(e watch.Event) (watch.Event, bool) {
+				u, ok := e.Object.(*unstructured.Unstructured)
+				if !ok || e.Type == watch.Error {
+					return e, true
+				}
+				ing, err := IngressFromUnstructured(u)
+				if err != nil {
+					return watch.Event{Type: watch.Error, Object: &metav1.Status{
+						Status:  metav1.StatusFailure,
+						Message: err.Error(),
+					}}, true
+				}
+				e.Object = ing
+				return e, true
+			}), nil
+		},
+	}
+}
+
+// IngressFromUnstructured converts an Ingress returned by the dynamic client, or sent to the
+// admission webhook, into the vendored extensions/v1beta1 types. The fields missing from these
+// types are kept in annotations of the converted Ingress: spec.ingressClassName in the ingress.class
+// annotation, where it takes precedence over the annotation, the pathType of the paths in the
+// path-types annotation, and resource backends, which can't be served, are reported in the
+// unsupported annotation.
+func IngressFromUnstructured(u *unstructured.Unstructured) (*extensions.Ingress, error) {
+	obj := u.DeepCopy().Object
+	spec, _, err := unstructured.NestedMap(obj, "spec")
+	if err != nil {
+		return nil, err
+	}
+	c := &ingressConverter{}
+	if spec != nil {
+		c.convertSpec(spec)
+		obj["spec"] = spec
+	}
+
+	ing := &extensions.Ingress{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj, ing); err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]string)
+	for k, v := range ing.Annotations {
+		if k != k8s.PathTypesKey && k != k8s.UnsupportedKey {
+			annotations[k] = v
+		}
+	}
+	if className, ok := spec["ingressClassName"].(string); ok && className != "" {
+		annotations[class.IngressKey] = className
+	}
+	if c.hasPathTypes {
+		pathTypes, err := json.Marshal(c.pathTypes)
+		if err != nil {
+			return nil, err
+		}
+		annotations[k8s.PathTypesKey] = string(pathTypes)
+	}
+	if len(c.unsupported) != 0 {
+		annotations[k8s.UnsupportedKey] = strings.Join(c.unsupported, ", ")
+	}
+	ing.Annotations = annotations
+	return ing, nil
+}
+
+// ingressConverter rewrites the spec of networking.k8s.io Ingresses into the extensions/v1beta1 format
+type ingressConverter struct {
+	pathTypes    [][]string
+	hasPathTypes bool
+	unsupported  []string
+}
+
+func (c *ingressConverter) convertSpec(spec map[string]interface{}) {
+	if backend, ok := spec["defaultBackend"].(map[string]interface{}); ok {
+		spec["backend"] = backend
+		delete(spec, "defaultBackend")
+	}
+	if backend, ok := spec["backend"].(map[string]interface{}); ok {
+		c.convertBackend(backend)
+	}
+
+	rules, _ := spec["rules"].([]interface{})
+	c.pathTypes = make([][]string, len(rules))
+	for ri, r := range rules {
+		rule, _ := r.(map[string]interface{})
+		paths, _, _ := unstructured.NestedSlice(rule, "http", "paths")
+		c.pathTypes[ri] = make([]string, len(paths))
+		for pi, p := range paths {
+			path, _ := p.(map[string]interface{})
+			if pathType, ok := path["pathType"].(string); ok {
+				c.pathTypes[ri][pi] = pathType
+				c.hasPathTypes = true
+			}
+			if backend, ok := path["backend"].(map[string]interface{}); ok {
+				c.convertBackend(backend)
+			}
+		}
+		if len(paths) != 0 {
+			unstructured.SetNestedSlice(rule, paths, "http", "paths")
+		}
+	}
+}
+
+// convertBackend rewrites the service of a networking.k8s.io/v1 backend into serviceName and servicePort
+func (c *ingressConverter) convertBackend(backend map[string]interface{}) {
+	if service, ok := backend["service"].(map[string]interface{}); ok {
+		backend["serviceName"] = service["name"]
+		if number, ok, _ := unstructured.NestedFieldNoCopy(service, "port", "number"); ok {
+			backend["servicePort"] = number
+		} else if name, ok, _ := unstructured.NestedFieldNoCopy(service, "port", "name"); ok {
+			backend["servicePort"] = name
+		}
+		delete(backend, "service")
+	}
+	if resource, ok := backend["resource"].(map[string]interface{}); ok {
+		c.unsupported = append(c.unsupported, fmt.Sprintf("resource backend %v %v is not supported, only Service backends are", resource["kind"], resource["name"]))
+		delete(backend, "resource")
+	}
+}
+
+// isReconcileStatusUpdate returns true when cur is an update of old which only changed the reconcile
+// status written by the controller. Such updates don't trigger a sync, which would write the status
+// again.
+func isReconcileStatusUpdate(old, cur *extensions.Ingress) bool {
+	if old.ResourceVersion == cur.ResourceVersion || old.Annotations[k8s.ReconcileStatusKey] == cur.Annotations[k8s.ReconcileStatusKey] {
+		return false
+	}
+	old, cur = old.DeepCopy(), cur.DeepCopy()
+	for _, ing := range []*extensions.Ingress{old, cur} {
+		ing.ResourceVersion = ""
+		delete(ing.Annotations, k8s.ReconcileStatusKey)
+		if len(ing.Annotations) == 0 {
+			ing.Annotations = nil
+		}
+	}
+	return reflect.DeepEqual(old, cur)
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+)
+
+func TestIngressFromUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "extensions/v1beta1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":        "foo",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{class.IngressKey: "alb"},
+		},
+		"spec": map[string]interface{}{
+			"ingressClassName": "internal",
+			"backend": map[string]interface{}{
+				"serviceName": "svc",
+				"servicePort": int64(80),
+			},
+		},
+	}}
+
+	ing, err := IngressFromUnstructured(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ing.Name != "foo" || ing.Spec.Backend == nil || ing.Spec.Backend.ServiceName != "svc" {
+		t.Errorf("unexpected ingress %+v", ing)
+	}
+	if c := ing.Annotations[class.IngressKey]; c != "internal" {
+		t.Errorf("expected the internal class but %v was returned", c)
+	}
+}
+
+func TestNetworkingV1IngressFromUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":        "foo",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{k8s.UnsupportedKey: "set by the user"},
+		},
+		"spec": map[string]interface{}{
+			"defaultBackend": map[string]interface{}{
+				"service": map[string]interface{}{
+					"name": "default",
+					"port": map[string]interface{}{"name": "http"},
+				},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"host": "example.com",
+					"http": map[string]interface{}{
+						"paths": []interface{}{
+							map[string]interface{}{
+								"path":     "/api",
+								"pathType": "Prefix",
+								"backend": map[string]interface{}{
+									"service": map[string]interface{}{
+										"name": "api",
+										"port": map[string]interface{}{"number": int64(8080)},
+									},
+								},
+							},
+							map[string]interface{}{
+								"path":     "/static",
+								"pathType": "Exact",
+								"backend": map[string]interface{}{
+									"resource": map[string]interface{}{
+										"apiGroup": "k8s.example.com",
+										"kind":     "StorageBucket",
+										"name":     "static-assets",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	ing, err := IngressFromUnstructured(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ing.Spec.Backend == nil || ing.Spec.Backend.ServiceName != "default" || ing.Spec.Backend.ServicePort != intstr.FromString("http") {
+		t.Errorf("unexpected default backend %+v", ing.Spec.Backend)
+	}
+	backend := ing.Spec.Rules[0].HTTP.Paths[0].Backend
+	if backend.ServiceName != "api" || backend.ServicePort != intstr.FromInt(8080) {
+		t.Errorf("unexpected backend %+v", backend)
+	}
+	if p := k8s.PathType(ing, 0, 0); p != k8s.PathTypePrefix {
+		t.Errorf("expected the Prefix pathType but %v was returned", p)
+	}
+	if p := k8s.PathType(ing, 0, 1); p != k8s.PathTypeExact {
+		t.Errorf("expected the Exact pathType but %v was returned", p)
+	}
+	expected := "resource backend StorageBucket static-assets is not supported, only Service backends are"
+	if reason := ing.Annotations[k8s.UnsupportedKey]; reason != expected {
+		t.Errorf("expected %q but %q was returned", expected, reason)
+	}
+	if _, ok := u.Object["spec"].(map[string]interface{})["defaultBackend"]; !ok {
+		t.Errorf("the unstructured Ingress was modified")
+	}
+}
+
+func TestIngressFromUnstructuredWithoutPathTypes(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "extensions/v1beta1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":        "foo",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{k8s.PathTypesKey: `[["Exact"]]`},
+		},
+	}}
+
+	ing, err := IngressFromUnstructured(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ing.Annotations, map[string]string{}) {
+		t.Errorf("expected the path-types annotation to be removed, got %v", ing.Annotations)
+	}
+}
+
+func TestIsReconcileStatusUpdate(t *testing.T) {
+	ing := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "foo",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{class.IngressKey: "alb"},
+		},
+	}
+
+	status := ing.DeepCopy()
+	status.ResourceVersion = "2"
+	status.Annotations[k8s.ReconcileStatusKey] = `{"loadBalancerARN":"arn"}`
+
+	changed := status.DeepCopy()
+	changed.ResourceVersion = "3"
+	changed.Annotations[k8s.ReconcileStatusKey] = `{"loadBalancerARN":"arn2"}`
+	changed.Annotations[class.IngressKey] = "internal"
+
+	for _, tc := range []struct {
+		name     string
+		old, cur *extensions.Ingress
+		expected bool
+	}{
+		{"resync", ing, ing, false},
+		{"status written", ing, status, true},
+		{"status and class changed", status, changed, false},
+	} {
+		if actual := isReconcileStatusUpdate(tc.old, tc.cur); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+)
+
+// ingressClassResources are the resources of the IngressClasses, used with the dynamic client, preferred first
+var ingressClassResources = []schema.GroupVersionResource{
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingressclasses"},
+}
+
+// IngressClassLister makes a Store that lists IngressClasses.
+type IngressClassLister struct {
+	cache.Store
+}
+
+// ListResources returns the IngressClasses of the local store
+func (l *IngressClassLister) ListResources() []class.Resource {
+	if l.Store == nil {
+		return nil
+	}
+
+	var resources []class.Resource
+	for _, item := range l.List() {
+		u, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		resources = append(resources, ingressClassFromUnstructured(u))
+	}
+	return resources
+}
+
+func ingressClassFromUnstructured(u *unstructured.Unstructured) class.Resource {
+	r := class.Resource{
+		Name:    u.GetName(),
+		Default: u.GetAnnotations()[class.DefaultClassKey] == "true",
+	}
+	r.Controller, _, _ = unstructured.NestedString(u.Object, "spec", "controller")
+
+	apiGroup, _, _ := unstructured.NestedString(u.Object, "spec", "parameters", "apiGroup")
+	kind, _, _ := unstructured.NestedString(u.Object, "spec", "parameters", "kind")
+	if apiGroup == v1alpha1.GroupName && kind == "IngressClassParams" {
+		r.Params, _, _ = unstructured.NestedString(u.Object, "spec", "parameters", "name")
+	}
+	return r
+}
+
+// ingressClassResource returns the most recent IngressClass resource served by the API server,
+// false when IngressClasses aren't served
+func ingressClassResource(client discovery.DiscoveryInterface) (schema.GroupVersionResource, bool) {
+	for _, icr := range ingressClassResources {
+		resources, err := client.ServerResourcesForGroupVersion(icr.GroupVersion().String())
+		if err != nil {
+			continue
+		}
+		for _, r := range resources.APIResources {
+			if r.Name == icr.Resource {
+				return icr, true
+			}
+		}
+	}
+	glog.Infof("IngressClass resources are not available, only the %v annotation is used", class.IngressKey)
+	return schema.GroupVersionResource{}, false
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+)
+
+func TestIngressClassFromUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1beta1",
+		"kind":       "IngressClass",
+		"metadata": map[string]interface{}{
+			"name":        "internal",
+			"annotations": map[string]interface{}{class.DefaultClassKey: "true"},
+		},
+		"spec": map[string]interface{}{
+			"controller": class.ControllerName,
+			"parameters": map[string]interface{}{
+				"apiGroup": "elbv2.k8s.aws",
+				"kind":     "IngressClassParams",
+				"name":     "internal-params",
+			},
+		},
+	}}
+
+	r := ingressClassFromUnstructured(u)
+	expected := class.Resource{Name: "internal", Controller: class.ControllerName, Default: true, Params: "internal-params"}
+	if r != expected {
+		t.Errorf("expected %+v but %+v was returned", expected, r)
+	}
+
+	unstructured.SetNestedField(u.Object, "ConfigMap", "spec", "parameters", "kind")
+	if r := ingressClassFromUnstructured(u); r.Params != "" {
+		t.Errorf("expected no IngressClassParams for other parameters, got %v", r.Params)
+	}
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+)
+
+// IngressClassParamsLister makes a Store that lists IngressClassParams.
+type IngressClassParamsLister struct {
+	cache.Store
+}
+
+// ByName returns the IngressClassParams with the name, or nil if they don't exist or can't be converted.
+func (l *IngressClassParamsLister) ByName(name string) *v1alpha1.IngressClassParams {
+	if l.Store == nil {
+		return nil
+	}
+
+	item, exists, err := l.GetByKey(name)
+	if err != nil || !exists {
+		return nil
+	}
+	u, ok := item.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	params, err := v1alpha1.IngressClassParamsFromUnstructured(u)
+	if err != nil {
+		glog.Errorf("could not convert IngressClassParams %s: %v", u.GetName(), err)
+		return nil
+	}
+	return params
+}
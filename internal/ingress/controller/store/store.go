@@ -30,7 +30,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -42,6 +45,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 )
 
 // Storer is the interface that wraps the required methods to gather information
@@ -50,6 +54,9 @@ type Storer interface {
 	// GetConfigMap returns the ConfigMap matching key.
 	GetConfigMap(key string) (*corev1.ConfigMap, error)
 
+	// GetSecret returns the Secret matching key.
+	GetSecret(key string) (*corev1.Secret, error)
+
 	// GetService returns the Service matching key.
 	GetService(key string) (*corev1.Service, error)
 
@@ -68,6 +75,15 @@ type Storer interface {
 	// ListIngresses returns a list of all Ingresses in the store.
 	ListIngresses() []*extensions.Ingress
 
+	// ListServices returns a list of all Services in the store.
+	ListServices() []*corev1.Service
+
+	// ListTargetGroupBindings returns a list of all TargetGroupBindings in the store.
+	ListTargetGroupBindings() []*v1alpha1.TargetGroupBinding
+
+	// GetIngressClassParams returns the IngressClassParams of an ingress class, nil if they don't exist.
+	GetIngressClassParams(className string) *v1alpha1.IngressClassParams
+
 	// GetIngressAnnotations returns the parsed annotations of an Ingress matching key.
 	GetIngressAnnotations(key string) (*annotations.Ingress, error)
 
@@ -115,18 +131,32 @@ type Informer struct {
 	Node      cache.SharedIndexInformer
 	Pod       cache.SharedIndexInformer
 	ConfigMap cache.SharedIndexInformer
+	Secret    cache.SharedIndexInformer
+
+	// TargetGroupBinding is only set when TargetGroupBindings are enabled
+	TargetGroupBinding cache.SharedIndexInformer
+
+	// IngressClassParams is only set when IngressClassParams are enabled
+	IngressClassParams cache.SharedIndexInformer
+
+	// IngressClass is only set when the API server serves IngressClasses
+	IngressClass cache.SharedIndexInformer
 }
 
 // Lister contains object listers (stores).
 type Lister struct {
-	Ingress           IngressLister
-	Service           ServiceLister
-	Node              NodeLister
-	Pod               PodLister
-	Endpoint          EndpointLister
-	ConfigMap         ConfigMapLister
-	IngressAnnotation IngressAnnotationsLister
-	ServiceAnnotation ServiceAnnotationsLister
+	Ingress            IngressLister
+	Service            ServiceLister
+	Node               NodeLister
+	Pod                PodLister
+	Endpoint           EndpointLister
+	ConfigMap          ConfigMapLister
+	Secret             SecretLister
+	IngressAnnotation  IngressAnnotationsLister
+	ServiceAnnotation  ServiceAnnotationsLister
+	TargetGroupBinding TargetGroupBindingLister
+	IngressClassParams IngressClassParamsLister
+	IngressClass       IngressClassLister
 }
 
 // NotExistsError is returned when an object does not exist in a local store.
@@ -144,6 +174,7 @@ func (i *Informer) Run(stopCh chan struct{}) {
 	go i.Node.Run(stopCh)
 	go i.Pod.Run(stopCh)
 	go i.ConfigMap.Run(stopCh)
+	go i.Secret.Run(stopCh)
 
 	// wait for all involved caches to be synced before processing items
 	// from the queue
@@ -151,12 +182,42 @@ func (i *Informer) Run(stopCh chan struct{}) {
 		i.Endpoint.HasSynced,
 		i.Service.HasSynced,
 		i.ConfigMap.HasSynced,
+		i.Secret.HasSynced,
 		i.Node.HasSynced,
 		i.Pod.HasSynced,
 	) {
 		runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
 	}
 
+	if i.TargetGroupBinding != nil {
+		go i.TargetGroupBinding.Run(stopCh)
+		if !cache.WaitForCacheSync(stopCh,
+			i.TargetGroupBinding.HasSynced,
+		) {
+			runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
+		}
+	}
+
+	// the annotations of the ingresses are checked against their IngressClassParams
+	if i.IngressClassParams != nil {
+		go i.IngressClassParams.Run(stopCh)
+		if !cache.WaitForCacheSync(stopCh,
+			i.IngressClassParams.HasSynced,
+		) {
+			runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
+		}
+	}
+
+	// the IngressClasses decide which ingresses are managed by the controller
+	if i.IngressClass != nil {
+		go i.IngressClass.Run(stopCh)
+		if !cache.WaitForCacheSync(stopCh,
+			i.IngressClass.HasSynced,
+		) {
+			runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
+		}
+	}
+
 	// in big clusters, deltas can keep arriving even after HasSynced
 	// functions have returned 'true'
 	time.Sleep(1 * time.Second)
@@ -226,7 +287,8 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 		informers.WithNamespace(cfg.Namespace),
 		informers.WithTweakListOptions(func(*metav1.ListOptions) {}))
 
-	store.informers.Ingress = infFactory.Extensions().V1beta1().Ingresses().Informer()
+	store.informers.Ingress = cache.NewSharedIndexInformer(newIngressListWatch(cfg.DynamicClient, cfg.IngressResource, cfg.Namespace),
+		&extensions.Ingress{}, cfg.ResyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
 	store.listers.Ingress.Store = store.informers.Ingress.GetStore()
 
 	store.informers.Endpoint = infFactory.Core().V1().Endpoints().Informer()
@@ -235,6 +297,9 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 	store.informers.ConfigMap = infFactory.Core().V1().ConfigMaps().Informer()
 	store.listers.ConfigMap.Store = store.informers.ConfigMap.GetStore()
 
+	store.informers.Secret = infFactory.Core().V1().Secrets().Informer()
+	store.listers.Secret.Store = store.informers.Secret.GetStore()
+
 	store.informers.Service = infFactory.Core().V1().Services().Informer()
 	store.listers.Service.Store = store.informers.Service.GetStore()
 
@@ -244,6 +309,47 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 	store.informers.Pod = infFactory.Core().V1().Pods().Informer()
 	store.listers.Pod.Store = store.informers.Pod.GetStore()
 
+	if cfg.EnableTargetGroupBinding {
+		// TargetGroupBindings are custom resources without a typed client, they are watched with the dynamic client
+		tgbClient := cfg.DynamicClient.Resource(v1alpha1.TargetGroupBindingResource).Namespace(cfg.Namespace)
+		store.informers.TargetGroupBinding = cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (k8sruntime.Object, error) {
+				return tgbClient.List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return tgbClient.Watch(options)
+			},
+		}, &unstructured.Unstructured{}, cfg.ResyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		store.listers.TargetGroupBinding.Store = store.informers.TargetGroupBinding.GetStore()
+	}
+
+	if cfg.EnableIngressClassParams {
+		// IngressClassParams are cluster scoped custom resources, watched with the dynamic client
+		icpClient := cfg.DynamicClient.Resource(v1alpha1.IngressClassParamsResource)
+		store.informers.IngressClassParams = cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (k8sruntime.Object, error) {
+				return icpClient.List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return icpClient.Watch(options)
+			},
+		}, &unstructured.Unstructured{}, cfg.ResyncPeriod, cache.Indexers{})
+		store.listers.IngressClassParams.Store = store.informers.IngressClassParams.GetStore()
+	}
+
+	if icr, ok := ingressClassResource(cfg.Client.Discovery()); ok {
+		icClient := cfg.DynamicClient.Resource(icr)
+		store.informers.IngressClass = cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (k8sruntime.Object, error) {
+				return icClient.List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return icClient.Watch(options)
+			},
+		}, &unstructured.Unstructured{}, cfg.ResyncPeriod, cache.Indexers{})
+		store.listers.IngressClass.Store = store.informers.IngressClass.GetStore()
+	}
+
 	ingEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			ing := obj.(*extensions.Ingress)
@@ -292,6 +398,10 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 		UpdateFunc: func(old, cur interface{}) {
 			oldIng := old.(*extensions.Ingress)
 			curIng := cur.(*extensions.Ingress)
+			if isReconcileStatusUpdate(oldIng, curIng) {
+				glog.V(3).Infof("ignoring update of the reconcile status of ingress %v/%v", curIng.Namespace, curIng.Name)
+				return
+			}
 			validOld := class.IsValid(oldIng)
 			validCur := class.IsValid(curIng)
 			if !validOld && validCur {
@@ -368,6 +478,57 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 		},
 	}
 
+	// the OIDC client credentials of authenticate actions are read from Secrets
+	secrEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			updateCh.In() <- Event{
+				Type: CreateEvent,
+				Obj:  obj,
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			updateCh.In() <- Event{
+				Type: DeleteEvent,
+				Obj:  obj,
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			osecr := old.(*corev1.Secret)
+			csecr := cur.(*corev1.Secret)
+			if !reflect.DeepEqual(osecr.Data, csecr.Data) {
+				updateCh.In() <- Event{
+					Type: UpdateEvent,
+					Obj:  cur,
+				}
+			}
+		},
+	}
+
+	tgbEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			updateCh.In() <- Event{
+				Type: CreateEvent,
+				Obj:  obj,
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			updateCh.In() <- Event{
+				Type: DeleteEvent,
+				Obj:  obj,
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			otgb := old.(*unstructured.Unstructured)
+			ctgb := cur.(*unstructured.Unstructured)
+			if !reflect.DeepEqual(otgb.Object["spec"], ctgb.Object["spec"]) {
+				updateCh.In() <- Event{
+					Type: UpdateEvent,
+					Obj:  cur,
+				}
+			}
+		},
+	}
+
 	cmEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			cm := obj.(*corev1.ConfigMap)
@@ -395,16 +556,7 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 						store.setConfig(cm)
 					}
 
-					ings := store.listers.IngressAnnotation.List()
-					for _, ingKey := range ings {
-						key := k8s.MetaNamespaceKey(ingKey)
-						ing, err := store.GetIngress(key)
-						if err != nil {
-							glog.Errorf("could not find Ingress %v in local store: %v", key, err)
-							continue
-						}
-						store.extractIngressAnnotations(ing)
-					}
+					store.refreshAnnotations()
 
 					updateCh.In() <- Event{
 						Type: ConfigurationEvent,
@@ -415,10 +567,65 @@ func New(cfg *config.Configuration, updateCh *channels.RingChannel) Storer {
 		},
 	}
 
+	// the annotations are parsed again, as they are checked against the IngressClassParams of their ingress class
+	icpEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			store.refreshAnnotations()
+			updateCh.In() <- Event{
+				Type: ConfigurationEvent,
+				Obj:  obj,
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			store.refreshAnnotations()
+			updateCh.In() <- Event{
+				Type: ConfigurationEvent,
+				Obj:  obj,
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oicp := old.(*unstructured.Unstructured)
+			cicp := cur.(*unstructured.Unstructured)
+			if reflect.DeepEqual(oicp.Object["spec"], cicp.Object["spec"]) {
+				return
+			}
+			store.refreshAnnotations()
+			updateCh.In() <- Event{
+				Type: ConfigurationEvent,
+				Obj:  cur,
+			}
+		},
+	}
+
+	// the ingresses managed by the controller, and their IngressClassParams, depend on the IngressClasses
+	icEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			store.updateIngressClasses(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			store.updateIngressClasses(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				store.updateIngressClasses(cur)
+			}
+		},
+	}
+
 	store.informers.Ingress.AddEventHandler(ingEventHandler)
 	store.informers.Endpoint.AddEventHandler(epEventHandler)
 	store.informers.ConfigMap.AddEventHandler(cmEventHandler)
 	store.informers.Service.AddEventHandler(svcEventHandler)
+	store.informers.Secret.AddEventHandler(secrEventHandler)
+	if store.informers.TargetGroupBinding != nil {
+		store.informers.TargetGroupBinding.AddEventHandler(tgbEventHandler)
+	}
+	if store.informers.IngressClassParams != nil {
+		store.informers.IngressClassParams.AddEventHandler(icpEventHandler)
+	}
+	if store.informers.IngressClass != nil {
+		store.informers.IngressClass.AddEventHandler(icEventHandler)
+	}
 	// TODO Node events
 
 	// do not wait for informers to read the configmap configuration
@@ -446,6 +653,30 @@ func (s *k8sStore) extractIngressAnnotations(ing *extensions.Ingress) {
 	}
 }
 
+// refreshAnnotations parses the annotations of every ingress of the controller and service again
+func (s *k8sStore) refreshAnnotations() {
+	for _, item := range s.listers.Ingress.List() {
+		ing := item.(*extensions.Ingress)
+		if class.IsValid(ing) {
+			s.extractIngressAnnotations(ing)
+		}
+	}
+
+	for _, svc := range s.ListServices() {
+		s.extractServiceAnnotations(svc)
+	}
+}
+
+// updateIngressClasses makes the IngressClasses of the store known to the ingress class checks
+func (s *k8sStore) updateIngressClasses(obj interface{}) {
+	class.SetResources(s.listers.IngressClass.ListResources())
+	s.refreshAnnotations()
+	s.updateCh.In() <- Event{
+		Type: ConfigurationEvent,
+		Obj:  obj,
+	}
+}
+
 // extractServiceAnnotations parses service annotations converting the value of the
 // annotation to a go struct and also information about the referenced secrets
 func (s *k8sStore) extractServiceAnnotations(svc *corev1.Service) {
@@ -478,6 +709,11 @@ func objectRefAnnotationNsKey(ann string, ing *extensions.Ingress) (string, erro
 	return *annValue, nil
 }
 
+// GetSecret returns the Secret matching key.
+func (s k8sStore) GetSecret(key string) (*corev1.Secret, error) {
+	return s.listers.Secret.ByKey(key)
+}
+
 // GetService returns the Service matching key.
 func (s k8sStore) GetService(key string) (*corev1.Service, error) {
 	return s.listers.Service.ByKey(key)
@@ -537,6 +773,30 @@ func (s k8sStore) ListIngresses() []*extensions.Ingress {
 	return ingresses
 }
 
+// ListServices returns the list of Services
+func (s k8sStore) ListServices() []*corev1.Service {
+	var services []*corev1.Service
+	for _, item := range s.listers.Service.List() {
+		services = append(services, item.(*corev1.Service))
+	}
+	return services
+}
+
+// ListTargetGroupBindings returns the list of TargetGroupBindings
+func (s k8sStore) ListTargetGroupBindings() []*v1alpha1.TargetGroupBinding {
+	return s.listers.TargetGroupBinding.ListTargetGroupBindings()
+}
+
+// GetIngressClassParams returns the IngressClassParams referenced by the IngressClass resource of
+// the ingress class, or named after the ingress class.
+func (s k8sStore) GetIngressClassParams(className string) *v1alpha1.IngressClassParams {
+	name := className
+	if r, ok := class.GetResource(className); ok && r.Controller == class.ControllerName && r.Params != "" {
+		name = r.Params
+	}
+	return s.listers.IngressClassParams.ByName(name)
+}
+
 // GetIngressAnnotations returns the parsed annotations of an Ingress matching key.
 func (s k8sStore) GetIngressAnnotations(key string) (*annotations.Ingress, error) {
 	ia, err := s.listers.IngressAnnotation.ByKey(key)
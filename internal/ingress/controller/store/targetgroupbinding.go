@@ -0,0 +1,37 @@
+package store
+
+import (
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+)
+
+// TargetGroupBindingLister makes a Store that lists TargetGroupBindings.
+type TargetGroupBindingLister struct {
+	cache.Store
+}
+
+// ListTargetGroupBindings returns the TargetGroupBindings of the local store, skipping the
+// objects that can't be converted.
+func (l *TargetGroupBindingLister) ListTargetGroupBindings() []*v1alpha1.TargetGroupBinding {
+	if l.Store == nil {
+		return nil
+	}
+
+	var bindings []*v1alpha1.TargetGroupBinding
+	for _, item := range l.List() {
+		u, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		binding, err := v1alpha1.TargetGroupBindingFromUnstructured(u)
+		if err != nil {
+			glog.Errorf("could not convert TargetGroupBinding %s/%s: %v", u.GetNamespace(), u.GetName(), err)
+			continue
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
@@ -36,3 +36,27 @@ func TestInvalidContent(t *testing.T) {
 		t.Error("expected false")
 	}
 }
+
+func TestReconcileError(t *testing.T) {
+	if NewReconcileError(StepListener, nil) != nil {
+		t.Error("expected nil")
+	}
+
+	err := NewReconcileError(StepRule, New("priority in use"))
+	if Reason(err) != "FailedRule" {
+		t.Errorf("expected FailedRule, got %v", Reason(err))
+	}
+	if err.Error() != "priority in use" {
+		t.Errorf("expected the message of the failure, got %v", err.Error())
+	}
+
+	// the step a rule failed at is kept when the listener reconciliation returns the error
+	err = NewReconcileError(StepListener, err)
+	if Reason(err) != "FailedRule" {
+		t.Errorf("expected FailedRule, got %v", Reason(err))
+	}
+
+	if Reason(New("failure")) != "ERROR" {
+		t.Errorf("expected ERROR, got %v", Reason(New("failure")))
+	}
+}
@@ -0,0 +1,68 @@
+package errors
+
+// Step is a step of the reconciliation of an ingress with its AWS resources
+type Step string
+
+const (
+	// StepConfiguration is the assembly of the desired state from the ingress and its annotations
+	StepConfiguration Step = "Configuration"
+	// StepFinalizer is the addition of the controller finalizer to the ingress
+	StepFinalizer Step = "Finalizer"
+	// StepLoadBalancer is the creation, modification or deletion of the load balancer
+	StepLoadBalancer Step = "LoadBalancer"
+	// StepListener is the creation, modification or deletion of a listener
+	StepListener Step = "Listener"
+	// StepRule is the creation, modification or deletion of a listener rule
+	StepRule Step = "Rule"
+	// StepTargetGroup is the creation, modification or deletion of a target group and its targets
+	StepTargetGroup Step = "TargetGroup"
+	// StepSecurityGroup is the association of the security groups of the load balancer and its targets
+	StepSecurityGroup Step = "SecurityGroup"
+	// StepTags is the tagging of the load balancer and target groups
+	StepTags Step = "Tags"
+	// StepAttributes is the configuration of the load balancer and target group attributes
+	StepAttributes Step = "Attributes"
+	// StepDNS is the management of the Route53 records of the ingress hosts
+	StepDNS Step = "DNS"
+)
+
+// Reason returns the reason of the events reporting a failure of the step, like FailedListener
+func (s Step) Reason() string {
+	return "Failed" + string(s)
+}
+
+// ReconcileError is the failure of a step of the reconciliation
+type ReconcileError struct {
+	Step Step
+	Err  error
+}
+
+// NewReconcileError returns a new ReconcileError for a failure of step. Errors which already are
+// a ReconcileError keep the step they failed at, and nil is returned for a nil err.
+func NewReconcileError(step Step, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(ReconcileError); ok {
+		return err
+	}
+	return ReconcileError{Step: step, Err: err}
+}
+
+func (e ReconcileError) Error() string {
+	return e.Err.Error()
+}
+
+// Reason returns the reason of the events reporting the error
+func (e ReconcileError) Reason() string {
+	return e.Step.Reason()
+}
+
+// Reason returns the reason of the events reporting err, the reason of its step when it is a
+// ReconcileError and ERROR otherwise
+func Reason(err error) string {
+	if e, ok := err.(ReconcileError); ok {
+		return e.Reason()
+	}
+	return "ERROR"
+}
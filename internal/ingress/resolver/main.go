@@ -18,6 +18,7 @@ package resolver
 
 import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 )
 
 // Resolver is an interface that knows how to extract information from a controller
@@ -25,4 +26,6 @@ type Resolver interface {
 	// GetConfig returns the controller configuration
 	GetConfig() *config.Configuration
 	GetInstanceIDFromPodIP(string) (string, error)
+	// GetIngressClassParams returns the IngressClassParams of an ingress class, nil if they don't exist
+	GetIngressClassParams(className string) *v1alpha1.IngressClassParams
 }
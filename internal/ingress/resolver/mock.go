@@ -16,10 +16,14 @@ limitations under the License.
 
 package resolver
 
-import "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+)
 
 // Mock implements the Resolver interface
 type Mock struct {
+	IngressClassParams *v1alpha1.IngressClassParams
 }
 
 func (m Mock) GetConfig() *config.Configuration {
@@ -29,3 +33,7 @@ func (m Mock) GetConfig() *config.Configuration {
 func (m Mock) GetInstanceIDFromPodIP(s string) (string, error) {
 	return "", nil
 }
+
+func (m Mock) GetIngressClassParams(className string) *v1alpha1.IngressClassParams {
+	return m.IngressClassParams
+}
@@ -17,8 +17,8 @@ limitations under the License.
 package status
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang/glog"
@@ -28,25 +28,26 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
-	clientset "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/leaderelection"
-	"k8s.io/client-go/tools/leaderelection/resourcelock"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/dynamic"
 
-	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/task"
 )
 
 const (
 	updateInterval = 60 * time.Second
+
+	reconcileStatusRefreshInterval = 10 * time.Minute
 )
 
 // Sync ...
 type Sync interface {
-	Run()
+	Run(stopCh <-chan struct{})
 	Shutdown()
 }
 
@@ -55,45 +56,45 @@ type ingressLister interface {
 	ListIngresses() []*extensions.Ingress
 }
 
+type albIngressLister interface {
+	// ALBIngresses returns the ALBIngresses of the running configuration, the list is never modified
+	ALBIngresses() albingress.ALBIngresses
+}
+
 // Config ...
 type Config struct {
-	Client clientset.Interface
+	DynamicClient dynamic.Interface
 
-	ElectionID string
+	// IngressResource is the Ingress resource whose status is updated
+	IngressResource schema.GroupVersionResource
 
 	IngressLister ingressLister
 
-	DefaultIngressClass string
-	IngressClass        string
-
-	RunningConfig *ingress.Configuration
+	ALBIngressLister albIngressLister
 }
 
-// statusSync keeps the status IP in each Ingress rule updated executing a periodic check
-// in all the defined rules. To simplify the process leader election is used so the update
-// is executed only in one node (Ingress controllers can be scaled to more than one)
-// If the controller is running with the flag --publish-service (with a valid service)
-// the IP address behind the service is used, if it is running with the flag
-// --publish-status-address, the address specified in the flag is used, if neither of the
-// two flags are set, the source is the IP/s of the node/s
+// statusSync keeps the status of each Ingress updated with the DNS name of its load balancer,
+// executing a periodic check of all Ingresses. It only runs in the leader, the controller
+// starts it once it acquired the leader election.
 type statusSync struct {
 	Config
-	// pod contains runtime information about this pod
-	pod *k8s.PodInfo
 
-	elector *leaderelection.LeaderElector
 	// workqueue used to keep in sync the status IP/s
 	// in the Ingress rules
 	syncQueue *task.Queue
 }
 
-// Run starts the loop to keep the status in sync
-func (s statusSync) Run() {
-	s.elector.Run()
+// Run starts the loop to keep the status in sync until stopCh is closed
+func (s statusSync) Run(stopCh <-chan struct{}) {
+	go s.syncQueue.Run(time.Second, stopCh)
+	// enqueue an item to trigger the update of the Ingress status.
+	wait.PollUntil(updateInterval, func() (bool, error) {
+		s.syncQueue.EnqueueTask(task.GetDummyObject("sync status"))
+		return false, nil
+	}, stopCh)
 }
 
-// Shutdown stop the sync. In case the instance is the leader it will remove the current IP
-// if there is no other instances running.
+// Shutdown stops the sync.
 func (s statusSync) Shutdown() {
 	go s.syncQueue.Shutdown()
 }
@@ -115,81 +116,17 @@ func (s statusSync) keyfunc(input interface{}) (interface{}, error) {
 
 // NewStatusSyncer returns a new Sync instance
 func NewStatusSyncer(config Config) Sync {
-	pod, err := k8s.GetPodDetails(config.Client)
-	if err != nil {
-		glog.Fatalf("unexpected error obtaining pod information: %v", err)
-	}
-
 	st := statusSync{
-		pod: pod,
-
 		Config: config,
 	}
 	st.syncQueue = task.NewCustomTaskQueue(st.sync, st.keyfunc)
-
-	// we need to use the defined ingress class to allow multiple leaders
-	// in order to update information about ingress status
-	electionID := fmt.Sprintf("%v-%v", config.ElectionID, config.DefaultIngressClass)
-	if config.IngressClass != "" {
-		electionID = fmt.Sprintf("%v-%v", config.ElectionID, config.IngressClass)
-	}
-
-	callbacks := leaderelection.LeaderCallbacks{
-		OnStartedLeading: func(stop <-chan struct{}) {
-			glog.V(2).Infof("I am the new status update leader")
-			go st.syncQueue.Run(time.Second, stop)
-			// when this instance is the leader we need to enqueue
-			// an item to trigger the update of the Ingress status.
-			wait.PollUntil(updateInterval, func() (bool, error) {
-				st.syncQueue.EnqueueTask(task.GetDummyObject("sync status"))
-				return false, nil
-			}, stop)
-		},
-		OnStoppedLeading: func() {
-			glog.V(2).Infof("I am not status update leader anymore")
-		},
-		OnNewLeader: func(identity string) {
-			glog.Infof("new leader elected: %v", identity)
-		},
-	}
-
-	broadcaster := record.NewBroadcaster()
-	hostname, _ := os.Hostname()
-
-	recorder := broadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{
-		Component: "ingress-leader-elector",
-		Host:      hostname,
-	})
-
-	lock := resourcelock.ConfigMapLock{
-		ConfigMapMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: electionID},
-		Client:        config.Client.CoreV1(),
-		LockConfig: resourcelock.ResourceLockConfig{
-			Identity:      pod.Name,
-			EventRecorder: recorder,
-		},
-	}
-
-	ttl := 30 * time.Second
-	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
-		Lock:          &lock,
-		LeaseDuration: ttl,
-		RenewDeadline: ttl / 2,
-		RetryPeriod:   ttl / 4,
-		Callbacks:     callbacks,
-	})
-
-	if err != nil {
-		glog.Fatalf("unexpected error starting leader election: %v", err)
-	}
-
-	st.elector = le
 	return st
 }
 
 // updateStatus changes the status information of Ingress rules
 func (s *statusSync) updateStatus() {
 	ings := s.IngressLister.ListIngresses()
+	albIngresses := s.ALBIngressLister.ALBIngresses()
 
 	p := pool.NewLimited(10)
 	defer p.Close()
@@ -197,25 +134,32 @@ func (s *statusSync) updateStatus() {
 	batch := p.Batch()
 
 	for _, ing := range ings {
-		batch.Queue(runUpdate(ing, s.Client, s.RunningConfig))
+		batch.Queue(runUpdate(ing, s.DynamicClient.Resource(s.IngressResource), albIngresses))
 	}
 
 	batch.QueueComplete()
 	batch.WaitAll()
 }
 
-func runUpdate(ing *extensions.Ingress, client clientset.Interface, rc *ingress.Configuration) pool.WorkFunc {
+func runUpdate(ing *extensions.Ingress, client dynamic.NamespaceableResourceInterface, albIngresses albingress.ALBIngresses) pool.WorkFunc {
 	return func(wu pool.WorkUnit) (interface{}, error) {
 		if wu.IsCancelled() {
 			return nil, nil
 		}
 
+		// the ingress is updated with the dynamic client, in the API version served by the API server
+		ingClient := client.Namespace(ing.Namespace)
+
 		var current []apiv1.LoadBalancerIngress
-		if _, i := rc.Ingresses.FindByID(k8s.MetaNamespaceKey(ing)); i != nil {
+		if _, i := albIngresses.FindByIngressID(k8s.MetaNamespaceKey(ing)); i != nil {
 			hostnames, err := i.Hostnames()
 			if err == nil {
 				current = hostnames
 			}
+
+			if err := updateReconcileStatus(ing, ingClient, i.ReconcileStatus()); err != nil {
+				glog.Warningf("error updating the reconcile status of Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+			}
 		}
 
 		if ingressSliceEqual(ing.Status.LoadBalancer.Ingress, current) {
@@ -227,15 +171,15 @@ func runUpdate(ing *extensions.Ingress, client clientset.Interface, rc *ingress.
 			return nil, nil
 		}
 
-		ingClient := client.ExtensionsV1beta1().Ingresses(ing.Namespace)
-
 		currIng, err := ingClient.Get(ing.Name, metav1.GetOptions{})
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("unexpected error searching Ingress %v/%v", ing.Namespace, ing.Name))
 		}
 
-		glog.Infof("updating Ingress %v/%v status to %v", currIng.Namespace, currIng.Name, current)
-		currIng.Status.LoadBalancer.Ingress = current
+		glog.Infof("updating Ingress %v/%v status to %v", currIng.GetNamespace(), currIng.GetName(), current)
+		if err := unstructured.SetNestedSlice(currIng.Object, loadBalancerIngresses(current), "status", "loadBalancer", "ingress"); err != nil {
+			return nil, err
+		}
 		_, err = ingClient.UpdateStatus(currIng)
 		if err != nil {
 			glog.Warningf("error updating ingress rule: %v", err)
@@ -245,6 +189,70 @@ func runUpdate(ing *extensions.Ingress, client clientset.Interface, rc *ingress.
 	}
 }
 
+// updateReconcileStatus writes the reconcile status of the ALBIngress serving the ingress to its
+// k8s.ReconcileStatusKey annotation, when it changed
+func updateReconcileStatus(ing *extensions.Ingress, client dynamic.ResourceInterface, status albingress.ReconcileStatus) error {
+	value, changed, err := reconcileStatusAnnotation(ing, status)
+	if err != nil || !changed {
+		return err
+	}
+
+	glog.V(3).Infof("updating Ingress %v/%v reconcile status to %v", ing.Namespace, ing.Name, value)
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				k8s.ReconcileStatusKey: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.Patch(ing.Name, types.MergePatchType, patch)
+	return err
+}
+
+// reconcileStatusAnnotation returns the value of the k8s.ReconcileStatusKey annotation for status,
+// and whether it differs from the annotation of the ingress. The time of the last successful
+// reconciliation changes at every sync, it is only refreshed every reconcileStatusRefreshInterval
+// so the ingresses aren't updated that often.
+func reconcileStatusAnnotation(ing *extensions.Ingress, status albingress.ReconcileStatus) (string, bool, error) {
+	if status == (albingress.ReconcileStatus{}) {
+		// the ALBIngress wasn't reconciled yet
+		return "", false, nil
+	}
+
+	annotation := ing.Annotations[k8s.ReconcileStatusKey]
+	var previous albingress.ReconcileStatus
+	if err := json.Unmarshal([]byte(annotation), &previous); err == nil &&
+		previous.LastReconcileTime != nil && status.LastReconcileTime != nil &&
+		status.LastReconcileTime.Sub(previous.LastReconcileTime.Time) < reconcileStatusRefreshInterval {
+		status.LastReconcileTime = previous.LastReconcileTime
+	}
+
+	value, err := json.Marshal(status)
+	if err != nil {
+		return "", false, err
+	}
+	return string(value), string(value) != annotation, nil
+}
+
+// loadBalancerIngresses returns the unstructured status of the load balancer of an Ingress
+func loadBalancerIngresses(lbIngresses []apiv1.LoadBalancerIngress) []interface{} {
+	var status []interface{}
+	for _, lbIngress := range lbIngresses {
+		s := make(map[string]interface{})
+		if lbIngress.Hostname != "" {
+			s["hostname"] = lbIngress.Hostname
+		}
+		if lbIngress.IP != "" {
+			s["ip"] = lbIngress.IP
+		}
+		status = append(status, s)
+	}
+	return status
+}
+
 func ingressSliceEqual(lhs, rhs []apiv1.LoadBalancerIngress) bool {
 	if len(lhs) != len(rhs) {
 		return false
@@ -17,13 +17,18 @@ limitations under the License.
 package status
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/task"
@@ -258,17 +263,11 @@ func buildIngressLister() ingressLister {
 
 func buildStatusSync() statusSync {
 	return statusSync{
-		pod: &k8s.PodInfo{
-			Name:      "foo_base_pod",
-			Namespace: apiv1.NamespaceDefault,
-			Labels: map[string]string{
-				"lable_sig": "foo_pod",
-			},
-		},
 		syncQueue: task.NewTaskQueue(fakeSynFn),
 		Config: Config{
-			Client:        buildSimpleClientSet(),
-			IngressLister: buildIngressLister(),
+			DynamicClient:   fakedynamic.NewSimpleDynamicClient(runtime.NewScheme()),
+			IngressResource: k8s.ExtensionsIngressResource,
+			IngressLister:   buildIngressLister(),
 		},
 	}
 }
@@ -385,3 +384,54 @@ func TestIngressSliceEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadBalancerIngresses(t *testing.T) {
+	status := loadBalancerIngresses([]apiv1.LoadBalancerIngress{
+		{Hostname: "foo.elb.amazonaws.com"},
+		{IP: "10.0.0.1"},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"hostname": "foo.elb.amazonaws.com"},
+		map[string]interface{}{"ip": "10.0.0.1"},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("returned %v but expected %v", status, expected)
+	}
+}
+
+func TestReconcileStatusAnnotation(t *testing.T) {
+	reconciled := metav1.NewTime(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	status := albingress.ReconcileStatus{LoadBalancerARN: "arn", LastReconcileTime: &reconciled}
+	annotation := `{"loadBalancerARN":"arn","lastReconcileTime":"2020-01-01T10:00:00Z"}`
+
+	ing := &extensions.Ingress{}
+	if _, changed, _ := reconcileStatusAnnotation(ing, albingress.ReconcileStatus{}); changed {
+		t.Errorf("expected no annotation before the first reconciliation")
+	}
+
+	value, changed, err := reconcileStatusAnnotation(ing, status)
+	if err != nil || !changed || value != annotation {
+		t.Errorf("unexpected annotation %v (changed: %v, error: %v)", value, changed, err)
+	}
+
+	ing.Annotations = map[string]string{k8s.ReconcileStatusKey: annotation}
+	later := metav1.NewTime(reconciled.Add(time.Minute))
+	status.LastReconcileTime = &later
+	if value, changed, _ := reconcileStatusAnnotation(ing, status); changed {
+		t.Errorf("expected the reconcile time not to be refreshed yet, got %v", value)
+	}
+
+	status.LastError = &albingress.ReconcileErrorStatus{Reason: "FailedListener", Message: "failure", Time: later}
+	value, changed, _ = reconcileStatusAnnotation(ing, status)
+	expected := `{"loadBalancerARN":"arn","lastReconcileTime":"2020-01-01T10:00:00Z","lastError":{"reason":"FailedListener","message":"failure","time":"2020-01-01T10:01:00Z"}}`
+	if !changed || value != expected {
+		t.Errorf("expected the error to be written, got %v", value)
+	}
+
+	status.LastError = nil
+	refreshed := metav1.NewTime(reconciled.Add(reconcileStatusRefreshInterval))
+	status.LastReconcileTime = &refreshed
+	if value, changed, _ := reconcileStatusAnnotation(ing, status); !changed || value != `{"loadBalancerARN":"arn","lastReconcileTime":"2020-01-01T10:10:00Z"}` {
+		t.Errorf("expected the reconcile time to be refreshed, got %v", value)
+	}
+}
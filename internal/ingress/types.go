@@ -18,10 +18,16 @@ package ingress
 
 import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/nlbservice"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/targetgroupbinding"
 )
 
 // Configuration holds the definition of all the parts required to describe all
-// ingresses reachable by the ingress controller (using a filter by namespace)
+// ingresses reachable by the ingress controller (using a filter by namespace),
+// the services of type LoadBalancer provisioned with network load balancers and
+// the target groups bound to services by TargetGroupBindings
 type Configuration struct {
-	Ingresses albingress.ALBIngresses
+	Ingresses           albingress.ALBIngresses
+	Services            nlbservice.NLBServices
+	TargetGroupBindings targetgroupbinding.TargetGroupBindings
 }
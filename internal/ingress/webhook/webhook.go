@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	admission "k8s.io/api/admission/v1beta1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albingress"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+)
+
+// Path is the path of the validating admission webhook on the HTTP mux of the controller
+const Path = "/validate-ingress"
+
+// maxRequestSize limits the size of the AdmissionReview requests
+const maxRequestSize = 3 * 1024 * 1024
+
+// IngressValidator is a validating admission webhook rejecting the Ingresses of the controller whose
+// annotations are invalid, with the parsers used when the Ingresses are synced.
+type IngressValidator struct {
+	store     store.Storer
+	extractor annotations.Extractor
+}
+
+// NewIngressValidator returns an IngressValidator reading the configuration and resources
+// referenced by the annotations from the store
+func NewIngressValidator(s store.Storer) *IngressValidator {
+	return &IngressValidator{
+		store:     s,
+		extractor: annotations.NewIngressAnnotationExtractor(s),
+	}
+}
+
+// ServeHTTP answers an AdmissionReview request
+func (v *IngressValidator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &admission.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview without request", http.StatusBadRequest)
+		return
+	}
+
+	// the response is sent in the version of the request, admission.k8s.io/v1 reviews have the same fields
+	review.Response = v.review(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	b, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// review returns the response to an AdmissionReview request
+func (v *IngressValidator) review(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	if req.Operation != admission.Create && req.Operation != admission.Update {
+		return &admission.AdmissionResponse{Allowed: true}
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(req.Object.Raw, &u.Object); err != nil {
+		return denied(fmt.Sprintf("invalid Ingress: %v", err))
+	}
+	ing, err := store.IngressFromUnstructured(u)
+	if err != nil {
+		return denied(fmt.Sprintf("invalid Ingress: %v", err))
+	}
+	if ing.Namespace == "" {
+		ing.Namespace = req.Namespace
+	}
+
+	// the controller updates the finalizers and the reconcile status of the ingresses it serves, an ingress
+	// that turned invalid, e.g. after a configuration change, must not block these updates
+	if req.Operation == admission.Update && len(req.OldObject.Raw) > 0 {
+		old := &unstructured.Unstructured{}
+		if err := json.Unmarshal(req.OldObject.Raw, &old.Object); err == nil {
+			if oldIng, err := store.IngressFromUnstructured(old); err == nil && !validatedChange(oldIng, ing) {
+				return &admission.AdmissionResponse{Allowed: true}
+			}
+		}
+	}
+
+	if err := v.validate(ing); err != nil {
+		glog.V(2).Infof("rejecting Ingress %v: %v", k8s.MetaNamespaceKey(ing), err)
+		return denied(err.Error())
+	}
+	return &admission.AdmissionResponse{Allowed: true}
+}
+
+// validate returns the reason the controller would fail to assemble the ingress, nil for the
+// ingresses of other controllers and the ingresses being deleted
+func (v *IngressValidator) validate(ing *extensions.Ingress) error {
+	if !class.IsValid(ing) || ing.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if reason := ing.Annotations[k8s.UnsupportedKey]; reason != "" {
+		return fmt.Errorf("ingress %s can't be served: %s", k8s.MetaNamespaceKey(ing), reason)
+	}
+
+	anns := v.extractor.ExtractIngress(ing)
+	if anns.Error != nil {
+		return fmt.Errorf("error parsing annotations: %s", anns.Error.Error())
+	}
+
+	cfg := v.store.GetConfig()
+	if cfg.RestrictScheme && anns.LoadBalancer != nil && anns.LoadBalancer.Scheme != nil &&
+		*anns.LoadBalancer.Scheme == elbv2.LoadBalancerSchemeEnumInternetFacing {
+		allowed, err := albingress.AllowedExternal(v.store, cfg.RestrictSchemeNamespace, ing)
+		if err != nil {
+			return fmt.Errorf("error getting restricted ingresses ConfigMap: %s", err.Error())
+		}
+		if !allowed {
+			return fmt.Errorf("ingress %s is not allowed to be internet-facing", k8s.MetaNamespaceKey(ing))
+		}
+	}
+	return nil
+}
+
+// validatedChange returns true if the spec or the annotations read by the controller differ
+func validatedChange(old *extensions.Ingress, cur *extensions.Ingress) bool {
+	return !reflect.DeepEqual(old.Spec, cur.Spec) || !reflect.DeepEqual(validatedAnnotations(old), validatedAnnotations(cur))
+}
+
+// validatedAnnotations returns the annotations of the ingress read by the controller, the reconcile
+// status annotation is written by the controller itself
+func validatedAnnotations(ing *extensions.Ingress) map[string]string {
+	annos := make(map[string]string)
+	for k, val := range ing.Annotations {
+		if k == class.IngressKey || k == k8s.PathTypesKey || k == k8s.UnsupportedKey ||
+			strings.HasPrefix(k, parser.AnnotationsPrefix+"/") {
+			annos[k] = val
+		}
+	}
+	return annos
+}
+
+func denied(message string) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admission "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+)
+
+const (
+	invalidSchemeIngress = `{
+  "apiVersion": "extensions/v1beta1",
+  "kind": "Ingress",
+  "metadata": {
+    "name": "foo",
+    "annotations": {
+      "kubernetes.io/ingress.class": "%s",
+      "alb.ingress.kubernetes.io/scheme": "public"
+    }
+  },
+  "spec": {"backend": {"serviceName": "svc", "servicePort": 80}}
+}`
+
+	resourceBackendIngress = `{
+  "apiVersion": "networking.k8s.io/v1",
+  "kind": "Ingress",
+  "metadata": {"name": "foo", "namespace": "default"},
+  "spec": {
+    "ingressClassName": "alb",
+    "defaultBackend": {"resource": {"apiGroup": "k8s.example.com", "kind": "StorageBucket", "name": "static-assets"}}
+  }
+}`
+)
+
+func review(t *testing.T, operation admission.Operation, object string, oldObject string) *admission.AdmissionResponse {
+	req := &admission.AdmissionRequest{
+		UID:       "uid",
+		Namespace: "default",
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}
+	if oldObject != "" {
+		req.OldObject = runtime.RawExtension{Raw: []byte(oldObject)}
+	}
+	body, err := json.Marshal(&admission.AdmissionReview{Request: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	NewIngressValidator(store.NewDummy()).ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %v: %v", w.Code, w.Body.String())
+	}
+
+	r := &admission.AdmissionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Response == nil || r.Response.UID != "uid" {
+		t.Fatalf("unexpected response %+v", r.Response)
+	}
+	return r.Response
+}
+
+func TestIngressValidator(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		operation admission.Operation
+		object    string
+		oldObject string
+		denied    string
+	}{
+		{
+			name:      "invalid annotation",
+			operation: admission.Create,
+			object:    fmt.Sprintf(invalidSchemeIngress, "alb"),
+			denied:    "ALB scheme must be either",
+		},
+		{
+			name:      "ingress of another controller",
+			operation: admission.Update,
+			object:    fmt.Sprintf(invalidSchemeIngress, "nginx"),
+		},
+		{
+			name:      "resource backend",
+			operation: admission.Create,
+			object:    resourceBackendIngress,
+			denied:    "resource backend StorageBucket static-assets is not supported",
+		},
+		{
+			name:      "reconcile status update of an invalid ingress",
+			operation: admission.Update,
+			object:    strings.Replace(fmt.Sprintf(invalidSchemeIngress, "alb"), `"annotations": {`, `"annotations": {"ingress.k8s.aws/reconcile-status": "failed",`, 1),
+			oldObject: fmt.Sprintf(invalidSchemeIngress, "alb"),
+		},
+		{
+			name:      "annotation update of an invalid ingress",
+			operation: admission.Update,
+			object:    fmt.Sprintf(invalidSchemeIngress, "alb"),
+			oldObject: strings.Replace(fmt.Sprintf(invalidSchemeIngress, "alb"), `"public"`, `"internal"`, 1),
+			denied:    "ALB scheme must be either",
+		},
+		{
+			name:      "deletion",
+			operation: admission.Delete,
+			object:    fmt.Sprintf(invalidSchemeIngress, "alb"),
+		},
+	} {
+		r := review(t, tc.operation, tc.object, tc.oldObject)
+		if tc.denied == "" {
+			if !r.Allowed {
+				t.Errorf("%s: expected the ingress to be allowed, got %+v", tc.name, r.Result)
+			}
+			continue
+		}
+		if r.Allowed || r.Result == nil || !strings.Contains(r.Result.Message, tc.denied) {
+			t.Errorf("%s: expected the ingress to be denied with %q, got %+v", tc.name, tc.denied, r.Result)
+		}
+	}
+}
+
+func TestIngressValidatorInvalidRequest(t *testing.T) {
+	v := NewIngressValidator(store.NewDummy())
+
+	w := httptest.NewRecorder()
+	v.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %v, got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	v.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, strings.NewReader(`{}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %v, got %v", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"encoding/json"
+
+	"github.com/golang/glog"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+var (
+	// NetworkingV1IngressResource are the Ingresses of Kubernetes 1.19 and later
+	NetworkingV1IngressResource = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+
+	// NetworkingV1beta1IngressResource are the Ingresses of Kubernetes 1.14 to 1.21
+	NetworkingV1beta1IngressResource = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"}
+
+	// ExtensionsIngressResource are the Ingresses of Kubernetes 1.21 and older
+	ExtensionsIngressResource = extensions.SchemeGroupVersion.WithResource("ingresses")
+
+	// ingressResources are the Ingress resources the controller can consume, preferred first
+	ingressResources = []schema.GroupVersionResource{
+		NetworkingV1IngressResource,
+		NetworkingV1beta1IngressResource,
+		ExtensionsIngressResource,
+	}
+)
+
+const (
+	// PathTypesKey keeps the pathType of every path of an Ingress, by rule, in the Ingresses converted
+	// into the extensions/v1beta1 types, which predate pathType.
+	PathTypesKey = "ingress.k8s.aws/path-types"
+
+	// UnsupportedKey keeps the reason an Ingress can't be served in the converted Ingresses, when
+	// it uses a feature missing from the extensions/v1beta1 types, like resource backends.
+	UnsupportedKey = "ingress.k8s.aws/unsupported"
+
+	// ReconcileStatusKey is the annotation the controller writes the outcome of the reconciliations
+	// of an Ingress to: the ARN of its load balancer, the time of the last successful reconciliation
+	// and the last error, as JSON.
+	ReconcileStatusKey = "ingress.k8s.aws/reconcile-status"
+)
+
+const (
+	// PathTypeExact matches the path exactly
+	PathTypeExact = "Exact"
+	// PathTypePrefix matches the path and every path below it
+	PathTypePrefix = "Prefix"
+	// PathTypeImplementationSpecific uses the path as an ALB path pattern
+	PathTypeImplementationSpecific = "ImplementationSpecific"
+)
+
+// IngressResource returns the most recent Ingress resource served by the API server. The
+// extensions/v1beta1 Ingresses are used when discovery fails.
+func IngressResource(client discovery.DiscoveryInterface) schema.GroupVersionResource {
+	for _, r := range ingressResources {
+		resources, err := client.ServerResourcesForGroupVersion(r.GroupVersion().String())
+		if err != nil {
+			glog.V(3).Infof("Ingresses of %v are not available: %v", r.GroupVersion(), err)
+			continue
+		}
+		for _, res := range resources.APIResources {
+			if res.Name == r.Resource {
+				glog.Infof("Using the Ingresses of %v", r.GroupVersion())
+				return r
+			}
+		}
+	}
+	glog.Warningf("No Ingress resource found, using the Ingresses of %v", ExtensionsIngressResource.GroupVersion())
+	return ExtensionsIngressResource
+}
+
+// PathType returns the pathType of the path at index path of the rule at index rule of the Ingress
+func PathType(ing *extensions.Ingress, rule, path int) string {
+	var pathTypes [][]string
+	if err := json.Unmarshal([]byte(ing.Annotations[PathTypesKey]), &pathTypes); err != nil {
+		return PathTypeImplementationSpecific
+	}
+	if rule >= len(pathTypes) || path >= len(pathTypes[rule]) || pathTypes[rule][path] == "" {
+		return PathTypeImplementationSpecific
+	}
+	return pathTypes[rule][path]
+}
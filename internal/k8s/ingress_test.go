@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"testing"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestIngressResource(t *testing.T) {
+	tests := []struct {
+		title         string
+		groupVersions []string
+		expected      schema.GroupVersionResource
+	}{
+		{"networking v1", []string{"extensions/v1beta1", "networking.k8s.io/v1beta1", "networking.k8s.io/v1"}, NetworkingV1IngressResource},
+		{"networking v1beta1", []string{"extensions/v1beta1", "networking.k8s.io/v1beta1"}, NetworkingV1beta1IngressResource},
+		{"extensions", []string{"extensions/v1beta1"}, ExtensionsIngressResource},
+		{"discovery failure", nil, ExtensionsIngressResource},
+	}
+
+	for _, test := range tests {
+		var resources []*metav1.APIResourceList
+		for _, gv := range test.groupVersions {
+			resources = append(resources, &metav1.APIResourceList{
+				GroupVersion: gv,
+				APIResources: []metav1.APIResource{{Name: "ingresses"}},
+			})
+		}
+		client := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}
+
+		r := IngressResource(client)
+		if r != test.expected {
+			t.Errorf("%v: expected %v but returned %v", test.title, test.expected, r)
+		}
+	}
+}
+
+func TestPathType(t *testing.T) {
+	ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		PathTypesKey: `[["Exact",""],["Prefix"]]`,
+	}}}
+
+	tests := []struct {
+		rule     int
+		path     int
+		expected string
+	}{
+		{0, 0, PathTypeExact},
+		{0, 1, PathTypeImplementationSpecific},
+		{1, 0, PathTypePrefix},
+		{1, 1, PathTypeImplementationSpecific},
+		{2, 0, PathTypeImplementationSpecific},
+	}
+
+	for _, test := range tests {
+		if p := PathType(ing, test.rule, test.path); p != test.expected {
+			t.Errorf("rule %v path %v: expected %v but returned %v", test.rule, test.path, test.expected, p)
+		}
+	}
+
+	if p := PathType(&extensions.Ingress{}, 0, 0); p != PathTypeImplementationSpecific {
+		t.Errorf("expected %v without annotation but returned %v", PathTypeImplementationSpecific, p)
+	}
+}
@@ -0,0 +1,669 @@
+package nlbservice
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/replacement"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+)
+
+// The port used when creating target groups. Targets are always registered with an explicit port,
+// see the targetGroupDefaultPort of the tg package.
+const targetGroupDefaultPort = 1
+
+type newDesiredLoadBalancerOptions struct {
+	Service              *corev1.Service
+	Annotations          *annotations.Service
+	CommonTags           *tags.Tags
+	NamePrefix           string
+	ExistingLoadBalancer *loadBalancer
+}
+
+// newDesiredLoadBalancer returns the network load balancer described by a Service and its annotations
+func newDesiredLoadBalancer(o *newDesiredLoadBalancerOptions) (*loadBalancer, error) {
+	name := createLBName(o.Service.Namespace, o.Service.Name, o.NamePrefix)
+
+	vpc, err := albec2.EC2svc.GetVPCID()
+	if err != nil {
+		return nil, err
+	}
+
+	// the scheme of a load balancer can't be modified, a new load balancer replaces it. A single
+	// replacement is in progress at a time, further ones wait for the replaced load balancer's deletion.
+	l := o.ExistingLoadBalancer
+	switch {
+	case l == nil:
+		l = &loadBalancer{id: name}
+	case l.replaced == nil && l.lb.current != nil && !l.deleted && !util.DeepEqual(l.lb.current.Scheme, o.Annotations.NLB.Scheme):
+		replaced := l
+		replaced.stripDesiredState()
+		l = &loadBalancer{id: replacement.Name(replaced.id, name), replaced: replaced}
+	}
+	// the load balancer keeps its name, a replacement isn't named after the Service
+	name = l.id
+	l.stripDesiredState()
+	l.tags = o.CommonTags.Copy()
+	l.lb.desired = &elbv2.LoadBalancer{
+		AvailabilityZones: o.Annotations.NLB.Subnets.AsAvailabilityZones(),
+		LoadBalancerName:  aws.String(name),
+		Scheme:            o.Annotations.NLB.Scheme,
+		Type:              aws.String(elbv2.LoadBalancerTypeEnumNetwork),
+		VpcId:             vpc,
+	}
+
+	targetType := aws.StringValue(o.Annotations.TargetGroup.TargetType)
+	ports := make(map[int32]bool)
+	for _, port := range o.Service.Spec.Ports {
+		if ports[port.Port] {
+			return l, fmt.Errorf("port %d is used by more than one protocol, which is not supported by network load balancers", port.Port)
+		}
+		ports[port.Port] = true
+
+		lsProtocol, tgProtocol, err := protocols(port, o.Annotations)
+		if err != nil {
+			return l, err
+		}
+
+		backend := &extensions.IngressBackend{
+			ServiceName: o.Service.Name,
+			ServicePort: intstr.FromInt(int(port.Port)),
+		}
+		if port.Name != "" {
+			backend.ServicePort = intstr.FromString(port.Name)
+		}
+
+		t := l.targetGroup(createTGName(name, port.Port, tgProtocol, targetType, o.NamePrefix))
+		t.tags = o.CommonTags.Copy()
+		t.tags.Tags[tags.ServiceName] = o.Service.Name
+		t.tags.Tags[tags.ServicePort] = backend.ServicePort.String()
+		t.targets = tg.NewTargets(targetType, serviceIngress(o.Service), backend)
+		t.tg.desired = &elbv2.TargetGroup{
+			HealthCheckIntervalSeconds: o.Annotations.NLB.HealthCheckIntervalSeconds,
+			HealthCheckPath:            o.Annotations.NLB.HealthCheckPath,
+			HealthCheckPort:            o.Annotations.NLB.HealthCheckPort,
+			HealthCheckProtocol:        o.Annotations.NLB.HealthCheckProtocol,
+			HealthyThresholdCount:      o.Annotations.NLB.HealthCheckThresholdCount,
+			Port:                       aws.Int64(targetGroupDefaultPort),
+			Protocol:                   aws.String(tgProtocol),
+			TargetGroupName:            aws.String(t.id),
+			TargetType:                 aws.String(targetType),
+			// network load balancers require equal healthy and unhealthy thresholds
+			UnhealthyThresholdCount: o.Annotations.NLB.HealthCheckThresholdCount,
+		}
+
+		ls := l.listener(int64(port.Port))
+		ls.targetGroupID = t.id
+		ls.ls.desired = &elbv2.Listener{
+			Port:     aws.Int64(int64(port.Port)),
+			Protocol: aws.String(lsProtocol),
+		}
+		if lsProtocol == elbv2.ProtocolEnumTls {
+			ls.ls.desired.Certificates = []*elbv2.Certificate{{CertificateArn: o.Annotations.Listener.CertificateArn}}
+			ls.ls.desired.SslPolicy = o.Annotations.Listener.SslPolicy
+		}
+	}
+
+	return l, nil
+}
+
+// protocols returns the listener and target group protocols of a service port
+func protocols(port corev1.ServicePort, annos *annotations.Service) (string, string, error) {
+	switch port.Protocol {
+	case corev1.ProtocolUDP:
+		return elbv2.ProtocolEnumUdp, elbv2.ProtocolEnumUdp, nil
+	case corev1.ProtocolTCP, "":
+		if annos.Listener != nil && annos.Listener.CertificateArn != nil && annos.NLB.IsTLSPort(port.Name, port.Port) {
+			return elbv2.ProtocolEnumTls, elbv2.ProtocolEnumTcp, nil
+		}
+		return elbv2.ProtocolEnumTcp, elbv2.ProtocolEnumTcp, nil
+	}
+	return "", "", fmt.Errorf("protocol %v of port %d is not supported by network load balancers", port.Protocol, port.Port)
+}
+
+// serviceIngress returns an ingress in the namespace of the service, which the targets controller
+// uses to resolve the endpoints of the service.
+func serviceIngress(svc *corev1.Service) *extensions.Ingress {
+	ing := &extensions.Ingress{}
+	ing.Namespace = svc.Namespace
+	ing.Name = svc.Name
+	return ing
+}
+
+type newCurrentLoadBalancerOptions struct {
+	LoadBalancer *elbv2.LoadBalancer
+	TargetGroups []*elbv2.TargetGroup
+	Listeners    []*elbv2.Listener
+}
+
+// newCurrentLoadBalancer returns a loadBalancer based on the network load balancer in AWS
+func newCurrentLoadBalancer(o *newCurrentLoadBalancerOptions) *loadBalancer {
+	l := &loadBalancer{
+		id:   aws.StringValue(o.LoadBalancer.LoadBalancerName),
+		tags: &tags.Tags{},
+		lb:   lb{current: o.LoadBalancer},
+	}
+
+	for _, targetGroup := range o.TargetGroups {
+		t := l.targetGroup(aws.StringValue(targetGroup.TargetGroupName))
+		t.tg.current = targetGroup
+	}
+	for _, listener := range o.Listeners {
+		ls := l.listener(aws.Int64Value(listener.Port))
+		ls.ls.current = listener
+	}
+	return l
+}
+
+// targetGroup returns the target group with the given id, adding it when it is unknown
+func (l *loadBalancer) targetGroup(id string) *targetGroup {
+	for _, t := range l.targetGroups {
+		if t.id == id {
+			return t
+		}
+	}
+	t := &targetGroup{id: id}
+	l.targetGroups = append(l.targetGroups, t)
+	return t
+}
+
+// listener returns the listener of the given port, adding it when it is unknown
+func (l *loadBalancer) listener(port int64) *listener {
+	for _, ls := range l.listeners {
+		if ls.port == port {
+			return ls
+		}
+	}
+	ls := &listener{port: port}
+	l.listeners = append(l.listeners, ls)
+	return ls
+}
+
+func (l *loadBalancer) findTargetGroup(id string) *targetGroup {
+	for _, t := range l.targetGroups {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// Reconcile compares the current and desired state of the network load balancer, its target groups
+// and its listeners, and creates, modifies or deletes them in AWS.
+func (l *loadBalancer) Reconcile(ctx context.Context, rOpts *ReconcileOptions) []error {
+	var errors []error
+	lbc := l.lb.current
+	lbd := l.lb.desired
+
+	switch {
+	case lbd == nil: // lb should be deleted
+		if lbc == nil || l.deleted {
+			break
+		}
+		albctx.GetLogger(ctx).Infof("Start NLB deletion.")
+		if err := l.delete(ctx); err != nil {
+			return append(errors, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "%s deleted", *lbc.LoadBalancerName)
+		albctx.GetLogger(ctx).Infof("Completed NLB deletion. Name: %s | ARN: %s", *lbc.LoadBalancerName, *lbc.LoadBalancerArn)
+
+	case lbc == nil || l.deleted: // lb doesn't exist and should be created
+		if l.replaced != nil {
+			albctx.GetLogger(ctx).Infof("Start NLB creation, replacing %s.", *l.replaced.lb.current.LoadBalancerName)
+		} else {
+			albctx.GetLogger(ctx).Infof("Start NLB creation.")
+		}
+		if err := l.create(ctx); err != nil {
+			return append(errors, err)
+		}
+		lbc = l.lb.current
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "%s created", *lbc.LoadBalancerName)
+		albctx.GetLogger(ctx).Infof("Completed NLB creation. Name: %s | ARN: %s", *lbc.LoadBalancerName, *lbc.LoadBalancerArn)
+
+	default: // check for diff between lb current and desired, modify if necessary
+		if err := l.modify(ctx); err != nil {
+			return append(errors, err)
+		}
+	}
+
+	if l.deleted {
+		// Listeners are deleted with the load balancer, leaving only the target groups to remove.
+		for _, ls := range l.listeners {
+			ls.ls.current = nil
+		}
+	} else {
+		for _, t := range l.targetGroups {
+			if t.tg.desired == nil {
+				continue
+			}
+			if err := t.reconcile(ctx, rOpts); err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+
+	for _, ls := range l.listeners {
+		if err := ls.reconcile(ctx, l); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	for _, t := range l.targetGroups {
+		if t.tg.desired != nil || t.tg.current == nil {
+			continue
+		}
+		if err := t.delete(ctx); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	l.removeDeleted()
+
+	errors = append(errors, l.reconcileReplaced(ctx, rOpts)...)
+
+	if !l.deleted {
+		l.tags.Arn = aws.StringValue(l.lb.current.LoadBalancerArn)
+		if err := rOpts.TagsController.Reconcile(ctx, l.tags); err != nil {
+			errors = append(errors, fmt.Errorf("failed tagging due to %s", err.Error()))
+		}
+	}
+
+	return errors
+}
+
+// reconcileReplaced deletes the load balancer replaced by this load balancer once this load balancer is
+// active and the grace period elapsed. It is deleted right away when this load balancer is deleted.
+func (l *loadBalancer) reconcileReplaced(ctx context.Context, rOpts *ReconcileOptions) []error {
+	if l.replaced == nil {
+		return nil
+	}
+
+	if l.lb.desired != nil {
+		if l.deleted {
+			return nil
+		}
+		elapsed, err := l.replacement.Elapsed(ctx, l.lb.current, l.replaced.lb.current, rOpts.Store.GetConfig().NLBReplacementGracePeriod)
+		if err != nil {
+			return []error{err}
+		}
+		if !elapsed {
+			return nil
+		}
+	}
+
+	albctx.GetLogger(ctx).Infof("Start deletion of replaced NLB %s.", *l.replaced.lb.current.LoadBalancerName)
+	if errs := l.replaced.Reconcile(ctx, rOpts); len(errs) > 0 {
+		return errs
+	}
+	l.replaced = nil
+	l.replacement.Reset()
+	return nil
+}
+
+func (l *loadBalancer) create(ctx context.Context) error {
+	desired := l.lb.desired
+	in := &elbv2.CreateLoadBalancerInput{
+		Name:    desired.LoadBalancerName,
+		Subnets: util.AvailabilityZones(desired.AvailabilityZones).AsSubnets(),
+		Scheme:  desired.Scheme,
+		Type:    desired.Type,
+		Tags:    l.tags.AsELBV2(),
+	}
+
+	o, err := albelbv2.ELBV2svc.CreateLoadBalancer(in)
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error creating %s: %s", *in.Name, err.Error())
+		return fmt.Errorf("Failed NLB creation: %s", err.Error())
+	}
+	l.lb.current = o.LoadBalancers[0]
+	l.deleted = false
+	return nil
+}
+
+func (l *loadBalancer) delete(ctx context.Context) error {
+	in := &elbv2.DeleteLoadBalancerInput{
+		LoadBalancerArn: l.lb.current.LoadBalancerArn,
+	}
+	if _, err := albelbv2.ELBV2svc.DeleteLoadBalancer(in); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error deleting %s: %s", *l.lb.current.LoadBalancerName, err.Error())
+		return fmt.Errorf("Failed NLB deletion: %s", err.Error())
+	}
+	l.deleted = true
+	return nil
+}
+
+// modify sets the subnets of the load balancer. Its scheme can't be modified, a new load balancer
+// replaces it, see newDesiredLoadBalancer.
+func (l *loadBalancer) modify(ctx context.Context) error {
+	clb := l.lb.current
+	if !util.DeepEqual(clb.Scheme, l.lb.desired.Scheme) {
+		// the load balancer is replaced once the previous replacement completed
+		albctx.GetLogger(ctx).Infof("Replacement of %s waits for the previous replacement to complete.", *clb.LoadBalancerName)
+	}
+
+	if l.subnetsModified(ctx) {
+		if _, err := albelbv2.ELBV2svc.SetSubnets(&elbv2.SetSubnetsInput{
+			LoadBalancerArn: clb.LoadBalancerArn,
+			Subnets:         util.AvailabilityZones(l.lb.desired.AvailabilityZones).AsSubnets(),
+		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%s subnet modification failed: %s", *clb.LoadBalancerName, err.Error())
+			return fmt.Errorf("Failed setting NLB subnets: %s", err.Error())
+		}
+		clb.AvailabilityZones = l.lb.desired.AvailabilityZones
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "%s subnets modified", *clb.LoadBalancerName)
+	}
+	return nil
+}
+
+// subnetsModified returns true if the subnets of the load balancer changed
+func (l *loadBalancer) subnetsModified(ctx context.Context) bool {
+	clb := l.lb.current
+	dlb := l.lb.desired
+
+	currentSubnets := util.AvailabilityZones(clb.AvailabilityZones).AsSubnets()
+	desiredSubnets := util.AvailabilityZones(dlb.AvailabilityZones).AsSubnets()
+	sort.Sort(currentSubnets)
+	sort.Sort(desiredSubnets)
+	if log.Prettify(currentSubnets) != log.Prettify(desiredSubnets) {
+		albctx.GetLogger(ctx).Debugf("AvailabilityZones needs to be changed (%v != %v)", log.Prettify(currentSubnets), log.Prettify(desiredSubnets))
+		return true
+	}
+	return false
+}
+
+// removeDeleted drops the listeners and target groups which exist neither in AWS nor in the Service
+func (l *loadBalancer) removeDeleted() {
+	var listeners []*listener
+	for _, ls := range l.listeners {
+		if ls.ls.current != nil || ls.ls.desired != nil {
+			listeners = append(listeners, ls)
+		}
+	}
+	l.listeners = listeners
+
+	var targetGroups []*targetGroup
+	for _, t := range l.targetGroups {
+		if t.tg.current != nil || t.tg.desired != nil {
+			targetGroups = append(targetGroups, t)
+		}
+	}
+	l.targetGroups = targetGroups
+}
+
+// stripDesiredState removes the desired state of the load balancer, its listeners and target groups
+func (l *loadBalancer) stripDesiredState() {
+	l.lb.desired = nil
+	for _, ls := range l.listeners {
+		ls.ls.desired = nil
+		ls.targetGroupID = ""
+	}
+	for _, t := range l.targetGroups {
+		t.tg.desired = nil
+		t.targets = nil
+	}
+}
+
+// Hostname returns the AWS hostname of the load balancer. The hostname of a replaced load balancer is
+// returned until its replacement is active.
+func (l *loadBalancer) Hostname() *string {
+	if l.replaced != nil && (l.deleted || !replacement.Active(l.lb.current)) {
+		return l.replaced.Hostname()
+	}
+	if l.lb.current == nil || l.deleted {
+		return nil
+	}
+	return l.lb.current.DNSName
+}
+
+// assembleReplacement returns the most recently created of two load balancers of a Service, replacing
+// the other one. Both are found in AWS when the controller restarted during a replacement.
+func assembleReplacement(a *loadBalancer, b *loadBalancer) *loadBalancer {
+	if replacement.Newer(b.lb.current, a.lb.current) {
+		a, b = b, a
+	}
+	last := a
+	for last.replaced != nil {
+		last = last.replaced
+	}
+	last.replaced = b
+	last.replacement.Resume()
+	return a
+}
+
+func (t *targetGroup) reconcile(ctx context.Context, rOpts *ReconcileOptions) error {
+	switch {
+	case t.tg.current == nil:
+		if err := t.create(ctx); err != nil {
+			return err
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "%s target group created", t.id)
+	case t.needsModification(ctx):
+		if err := t.modify(ctx); err != nil {
+			return err
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "%s target group modified", t.id)
+	}
+
+	t.targets.TgArn = aws.StringValue(t.tg.current.TargetGroupArn)
+	if err := rOpts.TgTargetsController.Reconcile(ctx, t.targets); err != nil {
+		return fmt.Errorf("failed configuration of target group targets due to %s", err.Error())
+	}
+	t.tags.Arn = aws.StringValue(t.tg.current.TargetGroupArn)
+	if err := rOpts.TagsController.Reconcile(ctx, t.tags); err != nil {
+		return fmt.Errorf("failed configuration of target group tags due to %s", err.Error())
+	}
+	return nil
+}
+
+func (t *targetGroup) create(ctx context.Context) error {
+	desired := t.tg.desired
+	vpc, err := albec2.EC2svc.GetVPCID()
+	if err != nil {
+		return err
+	}
+	o, err := albelbv2.ELBV2svc.CreateTargetGroup(&elbv2.CreateTargetGroupInput{
+		HealthCheckIntervalSeconds: desired.HealthCheckIntervalSeconds,
+		HealthCheckPath:            desired.HealthCheckPath,
+		HealthCheckPort:            desired.HealthCheckPort,
+		HealthCheckProtocol:        desired.HealthCheckProtocol,
+		HealthyThresholdCount:      desired.HealthyThresholdCount,
+		Name:                       desired.TargetGroupName,
+		Port:                       desired.Port,
+		Protocol:                   desired.Protocol,
+		TargetType:                 desired.TargetType,
+		UnhealthyThresholdCount:    desired.UnhealthyThresholdCount,
+		VpcId:                      vpc,
+	})
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error creating target group %s: %s", t.id, err.Error())
+		return fmt.Errorf("Failed TargetGroup creation: %s.", err.Error())
+	}
+	t.tg.current = o.TargetGroups[0]
+	return nil
+}
+
+func (t *targetGroup) modify(ctx context.Context) error {
+	desired := t.tg.desired
+	o, err := albelbv2.ELBV2svc.ModifyTargetGroup(&elbv2.ModifyTargetGroupInput{
+		HealthCheckIntervalSeconds: desired.HealthCheckIntervalSeconds,
+		HealthCheckPath:            desired.HealthCheckPath,
+		HealthCheckPort:            desired.HealthCheckPort,
+		HealthCheckProtocol:        desired.HealthCheckProtocol,
+		HealthyThresholdCount:      desired.HealthyThresholdCount,
+		TargetGroupArn:             t.tg.current.TargetGroupArn,
+		UnhealthyThresholdCount:    desired.UnhealthyThresholdCount,
+	})
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error modifying target group %s: %s", t.id, err.Error())
+		return fmt.Errorf("Failed TargetGroup modification. ARN: %s | Error: %s", *t.tg.current.TargetGroupArn, err.Error())
+	}
+	t.tg.current = o.TargetGroups[0]
+	return nil
+}
+
+func (t *targetGroup) delete(ctx context.Context) error {
+	if err := albelbv2.ELBV2svc.RemoveTargetGroup(t.tg.current.TargetGroupArn); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error deleting %v target group: %s", t.id, err.Error())
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "%v target group deleted", t.id)
+	t.tg.current = nil
+	return nil
+}
+
+func (t *targetGroup) needsModification(ctx context.Context) bool {
+	ctg := t.tg.current
+	dtg := t.tg.desired
+
+	for _, f := range []struct {
+		name             string
+		current, desired interface{}
+	}{
+		{"HealthCheckIntervalSeconds", ctg.HealthCheckIntervalSeconds, dtg.HealthCheckIntervalSeconds},
+		{"HealthCheckPath", ctg.HealthCheckPath, dtg.HealthCheckPath},
+		{"HealthCheckPort", ctg.HealthCheckPort, dtg.HealthCheckPort},
+		{"HealthCheckProtocol", ctg.HealthCheckProtocol, dtg.HealthCheckProtocol},
+		{"HealthyThresholdCount", ctg.HealthyThresholdCount, dtg.HealthyThresholdCount},
+		{"UnhealthyThresholdCount", ctg.UnhealthyThresholdCount, dtg.UnhealthyThresholdCount},
+	} {
+		if !util.DeepEqual(f.current, f.desired) {
+			albctx.GetLogger(ctx).Debugf("%s needs to be changed (%v != %v)", f.name, log.Prettify(f.current), log.Prettify(f.desired))
+			return true
+		}
+	}
+	return false
+}
+
+func (ls *listener) reconcile(ctx context.Context, l *loadBalancer) error {
+	switch {
+	case ls.ls.desired == nil:
+		if ls.ls.current == nil {
+			return nil
+		}
+		if err := albelbv2.ELBV2svc.RemoveListener(ls.ls.current.ListenerArn); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error deleting %v listener: %s", ls.port, err.Error())
+			return fmt.Errorf("Failed Listener deletion. ARN: %s: %s", *ls.ls.current.ListenerArn, err.Error())
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "%v listener deleted", ls.port)
+		ls.ls.current = nil
+		return nil
+	}
+
+	t := l.findTargetGroup(ls.targetGroupID)
+	if t == nil || t.tg.current == nil {
+		return fmt.Errorf("target group %s of the %v listener is not available", ls.targetGroupID, ls.port)
+	}
+	ls.ls.desired.DefaultActions = []*elbv2.Action{{
+		Type:           aws.String(elbv2.ActionTypeEnumForward),
+		TargetGroupArn: t.tg.current.TargetGroupArn,
+	}}
+
+	desired := ls.ls.desired
+	switch {
+	case ls.ls.current == nil:
+		o, err := albelbv2.ELBV2svc.CreateListener(&elbv2.CreateListenerInput{
+			Certificates:    desired.Certificates,
+			DefaultActions:  desired.DefaultActions,
+			LoadBalancerArn: l.lb.current.LoadBalancerArn,
+			Port:            desired.Port,
+			Protocol:        desired.Protocol,
+			SslPolicy:       desired.SslPolicy,
+		})
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error creating %v listener: %s", ls.port, err.Error())
+			return fmt.Errorf("Failed Listener creation: %s", err.Error())
+		}
+		ls.ls.current = o.Listeners[0]
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "%v listener created", ls.port)
+
+	case ls.needsModification(ctx):
+		in := &elbv2.ModifyListenerInput{
+			DefaultActions: desired.DefaultActions,
+			ListenerArn:    ls.ls.current.ListenerArn,
+			Port:           desired.Port,
+			Protocol:       desired.Protocol,
+			SslPolicy:      desired.SslPolicy,
+		}
+		if desired.Certificates != nil {
+			in.Certificates = desired.Certificates
+		}
+		o, err := albelbv2.ELBV2svc.ModifyListener(in)
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error modifying %v listener: %s", ls.port, err.Error())
+			return fmt.Errorf("Failed Listener modification: %s", err.Error())
+		}
+		ls.ls.current = o.Listeners[0]
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "%v listener modified", ls.port)
+	}
+	return nil
+}
+
+func (ls *listener) needsModification(ctx context.Context) bool {
+	cl := ls.ls.current
+	dl := ls.ls.desired
+
+	if !util.DeepEqual(cl.Protocol, dl.Protocol) {
+		albctx.GetLogger(ctx).Debugf("Protocol needs to be changed (%v != %v)", log.Prettify(cl.Protocol), log.Prettify(dl.Protocol))
+		return true
+	}
+	if dl.Certificates != nil && !util.DeepEqual(cl.Certificates, dl.Certificates) {
+		albctx.GetLogger(ctx).Debugf("Certificates needs to be changed (%v != %v)", log.Prettify(cl.Certificates), log.Prettify(dl.Certificates))
+		return true
+	}
+	if dl.SslPolicy != nil && !util.DeepEqual(cl.SslPolicy, dl.SslPolicy) {
+		albctx.GetLogger(ctx).Debugf("SslPolicy needs to be changed (%v != %v)", log.Prettify(cl.SslPolicy), log.Prettify(dl.SslPolicy))
+		return true
+	}
+	if len(cl.DefaultActions) != 1 || !util.DeepEqual(cl.DefaultActions[0].TargetGroupArn, dl.DefaultActions[0].TargetGroupArn) {
+		albctx.GetLogger(ctx).Debugf("DefaultActions needs to be changed (%v != %v)", log.Prettify(cl.DefaultActions), log.Prettify(dl.DefaultActions))
+		return true
+	}
+	return false
+}
+
+// createLBName returns the name of the network load balancer of a Service. The hash covers the
+// kind of the resource so that an NLB never takes the name of the ALB of an ingress sharing the
+// namespace and name of the Service.
+func createLBName(namespace string, serviceName string, clustername string) string {
+	hasher := md5.New()
+	hasher.Write([]byte("service/" + namespace + "/" + serviceName))
+	hash := hex.EncodeToString(hasher.Sum(nil))[:4]
+
+	r, _ := regexp.Compile("[[:^alnum:]]")
+	name := fmt.Sprintf("%s-%s-%s",
+		r.ReplaceAllString(clustername, "-"),
+		r.ReplaceAllString(namespace, ""),
+		r.ReplaceAllString(serviceName, ""),
+	)
+	if len(name) > 26 {
+		name = name[:26]
+	}
+	return name + "-" + hash
+}
+
+// createTGName returns the name of the target group of a service port
+func createTGName(lbName string, port int32, protocol string, targetType string, prefix string) string {
+	hasher := md5.New()
+	hasher.Write([]byte(lbName))
+	hasher.Write([]byte(fmt.Sprintf("%d", port)))
+	hasher.Write([]byte(protocol))
+	hasher.Write([]byte(targetType))
+
+	return fmt.Sprintf("%.12s-%.19s", prefix, hex.EncodeToString(hasher.Sum(nil)))
+}
@@ -0,0 +1,257 @@
+package nlbservice
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/nlb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+)
+
+// NLBService contains the network load balancer provisioned for a Service of type LoadBalancer
+type NLBService struct {
+	id          string
+	namespace   string
+	name        string
+	service     *corev1.Service
+	annotations *annotations.Service
+	store       store.Storer
+	recorder    record.EventRecorder
+	logger      *log.Logger
+	lock        *sync.Mutex
+
+	loadBalancer *loadBalancer
+
+	valid      bool
+	reconciled bool
+}
+
+type newNLBServiceOptions struct {
+	Namespace string
+	Name      string
+	Service   *corev1.Service
+	Recorder  record.EventRecorder
+	Store     store.Storer
+}
+
+func newNLBService(o *newNLBServiceOptions) *NLBService {
+	id := o.Namespace + "/" + o.Name
+	return &NLBService{
+		id:        id,
+		namespace: o.Namespace,
+		name:      o.Name,
+		service:   o.Service,
+		store:     o.Store,
+		recorder:  o.Recorder,
+		logger:    log.New(id),
+		lock:      new(sync.Mutex),
+	}
+}
+
+// IsNLBService returns true if the Service asks for a network load balancer. Services are selected by
+// annotation, the in-tree cloud provider must ignore them, e.g. by their spec.loadBalancerClass.
+func IsNLBService(svc *corev1.Service) bool {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return false
+	}
+	lbType, err := parser.GetStringAnnotation("load-balancer-type", svc)
+	return err == nil && *lbType == nlb.LoadBalancerTypeNetwork
+}
+
+// NewNLBServiceFromServiceOptions are the options to NewNLBServiceFromService
+type NewNLBServiceFromServiceOptions struct {
+	Service         *corev1.Service
+	ExistingService *NLBService
+	Recorder        record.EventRecorder
+	Store           store.Storer
+}
+
+// NewNLBServiceFromService builds the desired network load balancer of a Service. The current state
+// of ExistingService, when set, is kept so it can be reconciled with the new desired state.
+func NewNLBServiceFromService(o *NewNLBServiceFromServiceOptions) (*NLBService, error) {
+	newService := newNLBService(&newNLBServiceOptions{
+		Namespace: o.Service.Namespace,
+		Name:      o.Service.Name,
+		Service:   o.Service,
+		Recorder:  o.Recorder,
+		Store:     o.Store,
+	})
+
+	if o.ExistingService != nil {
+		newService = o.ExistingService
+		newService.lock.Lock()
+		defer newService.lock.Unlock()
+		newService.service = o.Service
+		if newService.loadBalancer != nil {
+			newService.loadBalancer.stripDesiredState()
+		}
+	}
+	newService.valid = false
+	newService.reconciled = false
+
+	var err error
+	newService.annotations, err = o.Store.GetServiceAnnotations(k8s.MetaNamespaceKey(o.Service), nil)
+	if err != nil {
+		return newService, fmt.Errorf("error getting annotations: %s", err.Error())
+	}
+	if newService.annotations.Error != nil {
+		return newService, fmt.Errorf("error parsing annotations: %s", newService.annotations.Error.Error())
+	}
+	if newService.annotations.NLB == nil {
+		return newService, fmt.Errorf("error parsing annotations: network load balancer settings are missing")
+	}
+
+	lbTags := tags.NewTags(newService.Tags())
+	if newService.annotations.Tags != nil {
+		for k, v := range newService.annotations.Tags.LoadBalancer {
+			lbTags.Tags[k] = v
+		}
+	}
+
+	lb, err := newDesiredLoadBalancer(&newDesiredLoadBalancerOptions{
+		Service:              o.Service,
+		Annotations:          newService.annotations,
+		CommonTags:           lbTags,
+		NamePrefix:           o.Store.GetConfig().ALBNamePrefix,
+		ExistingLoadBalancer: newService.loadBalancer,
+	})
+	if lb != nil {
+		newService.loadBalancer = lb
+	}
+	if err != nil {
+		if newService.loadBalancer != nil {
+			// keep the load balancer untouched until the Service is fixed
+			newService.loadBalancer.stripDesiredState()
+		}
+		return newService, fmt.Errorf("error instantiating load balancer: %s", err.Error())
+	}
+
+	newService.valid = true
+	return newService, nil
+}
+
+// NewNLBServiceFromAWSLoadBalancerOptions are the options to NewNLBServiceFromAWSLoadBalancer
+type NewNLBServiceFromAWSLoadBalancerOptions struct {
+	LoadBalancer *elbv2.LoadBalancer
+	Tags         util.ELBv2Tags
+	TargetGroups []*elbv2.TargetGroup
+	Listeners    []*elbv2.Listener
+	Recorder     record.EventRecorder
+	Store        store.Storer
+}
+
+// NewNLBServiceFromAWSLoadBalancer builds an NLBService based off of an existing network load balancer
+func NewNLBServiceFromAWSLoadBalancer(o *NewNLBServiceFromAWSLoadBalancerOptions) (*NLBService, error) {
+	namespace, _ := o.Tags.Get(tags.Namespace)
+	name, _ := o.Tags.Get(tags.ServiceName)
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("The LoadBalancer %s does not have the proper tags, can't import: %s and %s tags are required",
+			aws.StringValue(o.LoadBalancer.LoadBalancerName), tags.Namespace, tags.ServiceName)
+	}
+
+	s := newNLBService(&newNLBServiceOptions{
+		Namespace: namespace,
+		Name:      name,
+		Recorder:  o.Recorder,
+		Store:     o.Store,
+	})
+	s.loadBalancer = newCurrentLoadBalancer(&newCurrentLoadBalancerOptions{
+		LoadBalancer: o.LoadBalancer,
+		TargetGroups: o.TargetGroups,
+		Listeners:    o.Listeners,
+	})
+	s.valid = true
+	s.reconciled = true
+	s.logger.Infof("Service rebuilt from existing NLB in AWS")
+	return s, nil
+}
+
+// Reconcile begins the state sync for all AWS resources of the NLBService
+func (s *NLBService) Reconcile(ctx context.Context, rOpts *ReconcileOptions) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.valid || s.loadBalancer == nil {
+		return nil
+	}
+
+	errors := s.loadBalancer.Reconcile(ctx, rOpts)
+	if len(errors) > 0 {
+		s.reconciled = false
+		s.logger.Errorf("Failed to reconcile state on this service")
+		for _, err := range errors {
+			s.logger.Errorf(" - %s", err.Error())
+		}
+		return fmt.Errorf("Reconcile failed")
+	}
+	s.reconciled = true
+
+	if s.service != nil {
+		if err := s.updateStatus(); err != nil {
+			s.logger.Errorf("Failed to update the status of the service: %s", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// updateStatus publishes the hostname of the load balancer in the status of the Service
+func (s *NLBService) updateStatus() error {
+	var ingress []corev1.LoadBalancerIngress
+	if hostname := s.loadBalancer.Hostname(); hostname != nil {
+		ingress = append(ingress, corev1.LoadBalancerIngress{Hostname: *hostname})
+	}
+	if reflect.DeepEqual(s.service.Status.LoadBalancer.Ingress, ingress) {
+		return nil
+	}
+
+	cfg := s.store.GetConfig()
+	if cfg.Client == nil || cfg.DryRun {
+		return nil
+	}
+	client := cfg.Client
+
+	svc := s.service.DeepCopy()
+	svc.Status.LoadBalancer.Ingress = ingress
+	updated, err := client.CoreV1().Services(svc.Namespace).UpdateStatus(svc)
+	if err != nil {
+		return err
+	}
+	s.service = updated
+	return nil
+}
+
+// Eventf writes an event to the Kubernetes service resource
+func (s *NLBService) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	if s.recorder == nil || s.service == nil {
+		return
+	}
+	s.recorder.Eventf(s.service, eventtype, reason, messageFmt, args...)
+}
+
+// ID returns the namespace/name of the Service
+func (s *NLBService) ID() string {
+	return s.id
+}
+
+// Tags returns the standard tags of the AWS resources of the Service
+func (s *NLBService) Tags() map[string]string {
+	return map[string]string{
+		"kubernetes.io/cluster/" + s.store.GetConfig().ClusterName: "owned",
+		tags.Namespace:   s.namespace,
+		tags.ServiceName: s.name,
+	}
+}
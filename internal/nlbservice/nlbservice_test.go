@@ -0,0 +1,383 @@
+package nlbservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	lsannotations "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/listener"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/nlb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+)
+
+const certificateArn = "arn:aws:acm:us-west-2:123456789012:certificate/cert"
+
+func init() {
+	mockEC2 := &mocks.EC2API{}
+	mockEC2.On("GetVPCID").Return(aws.String("vpc-id"), nil)
+	albec2.EC2svc = mockEC2
+}
+
+type fakeTargetsController struct{ reconciled []*tg.Targets }
+
+func (c *fakeTargetsController) Reconcile(_ context.Context, t *tg.Targets) error {
+	c.reconciled = append(c.reconciled, t)
+	return nil
+}
+
+type fakeTagsController struct{}
+
+func (c *fakeTagsController) Reconcile(context.Context, *tags.Tags) error { return nil }
+
+func testContext() context.Context {
+	ctx := albctx.SetLogger(context.Background(), log.New("test"))
+	return albctx.SetEventf(ctx, func(string, string, string, ...interface{}) {})
+}
+
+func nlbService() *corev1.Service {
+	svc := dummy.NewService()
+	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	svc.Spec.Ports = []corev1.ServicePort{
+		{Name: "https", Port: 443, Protocol: corev1.ProtocolTCP, NodePort: 30443},
+		{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP, NodePort: 30053},
+	}
+	svc.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("load-balancer-type"): "nlb"})
+	return svc
+}
+
+func nlbStore(svc *corev1.Service) *store.Dummy {
+	s := store.NewDummy()
+	s.ListServicesFunc = func() []*corev1.Service { return []*corev1.Service{svc} }
+	s.GetServiceFunc = func(string) (*corev1.Service, error) { return svc, nil }
+	s.GetServiceAnnotationsResponse = &annotations.Service{
+		TargetGroup: targetgroup.Dummy(),
+		Listener:    &lsannotations.Config{CertificateArn: aws.String(certificateArn), SslPolicy: aws.String(lsannotations.DefaultSslPolicy)},
+		NLB: &nlb.Config{
+			Scheme:                     aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing),
+			Subnets:                    util.Subnets{aws.String("subnet-a"), aws.String("subnet-b")},
+			HealthCheckProtocol:        aws.String(nlb.DefaultHealthCheckProtocol),
+			HealthCheckPort:            aws.String(nlb.DefaultHealthCheckPort),
+			HealthCheckIntervalSeconds: aws.Int64(nlb.DefaultHealthCheckInterval),
+			HealthCheckThresholdCount:  aws.Int64(nlb.DefaultHealthCheckThreshold),
+		},
+	}
+	cfg := s.GetConfig()
+	cfg.ALBNamePrefix = "cluster1"
+	cfg.ClusterName = "cluster1"
+	s.SetConfig(cfg)
+	return s
+}
+
+// creatingELBV2 returns an ELBV2 mock creating the target groups and listeners of a load balancer
+func creatingELBV2() *mocks.ELBV2API {
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("CreateTargetGroup", mock.Anything).Return(func(in *elbv2.CreateTargetGroupInput) *elbv2.CreateTargetGroupOutput {
+		return &elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String("tg-arn-" + *in.Protocol), TargetGroupName: in.Name}}}
+	}, nil)
+	elbv2svc.On("CreateListener", mock.Anything).Return(func(in *elbv2.CreateListenerInput) *elbv2.CreateListenerOutput {
+		return &elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{{ListenerArn: aws.String("ls-arn"), Port: in.Port, Protocol: in.Protocol, DefaultActions: in.DefaultActions}}}
+	}, nil)
+	return elbv2svc
+}
+
+// existingNLBService returns the NLBService of svc, assembled from its internet-facing NLB in subnet-a
+func existingNLBService(svc *corev1.Service, st store.Storer) *NLBService {
+	s := newNLBService(&newNLBServiceOptions{Namespace: svc.Namespace, Name: svc.Name, Store: st})
+	s.loadBalancer = newCurrentLoadBalancer(&newCurrentLoadBalancerOptions{
+		LoadBalancer: &elbv2.LoadBalancer{
+			AvailabilityZones: []*elbv2.AvailabilityZone{{SubnetId: aws.String("subnet-a")}},
+			DNSName:           aws.String("old.elb.amazonaws.com"),
+			LoadBalancerArn:   aws.String("old-arn"),
+			LoadBalancerName:  aws.String(createLBName(svc.Namespace, svc.Name, "cluster1")),
+			Scheme:            aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing),
+			State:             &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)},
+		},
+	})
+	return s
+}
+
+func TestIsNLBService(t *testing.T) {
+	svc := nlbService()
+	if !IsNLBService(svc) {
+		t.Errorf("expected %v to ask for a network load balancer", svc.Name)
+	}
+	svc.Spec.Type = corev1.ServiceTypeNodePort
+	if IsNLBService(svc) {
+		t.Errorf("expected a NodePort service not to get a network load balancer")
+	}
+	if IsNLBService(dummy.NewService()) {
+		t.Errorf("expected a service without load-balancer-type not to get a network load balancer")
+	}
+}
+
+func TestNewNLBServiceFromService(t *testing.T) {
+	svc := nlbService()
+	s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{
+		Service: svc,
+		Store:   nlbStore(svc),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := s.loadBalancer
+	if name := aws.StringValue(l.lb.desired.LoadBalancerName); len(name) > 32 || name != createLBName(svc.Namespace, svc.Name, "cluster1") {
+		t.Errorf("unexpected load balancer name %v", name)
+	}
+	if aws.StringValue(l.lb.desired.Type) != elbv2.LoadBalancerTypeEnumNetwork {
+		t.Errorf("expected a network load balancer, got %v", aws.StringValue(l.lb.desired.Type))
+	}
+	if len(l.listeners) != 2 || len(l.targetGroups) != 2 {
+		t.Fatalf("expected 2 listeners and 2 target groups, got %d and %d", len(l.listeners), len(l.targetGroups))
+	}
+
+	https := l.listener(443).ls.desired
+	if aws.StringValue(https.Protocol) != elbv2.ProtocolEnumTls || aws.StringValue(https.Certificates[0].CertificateArn) != certificateArn {
+		t.Errorf("expected a TLS listener with certificate on port 443, got %v", https)
+	}
+	dns := l.listener(53)
+	if aws.StringValue(dns.ls.desired.Protocol) != elbv2.ProtocolEnumUdp || dns.ls.desired.Certificates != nil {
+		t.Errorf("expected a UDP listener on port 53, got %v", dns.ls.desired)
+	}
+	dnsTG := l.findTargetGroup(dns.targetGroupID)
+	if aws.StringValue(dnsTG.tg.desired.Protocol) != elbv2.ProtocolEnumUdp || dnsTG.targets.Backend.ServicePort.String() != "dns" {
+		t.Errorf("unexpected target group %v for the dns port", dnsTG.tg.desired)
+	}
+	if dnsTG.tags.Tags[tags.ServiceName] != svc.Name || dnsTG.targets.Ingress.Namespace != svc.Namespace {
+		t.Errorf("expected the target group to refer to service %v/%v", svc.Namespace, svc.Name)
+	}
+}
+
+func TestNewNLBServiceFromServiceInvalid(t *testing.T) {
+	svc := nlbService()
+	svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: "dns-tcp", Port: 53, Protocol: corev1.ProtocolTCP})
+	s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{
+		Service: svc,
+		Store:   nlbStore(svc),
+	})
+	if err == nil {
+		t.Errorf("expected an error for a port used by both TCP and UDP")
+	}
+	if s.valid {
+		t.Errorf("expected the service to be invalid")
+	}
+}
+
+func TestReconcileCreate(t *testing.T) {
+	svc := nlbService()
+	st := nlbStore(svc)
+	client := testclient.NewSimpleClientset(svc)
+	cfg := st.GetConfig()
+	cfg.Client = client
+	st.SetConfig(cfg)
+
+	s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{Service: svc, Store: st})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elbv2svc := creatingELBV2()
+	elbv2svc.On("CreateLoadBalancer", mock.MatchedBy(func(in *elbv2.CreateLoadBalancerInput) bool {
+		return aws.StringValue(in.Type) == elbv2.LoadBalancerTypeEnumNetwork && len(in.Subnets) == 2
+	})).Return(&elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{{
+		LoadBalancerArn:  aws.String("lb-arn"),
+		LoadBalancerName: aws.String("lb"),
+		DNSName:          aws.String("lb.elb.amazonaws.com"),
+	}}}, nil)
+	albelbv2.ELBV2svc = elbv2svc
+
+	targets := &fakeTargetsController{}
+	err = s.Reconcile(testContext(), &ReconcileOptions{
+		Store:               st,
+		TgTargetsController: targets,
+		TagsController:      &fakeTagsController{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elbv2svc.AssertNumberOfCalls(t, "CreateTargetGroup", 2)
+	elbv2svc.AssertCalled(t, "CreateListener", mock.MatchedBy(func(in *elbv2.CreateListenerInput) bool {
+		return aws.Int64Value(in.Port) == 53 && aws.StringValue(in.DefaultActions[0].TargetGroupArn) == "tg-arn-UDP"
+	}))
+	if len(targets.reconciled) != 2 {
+		t.Errorf("expected the targets of 2 target groups to be reconciled, got %d", len(targets.reconciled))
+	}
+
+	updated, err := client.CoreV1().Services(svc.Namespace).Get(svc.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Status.LoadBalancer.Ingress) != 1 || updated.Status.LoadBalancer.Ingress[0].Hostname != "lb.elb.amazonaws.com" {
+		t.Errorf("expected the service status to hold the NLB hostname, got %v", updated.Status.LoadBalancer.Ingress)
+	}
+}
+
+func TestReconcileRemoved(t *testing.T) {
+	existing := NLBServices{newNLBService(&newNLBServiceOptions{Namespace: "default", Name: "service1", Store: store.NewDummy()})}
+	existing[0].loadBalancer = newCurrentLoadBalancer(&newCurrentLoadBalancerOptions{
+		LoadBalancer: &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lb-arn"), LoadBalancerName: aws.String("lb")},
+		TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String("tg-arn"), TargetGroupName: aws.String("tg")}},
+		Listeners:    []*elbv2.Listener{{ListenerArn: aws.String("ls-arn"), Port: aws.Int64(80)}},
+	})
+
+	removed := existing.RemovedServices(nil)
+	if len(removed) != 1 {
+		t.Fatalf("expected 1 removed service, got %d", len(removed))
+	}
+
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("DeleteLoadBalancer", &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String("lb-arn")}).Return(&elbv2.DeleteLoadBalancerOutput{}, nil)
+	elbv2svc.On("RemoveTargetGroup", aws.String("tg-arn")).Return(nil)
+	albelbv2.ELBV2svc = elbv2svc
+
+	err := removed[0].Reconcile(testContext(), &ReconcileOptions{
+		TgTargetsController: &fakeTargetsController{},
+		TagsController:      &fakeTagsController{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	elbv2svc.AssertExpectations(t)
+	elbv2svc.AssertNotCalled(t, "RemoveListener", mock.Anything)
+	if len(removed[0].loadBalancer.targetGroups) != 0 || len(removed[0].loadBalancer.listeners) != 0 {
+		t.Errorf("expected listeners and target groups to be removed")
+	}
+	if again := existing.RemovedServices(nil); len(again) != 0 {
+		t.Errorf("expected a deleted load balancer not to be removed again")
+	}
+}
+
+func TestReconcileSubnets(t *testing.T) {
+	svc := nlbService()
+	st := nlbStore(svc)
+	cfg := st.GetConfig()
+	cfg.Client = testclient.NewSimpleClientset(svc)
+	st.SetConfig(cfg)
+
+	s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{Service: svc, ExistingService: existingNLBService(svc, st), Store: st})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elbv2svc := creatingELBV2()
+	elbv2svc.On("SetSubnets", mock.MatchedBy(func(in *elbv2.SetSubnetsInput) bool {
+		return aws.StringValue(in.LoadBalancerArn) == "old-arn" && len(in.Subnets) == 2
+	})).Return(&elbv2.SetSubnetsOutput{}, nil)
+	albelbv2.ELBV2svc = elbv2svc
+
+	err = s.Reconcile(testContext(), &ReconcileOptions{
+		Store:               st,
+		TgTargetsController: &fakeTargetsController{},
+		TagsController:      &fakeTagsController{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	elbv2svc.AssertNumberOfCalls(t, "SetSubnets", 1)
+	elbv2svc.AssertNotCalled(t, "DeleteLoadBalancer", mock.Anything)
+	elbv2svc.AssertNotCalled(t, "CreateLoadBalancer", mock.Anything)
+}
+
+func TestReconcileSchemeReplacement(t *testing.T) {
+	svc := nlbService()
+	st := nlbStore(svc)
+	st.GetServiceAnnotationsResponse.NLB.Scheme = aws.String(elbv2.LoadBalancerSchemeEnumInternal)
+	client := testclient.NewSimpleClientset(svc)
+	cfg := st.GetConfig()
+	cfg.Client = client
+	cfg.NLBReplacementGracePeriod = 0
+	st.SetConfig(cfg)
+
+	existing := existingNLBService(svc, st)
+	existing.loadBalancer.lb.current.AvailabilityZones = append(existing.loadBalancer.lb.current.AvailabilityZones,
+		&elbv2.AvailabilityZone{SubnetId: aws.String("subnet-b")})
+	s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{Service: svc, ExistingService: existing, Store: st})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := s.loadBalancer
+	if l.replaced == nil || l.replaced.lb.desired != nil || l.id == l.replaced.id {
+		t.Fatalf("expected a new load balancer replacing %v", l.replaced)
+	}
+
+	elbv2svc := creatingELBV2()
+	elbv2svc.On("CreateLoadBalancer", mock.MatchedBy(func(in *elbv2.CreateLoadBalancerInput) bool {
+		return aws.StringValue(in.Name) == l.id && aws.StringValue(in.Scheme) == elbv2.LoadBalancerSchemeEnumInternal
+	})).Return(&elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{{
+		DNSName:          aws.String("new.elb.amazonaws.com"),
+		LoadBalancerArn:  aws.String("new-arn"),
+		LoadBalancerName: aws.String(l.id),
+		State:            &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumProvisioning)},
+	}}}, nil)
+	elbv2svc.On("GetLoadBalancerByArn", "new-arn").Return(&elbv2.LoadBalancer{
+		State: &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumProvisioning)},
+	}, nil).Once()
+	elbv2svc.On("DeleteLoadBalancer", &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String("old-arn")}).Return(&elbv2.DeleteLoadBalancerOutput{}, nil)
+	albelbv2.ELBV2svc = elbv2svc
+
+	rOpts := &ReconcileOptions{
+		Store:               st,
+		TgTargetsController: &fakeTargetsController{},
+		TagsController:      &fakeTagsController{},
+	}
+	if err := s.Reconcile(testContext(), rOpts); err != nil {
+		t.Fatal(err)
+	}
+	elbv2svc.AssertNotCalled(t, "DeleteLoadBalancer", mock.Anything)
+	updated, err := client.CoreV1().Services(svc.Namespace).Get(svc.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Status.LoadBalancer.Ingress) != 1 || updated.Status.LoadBalancer.Ingress[0].Hostname != "old.elb.amazonaws.com" {
+		t.Errorf("expected the replaced NLB to serve until its replacement is active, got %v", updated.Status.LoadBalancer.Ingress)
+	}
+
+	elbv2svc.On("GetLoadBalancerByArn", "new-arn").Return(&elbv2.LoadBalancer{
+		State: &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)},
+	}, nil)
+	if errs := l.reconcileReplaced(testContext(), rOpts); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	elbv2svc.AssertCalled(t, "DeleteLoadBalancer", &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String("old-arn")})
+	if l.replaced != nil || aws.StringValue(l.Hostname()) != "new.elb.amazonaws.com" {
+		t.Errorf("expected the replaced NLB to be deleted once its replacement is active")
+	}
+}
+
+func TestMergeReplacements(t *testing.T) {
+	service := func(arn string, created time.Time) *NLBService {
+		s := newNLBService(&newNLBServiceOptions{Namespace: "default", Name: "service1", Store: store.NewDummy()})
+		s.loadBalancer = newCurrentLoadBalancer(&newCurrentLoadBalancerOptions{
+			LoadBalancer: &elbv2.LoadBalancer{LoadBalancerArn: aws.String(arn), LoadBalancerName: aws.String(arn), CreatedTime: aws.Time(created)},
+		})
+		return s
+	}
+	now := time.Now()
+	merged := mergeReplacements(NLBServices{service("new", now), service("old", now.Add(-time.Hour))})
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 service, got %d", len(merged))
+	}
+	if l := merged[0].loadBalancer; l.id != "new" || l.replaced == nil || l.replaced.id != "old" {
+		t.Errorf("expected the most recent NLB to replace the other one, got %v", l)
+	}
+}
@@ -0,0 +1,208 @@
+package nlbservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	pool "gopkg.in/go-playground/pool.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albelbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws/albrgt"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+// NLBServices is a slice of NLBService
+type NLBServices []*NLBService
+
+// NewNLBServicesFromServicesOptions are the options to NewNLBServicesFromServices
+type NewNLBServicesFromServicesOptions struct {
+	Recorder    record.EventRecorder
+	Store       store.Storer
+	NLBServices NLBServices
+	Metric      metric.Collector
+}
+
+// NewNLBServicesFromServices returns the NLBServices of every Service of type LoadBalancer asking
+// for a network load balancer.
+func NewNLBServicesFromServices(o *NewNLBServicesFromServicesOptions) NLBServices {
+	var services NLBServices
+	for _, svc := range o.Store.ListServices() {
+		if !IsNLBService(svc) {
+			continue
+		}
+
+		_, existingService := o.NLBServices.FindByID(k8s.MetaNamespaceKey(svc))
+		s, err := NewNLBServiceFromService(&NewNLBServiceFromServiceOptions{
+			Service:         svc,
+			ExistingService: existingService,
+			Recorder:        o.Recorder,
+			Store:           o.Store,
+		})
+		if err != nil {
+			s.Eventf(corev1.EventTypeWarning, "ERROR", err.Error())
+			s.logger.Errorf(err.Error())
+			o.Metric.IncReconcileErrorCount(s.ID())
+		}
+		services = append(services, s)
+	}
+	return services
+}
+
+// AssembleServicesFromAWSOptions are the options to AssembleServicesFromAWS
+type AssembleServicesFromAWSOptions struct {
+	Store    store.Storer
+	Recorder record.EventRecorder
+}
+
+// AssembleServicesFromAWS builds the list of existing services from the network load balancers in AWS
+func AssembleServicesFromAWS(o *AssembleServicesFromAWSOptions) NLBServices {
+	glog.Infof("Building list of existing NLBs")
+	t0 := time.Now()
+
+	loadBalancers, err := albelbv2.ELBV2svc.ClusterNetworkLoadBalancers()
+	if err != nil {
+		glog.Fatal(err.Error())
+	}
+
+	resources, err := albrgt.RGTsvc.GetClusterResources()
+	if err != nil {
+		glog.Fatal(err.Error())
+	}
+
+	var services NLBServices
+	for _, loadBalancer := range loadBalancers {
+		lbTags := resources.NetworkLoadBalancers[*loadBalancer.LoadBalancerArn]
+		if _, ok := lbTags.Get(tags.ServiceName); !ok {
+			// not provisioned for a Service
+			continue
+		}
+
+		var targetGroups []*elbv2.TargetGroup
+		err := albelbv2.ELBV2svc.DescribeTargetGroupsPages(&elbv2.DescribeTargetGroupsInput{
+			LoadBalancerArn: loadBalancer.LoadBalancerArn,
+		}, func(page *elbv2.DescribeTargetGroupsOutput, _ bool) bool {
+			targetGroups = append(targetGroups, page.TargetGroups...)
+			return true
+		})
+		if err != nil {
+			glog.Fatal(err.Error())
+		}
+
+		listeners, err := albelbv2.ELBV2svc.DescribeListenersForLoadBalancer(loadBalancer.LoadBalancerArn)
+		if err != nil {
+			glog.Fatal(err.Error())
+		}
+
+		s, err := NewNLBServiceFromAWSLoadBalancer(&NewNLBServiceFromAWSLoadBalancerOptions{
+			LoadBalancer: loadBalancer,
+			Tags:         lbTags,
+			TargetGroups: targetGroups,
+			Listeners:    listeners,
+			Recorder:     o.Recorder,
+			Store:        o.Store,
+		})
+		if err != nil {
+			glog.Error(err.Error())
+			continue
+		}
+		services = append(services, s)
+	}
+	services = mergeReplacements(services)
+
+	glog.Infof("Assembled %d services from existing AWS resources in %v", len(services), time.Now().Sub(t0))
+	return services
+}
+
+// mergeReplacements merges the NLBServices assembled from load balancers of the same Service, left behind
+// by a replacement in progress. The most recently created load balancer replaces the others.
+func mergeReplacements(services NLBServices) NLBServices {
+	var merged NLBServices
+	for _, s := range services {
+		_, existing := merged.FindByID(s.id)
+		if existing == nil {
+			merged = append(merged, s)
+			continue
+		}
+		existing.loadBalancer = assembleReplacement(existing.loadBalancer, s.loadBalancer)
+		existing.logger.Infof("Replacement of NLB %s in progress", existing.loadBalancer.id)
+	}
+	return merged
+}
+
+// FindByID locates the service by the id parameter and returns its position
+func (a NLBServices) FindByID(id string) (int, *NLBService) {
+	for p, v := range a {
+		if v.id == id {
+			return p, v
+		}
+	}
+	return -1, nil
+}
+
+// RemovedServices compares the service list to the service list in the type, returning any services
+// that are not in the service list parameter, with their desired state stripped.
+func (a NLBServices) RemovedServices(newList NLBServices) NLBServices {
+	var deleteableServices NLBServices
+	for _, s := range a {
+		if i, _ := newList.FindByID(s.id); i >= 0 {
+			continue
+		}
+		if s.loadBalancer == nil || (s.loadBalancer.deleted && s.loadBalancer.replaced == nil) {
+			continue
+		}
+		s.loadBalancer.stripDesiredState()
+		s.service = nil
+		s.valid = true
+		deleteableServices = append(deleteableServices, s)
+	}
+	return deleteableServices
+}
+
+// Reconcile syncs the desired state to the current state
+func (a NLBServices) Reconcile(m metric.Collector, tgTargetsController tg.TargetsController, tagsController tags.Controller) {
+	p := pool.NewLimited(20)
+	defer p.Close()
+
+	batch := p.Batch()
+
+	for _, s := range a {
+		batch.Queue(func(s *NLBService) pool.WorkFunc {
+			return func(wu pool.WorkUnit) (interface{}, error) {
+				if wu.IsCancelled() {
+					return nil, nil
+				}
+
+				ctx := context.Background()
+				ctx = albctx.SetEventf(ctx, s.Eventf)
+				ctx = albctx.SetLogger(ctx, log.New(s.id))
+				err := s.Reconcile(ctx, &ReconcileOptions{
+					Store:               s.store,
+					TgTargetsController: tgTargetsController,
+					TagsController:      tagsController,
+				})
+				if err != nil {
+					m.IncReconcileErrorCount(s.ID())
+				}
+				return nil, err
+			}
+		}(s))
+	}
+
+	batch.QueueComplete()
+	for e := range batch.Results() {
+		err := e.Error()
+		if _, ok := err.(*pool.ErrRecovery); ok {
+			glog.Fatal(err.Error())
+		}
+	}
+}
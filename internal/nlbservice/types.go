@@ -0,0 +1,63 @@
+package nlbservice
+
+import (
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/replacement"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+)
+
+// loadBalancer contains the network load balancer of a Service with its listeners and target groups
+type loadBalancer struct {
+	id           string
+	lb           lb
+	tags         *tags.Tags
+	listeners    []*listener
+	targetGroups []*targetGroup
+
+	// replaced is the load balancer this load balancer replaces, e.g. after a scheme change. It keeps
+	// serving the Service until this load balancer is active and the grace period of the replacement passed.
+	replaced    *loadBalancer
+	replacement replacement.Replacement
+
+	deleted bool // flag representing the load balancer was fully deleted.
+}
+
+type lb struct {
+	current *elbv2.LoadBalancer // current version of load balancer in AWS
+	desired *elbv2.LoadBalancer // desired version of load balancer in AWS
+}
+
+// listener is a TCP, UDP or TLS listener forwarding a service port to its target group
+type listener struct {
+	port          int64
+	targetGroupID string
+	ls            ls
+}
+
+type ls struct {
+	current *elbv2.Listener // current version of listener in AWS
+	desired *elbv2.Listener // desired version of listener in AWS
+}
+
+// targetGroup holds the targets of a single service port
+type targetGroup struct {
+	id      string
+	tags    *tags.Tags
+	targets *tg.Targets
+	tg      tgs
+}
+
+type tgs struct {
+	current *elbv2.TargetGroup // current version of target group in AWS
+	desired *elbv2.TargetGroup // desired version of target group in AWS
+}
+
+// ReconcileOptions are the controllers used to reconcile a network load balancer
+type ReconcileOptions struct {
+	Store               store.Storer
+	TgTargetsController tg.TargetsController
+	TagsController      tags.Controller
+}
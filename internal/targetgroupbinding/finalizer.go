@@ -0,0 +1,107 @@
+package targetgroupbinding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+)
+
+// Finalizer is added to every valid TargetGroupBinding before its targets are registered. It is removed
+// once the targets of the binding were deregistered, so they can't be left behind when the binding is
+// deleted while the controller is not running.
+const Finalizer = "elbv2.k8s.aws/finalizer"
+
+// hasFinalizer returns true if the TargetGroupBinding holds the controller finalizer
+func hasFinalizer(binding *v1alpha1.TargetGroupBinding) bool {
+	for _, f := range binding.Finalizers {
+		if f == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeleting returns true if the TargetGroupBinding was deleted and is only kept until its finalizers are removed
+func isDeleting(binding *v1alpha1.TargetGroupBinding) bool {
+	return binding.DeletionTimestamp != nil
+}
+
+// addFinalizer adds the controller finalizer to the TargetGroupBinding
+func addFinalizer(cfg *config.Configuration, binding *v1alpha1.TargetGroupBinding) error {
+	if hasFinalizer(binding) {
+		return nil
+	}
+	return updateFinalizers(cfg, binding, func(current *unstructured.Unstructured) bool {
+		finalizers := current.GetFinalizers()
+		for _, f := range finalizers {
+			if f == Finalizer {
+				return false
+			}
+		}
+		if current.GetDeletionTimestamp() != nil {
+			return false
+		}
+		current.SetFinalizers(append(finalizers, Finalizer))
+		return true
+	})
+}
+
+// removeFinalizer removes the controller finalizer from the TargetGroupBinding, if it still exists
+func removeFinalizer(cfg *config.Configuration, binding *v1alpha1.TargetGroupBinding) error {
+	return updateFinalizers(cfg, binding, func(current *unstructured.Unstructured) bool {
+		var finalizers []string
+		for _, f := range current.GetFinalizers() {
+			if f != Finalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		if len(finalizers) == len(current.GetFinalizers()) {
+			return false
+		}
+		current.SetFinalizers(finalizers)
+		return true
+	})
+}
+
+// updateFinalizers applies update to the latest version of the TargetGroupBinding, retrying on conflicts.
+// update returns false when the TargetGroupBinding doesn't need to be updated. Only its finalizers are patched.
+func updateFinalizers(cfg *config.Configuration, binding *v1alpha1.TargetGroupBinding, update func(*unstructured.Unstructured) bool) error {
+	if cfg == nil || cfg.DynamicClient == nil {
+		return fmt.Errorf("no kubernetes client configured")
+	}
+	client := cfg.DynamicClient.Resource(v1alpha1.TargetGroupBindingResource).Namespace(binding.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := client.Get(binding.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if current.GetUID() != binding.UID || !update(current) {
+			return nil
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"finalizers":      current.GetFinalizers(),
+				"resourceVersion": current.GetResourceVersion(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = client.Patch(current.GetName(), types.MergePatchType, patch)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
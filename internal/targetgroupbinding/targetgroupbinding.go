@@ -0,0 +1,268 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+// TargetGroupBinding contains the targets of a target group managed outside of the controller. The load
+// balancer, listeners, rules and the target group itself are never modified.
+type TargetGroupBinding struct {
+	id       string
+	binding  *v1alpha1.TargetGroupBinding
+	targets  *tg.Targets
+	recorder record.EventRecorder
+	logger   *log.Logger
+
+	valid    bool
+	deleted  bool // flag representing the TargetGroupBinding was removed and its targets must be deregistered.
+	deleting bool // flag representing the TargetGroupBinding is kept by the finalizer until its targets are deregistered.
+}
+
+// NewTargetGroupBindingOptions are the options to NewTargetGroupBinding
+type NewTargetGroupBindingOptions struct {
+	Binding         *v1alpha1.TargetGroupBinding
+	ExistingBinding *TargetGroupBinding
+	Recorder        record.EventRecorder
+}
+
+// NewTargetGroupBinding validates a TargetGroupBinding resource and builds the targets of its target group
+func NewTargetGroupBinding(o *NewTargetGroupBindingOptions) (*TargetGroupBinding, error) {
+	id := o.Binding.Namespace + "/" + o.Binding.Name
+	b := &TargetGroupBinding{
+		id:       id,
+		binding:  o.Binding,
+		recorder: o.Recorder,
+		logger:   log.New(id),
+		deleting: isDeleting(o.Binding),
+	}
+
+	spec := o.Binding.Spec
+	if err := validate(spec); err != nil {
+		if o.ExistingBinding != nil {
+			// keep the targets registered until the TargetGroupBinding is fixed
+			b.targets = o.ExistingBinding.targets
+		}
+		return b, err
+	}
+
+	targetType := spec.TargetType
+	if targetType == "" && o.ExistingBinding != nil && o.ExistingBinding.targets != nil && o.ExistingBinding.targets.TgArn == spec.TargetGroupARN {
+		// reuse the target type already read from the target group
+		targetType = o.ExistingBinding.targets.TargetType
+	}
+
+	// the endpoint resolver looks up the Service of an ingress backend, in the namespace of the ingress
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Binding.Namespace,
+			Name:      o.Binding.Name,
+		},
+	}
+	backend := &extensions.IngressBackend{
+		ServiceName: spec.ServiceRef.Name,
+		ServicePort: spec.ServiceRef.Port,
+	}
+	b.targets = tg.NewTargets(targetType, ingress, backend)
+	b.targets.TgArn = spec.TargetGroupARN
+	if o.ExistingBinding != nil && o.ExistingBinding.targets != nil && o.ExistingBinding.targets.TgArn == spec.TargetGroupARN {
+		// keep track of the registered targets, in case the binding is removed before its next reconciliation
+		b.targets.Targets = o.ExistingBinding.targets.Targets
+	}
+	b.valid = true
+	return b, nil
+}
+
+func validate(spec v1alpha1.TargetGroupBindingSpec) error {
+	if spec.TargetGroupARN == "" {
+		return fmt.Errorf("targetGroupARN is required")
+	}
+	if spec.ServiceRef.Name == "" {
+		return fmt.Errorf("serviceRef.name is required")
+	}
+	if spec.TargetType != "" && spec.TargetType != elbv2.TargetTypeEnumInstance && spec.TargetType != elbv2.TargetTypeEnumIp {
+		return fmt.Errorf("targetType must be %s or %s, got %s", elbv2.TargetTypeEnumInstance, elbv2.TargetTypeEnumIp, spec.TargetType)
+	}
+	return nil
+}
+
+// ReconcileOptions are the options to Reconcile
+type ReconcileOptions struct {
+	Config              *config.Configuration
+	ELBV2               elbv2iface.ELBV2API
+	TgTargetsController tg.TargetsController
+	EndpointResolver    backend.EndpointResolver
+}
+
+// Reconcile registers the endpoints of the Service in the target group, or deregisters the targets it
+// registered once the TargetGroupBinding was removed.
+func (b *TargetGroupBinding) Reconcile(ctx context.Context, rOpts *ReconcileOptions) error {
+	if b.deleting {
+		return b.reconcileDeleting(ctx, rOpts)
+	}
+	if b.targets == nil || (!b.valid && !b.deleted) {
+		return nil
+	}
+
+	if b.deleted {
+		if err := b.deregisterTargets(ctx, rOpts.ELBV2); err != nil {
+			albctx.GetLogger(ctx).Errorf("Error removing targets from %v: %v", b.targets.TgArn, err.Error())
+			return err
+		}
+		return nil
+	}
+
+	// the finalizer is added before any target is registered, so the targets are deregistered even if the
+	// TargetGroupBinding is deleted while the controller is not running
+	if err := addFinalizer(rOpts.Config, b.binding); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error adding finalizer: %s", err.Error())
+		return fmt.Errorf("Failed to add finalizer: %s", err.Error())
+	}
+
+	if err := b.resolveTargetType(ctx, rOpts.ELBV2); err != nil {
+		return err
+	}
+
+	if err := rOpts.TgTargetsController.Reconcile(ctx, b.targets); err != nil {
+		return fmt.Errorf("Failed to reconcile targets of %s: %s", b.targets.TgArn, err.Error())
+	}
+	return nil
+}
+
+// reconcileDeleting deregisters the targets of a deleted TargetGroupBinding and removes its finalizer.
+// The targets registered before a restart of the controller aren't tracked, the current endpoints of
+// the Service are deregistered as well.
+func (b *TargetGroupBinding) reconcileDeleting(ctx context.Context, rOpts *ReconcileOptions) error {
+	if !hasFinalizer(b.binding) {
+		return nil
+	}
+
+	if b.targets != nil {
+		err := b.deregisterDeletingTargets(ctx, rOpts)
+		if isTargetGroupNotFound(err) {
+			albctx.GetLogger(ctx).Infof("Target group %v was deleted, no targets to remove", b.targets.TgArn)
+		} else if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error removing targets from %s: %s", b.targets.TgArn, err.Error())
+			return fmt.Errorf("Failed to remove targets from %s: %s", b.targets.TgArn, err.Error())
+		}
+	}
+
+	if err := removeFinalizer(rOpts.Config, b.binding); err != nil {
+		return fmt.Errorf("Failed to remove finalizer: %s", err.Error())
+	}
+	return nil
+}
+
+func (b *TargetGroupBinding) deregisterDeletingTargets(ctx context.Context, rOpts *ReconcileOptions) error {
+	if b.valid {
+		if err := b.resolveTargetType(ctx, rOpts.ELBV2); err != nil {
+			return err
+		}
+		endpoints, err := rOpts.EndpointResolver.Resolve(b.targets.Ingress, b.targets.Backend, b.targets.TargetType)
+		if err != nil {
+			// e.g. the Service was deleted as well, only the tracked targets can be removed
+			albctx.GetLogger(ctx).Warnf("Unable to resolve the endpoints of service %s: %s", b.targets.Backend.ServiceName, err.Error())
+		}
+		b.targets.Targets = append(b.targets.Targets, endpoints...)
+	}
+	return b.deregisterTargets(ctx, rOpts.ELBV2)
+}
+
+// resolveTargetType reads the target type from the target group when the TargetGroupBinding doesn't specify it
+func (b *TargetGroupBinding) resolveTargetType(ctx context.Context, svc elbv2iface.ELBV2API) error {
+	if b.targets.TargetType != "" {
+		return nil
+	}
+	resp, err := svc.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: []*string{aws.String(b.targets.TgArn)},
+	})
+	if isTargetGroupNotFound(err) || (err == nil && len(resp.TargetGroups) == 0) {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Target group %s not found", b.targets.TgArn)
+		return awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, fmt.Sprintf("Failed to find target group %s", b.targets.TgArn), err)
+	}
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error describing target group %s: %s", b.targets.TgArn, err.Error())
+		return fmt.Errorf("Failed to describe target group %s: %s", b.targets.TgArn, err.Error())
+	}
+	b.targets.TargetType = aws.StringValue(resp.TargetGroups[0].TargetType)
+	return nil
+}
+
+func isTargetGroupNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException
+	}
+	return false
+}
+
+// deregisterTargets removes the targets registered by the binding that aren't already draining. The other
+// targets of the target group, e.g. registered by another binding or outside of the controller, are kept.
+func (b *TargetGroupBinding) deregisterTargets(ctx context.Context, svc elbv2iface.ELBV2API) error {
+	registered := make(map[string]bool)
+	for _, t := range b.targets.Targets {
+		registered[targetKey(t)] = true
+	}
+	if len(registered) == 0 {
+		return nil
+	}
+
+	resp, err := svc.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(b.targets.TgArn)})
+	if err != nil {
+		return err
+	}
+
+	var removals []*elbv2.TargetDescription
+	for _, thd := range resp.TargetHealthDescriptions {
+		if aws.StringValue(thd.TargetHealth.State) == elbv2.TargetHealthStateEnumDraining || !registered[targetKey(thd.Target)] {
+			continue
+		}
+		removals = append(removals, thd.Target)
+	}
+	if len(removals) == 0 {
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("Removing %d targets from %v", len(removals), b.targets.TgArn)
+	_, err = svc.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(b.targets.TgArn),
+		Targets:        removals,
+	})
+	if err != nil {
+		return err
+	}
+	b.targets.Targets = nil
+	return nil
+}
+
+func targetKey(t *elbv2.TargetDescription) string {
+	return fmt.Sprintf("%v:%v", aws.StringValue(t.Id), aws.Int64Value(t.Port))
+}
+
+// Eventf writes an event to the TargetGroupBinding resource
+func (b *TargetGroupBinding) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	if b.recorder == nil || b.deleted {
+		return
+	}
+	b.recorder.Eventf(b.binding, eventtype, reason, messageFmt, args...)
+}
+
+// ID returns the namespace/name of the TargetGroupBinding
+func (b *TargetGroupBinding) ID() string {
+	return b.id
+}
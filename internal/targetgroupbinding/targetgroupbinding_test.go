@@ -0,0 +1,328 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+const (
+	tgArn      = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/1234"
+	otherTgArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/5678"
+)
+
+type fakeEndpointResolver struct {
+	endpoints []*elbv2.TargetDescription
+	err       error
+}
+
+func (r *fakeEndpointResolver) Resolve(*extensions.Ingress, *extensions.IngressBackend, string) ([]*elbv2.TargetDescription, error) {
+	return r.endpoints, r.err
+}
+
+type fakeTargetsController struct{ reconciled []*tg.Targets }
+
+func (c *fakeTargetsController) Reconcile(_ context.Context, t *tg.Targets) error {
+	c.reconciled = append(c.reconciled, t)
+	return nil
+}
+
+func testContext() context.Context {
+	ctx := albctx.SetLogger(context.Background(), log.New("test"))
+	return albctx.SetEventf(ctx, func(string, string, string, ...interface{}) {})
+}
+
+// newFinalizerTestConfig returns a configuration whose dynamic client serves the bindings
+func newFinalizerTestConfig(t *testing.T, bindings ...*v1alpha1.TargetGroupBinding) (*config.Configuration, *fakedynamic.FakeDynamicClient) {
+	scheme := runtime.NewScheme()
+	client := fakedynamic.NewSimpleDynamicClient(scheme)
+	tracker := k8stesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())
+	for _, b := range bindings {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.Add(&unstructured.Unstructured{Object: content}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client.PrependReactor("*", "targetgroupbindings", k8stesting.ObjectReaction(tracker))
+	// the fake clients handle every patch as a strategic merge patch, which unstructured objects don't support
+	client.PrependReactor("patch", "targetgroupbindings", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		var p struct {
+			Metadata struct {
+				Finalizers []string `json:"finalizers"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(patch.GetPatch(), &p); err != nil {
+			return true, nil, err
+		}
+		obj, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		u := obj.(*unstructured.Unstructured)
+		u.SetFinalizers(p.Metadata.Finalizers)
+		return true, u, tracker.Update(patch.GetResource(), u, patch.GetNamespace())
+	})
+
+	cfg := config.NewDefault()
+	cfg.DynamicClient = client
+	return cfg, client
+}
+
+func getFinalizers(t *testing.T, cfg *config.Configuration, b *v1alpha1.TargetGroupBinding) []string {
+	current, err := cfg.DynamicClient.Resource(v1alpha1.TargetGroupBindingResource).Namespace(b.Namespace).Get(b.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return current.GetFinalizers()
+}
+
+func binding(arn, targetType string) *v1alpha1.TargetGroupBinding {
+	return &v1alpha1.TargetGroupBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "TargetGroupBinding"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "web-uid"},
+		Spec: v1alpha1.TargetGroupBindingSpec{
+			TargetGroupARN: arn,
+			TargetType:     targetType,
+			ServiceRef: v1alpha1.ServiceReference{
+				Name: "web",
+				Port: intstr.FromString("http"),
+			},
+		},
+	}
+}
+
+func TestNewTargetGroupBinding(t *testing.T) {
+	b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumIp)})
+	assert.NoError(t, err)
+	assert.Equal(t, "default/web", b.ID())
+	assert.Equal(t, tgArn, b.targets.TgArn)
+	assert.Equal(t, elbv2.TargetTypeEnumIp, b.targets.TargetType)
+	assert.Equal(t, "default", b.targets.Ingress.Namespace)
+	assert.Equal(t, "web", b.targets.Backend.ServiceName)
+	assert.Equal(t, intstr.FromString("http"), b.targets.Backend.ServicePort)
+}
+
+func TestNewTargetGroupBindingInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		binding *v1alpha1.TargetGroupBinding
+	}{
+		{name: "missing target group", binding: binding("", "")},
+		{name: "missing service", binding: func() *v1alpha1.TargetGroupBinding {
+			b := binding(tgArn, "")
+			b.Spec.ServiceRef.Name = ""
+			return b
+		}()},
+		{name: "invalid target type", binding: binding(tgArn, "pod")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			existing, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumInstance)})
+			b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: tc.binding, ExistingBinding: existing})
+			assert.Error(t, err)
+			assert.False(t, b.valid)
+			assert.Equal(t, existing.targets, b.targets)
+
+			// invalid bindings keep their targets and are never reconciled
+			controller := &fakeTargetsController{}
+			assert.NoError(t, b.Reconcile(testContext(), &ReconcileOptions{TgTargetsController: controller}))
+			assert.Empty(t, controller.reconciled)
+			assert.Empty(t, TargetGroupBindings{existing}.RemovedBindings(TargetGroupBindings{b}))
+		})
+	}
+}
+
+func TestReconcileTargetTypeFromTargetGroup(t *testing.T) {
+	b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, "")})
+	assert.NoError(t, err)
+
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("DescribeTargetGroups", &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: []*string{aws.String(tgArn)},
+	}).Return(&elbv2.DescribeTargetGroupsOutput{
+		TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(tgArn), TargetType: aws.String(elbv2.TargetTypeEnumIp)}},
+	}, nil).Once()
+
+	cfg, _ := newFinalizerTestConfig(t, binding(tgArn, ""))
+	tc := &fakeTargetsController{}
+	assert.NoError(t, b.Reconcile(testContext(), &ReconcileOptions{Config: cfg, ELBV2: elbv2svc, TgTargetsController: tc}))
+	assert.Len(t, tc.reconciled, 1)
+	assert.Equal(t, elbv2.TargetTypeEnumIp, tc.reconciled[0].TargetType)
+
+	// the target type read from the target group is kept by the next sync
+	next, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, ""), ExistingBinding: b})
+	assert.NoError(t, err)
+	assert.NoError(t, next.Reconcile(testContext(), &ReconcileOptions{Config: cfg, ELBV2: elbv2svc, TgTargetsController: tc}))
+	assert.Len(t, tc.reconciled, 2)
+	elbv2svc.AssertExpectations(t)
+}
+
+func TestRemovedBindings(t *testing.T) {
+	current, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumInstance)})
+	unchanged, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumInstance)})
+	moved, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(otherTgArn, elbv2.TargetTypeEnumInstance)})
+
+	assert.Empty(t, TargetGroupBindings{current}.RemovedBindings(TargetGroupBindings{unchanged}))
+	assert.Equal(t, TargetGroupBindings{current}, TargetGroupBindings{current}.RemovedBindings(TargetGroupBindings{moved}))
+	assert.True(t, current.deleted)
+
+	// a binding is only removed once
+	assert.Empty(t, TargetGroupBindings{current}.RemovedBindings(nil))
+}
+
+func TestReconcileDeregistersRemovedBinding(t *testing.T) {
+	healthy := &elbv2.TargetDescription{Id: aws.String("i-1"), Port: aws.Int64(30080)}
+	draining := &elbv2.TargetDescription{Id: aws.String("i-2"), Port: aws.Int64(30080)}
+	foreign := &elbv2.TargetDescription{Id: aws.String("i-1"), Port: aws.Int64(31080)}
+
+	existing, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumInstance)})
+	existing.targets.Targets = []*elbv2.TargetDescription{healthy, draining}
+	b, _ := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: binding(tgArn, elbv2.TargetTypeEnumInstance), ExistingBinding: existing})
+	removed := TargetGroupBindings{b}.RemovedBindings(nil)
+	assert.Len(t, removed, 1)
+
+	// the targets not registered by the binding are left alone
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("DescribeTargetHealth", &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+			{Target: healthy, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+			{Target: draining, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumDraining)}},
+			{Target: foreign, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+		},
+	}, nil)
+	elbv2svc.On("DeregisterTargets", &elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(tgArn),
+		Targets:        []*elbv2.TargetDescription{healthy},
+	}).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+
+	tc := &fakeTargetsController{}
+	assert.NoError(t, removed[0].Reconcile(testContext(), &ReconcileOptions{ELBV2: elbv2svc, TgTargetsController: tc}))
+	assert.Empty(t, tc.reconciled)
+	elbv2svc.AssertExpectations(t)
+}
+
+func TestReconcileAddsFinalizer(t *testing.T) {
+	resource := binding(tgArn, elbv2.TargetTypeEnumIp)
+	cfg, _ := newFinalizerTestConfig(t, resource)
+	b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: resource})
+	assert.NoError(t, err)
+
+	tc := &fakeTargetsController{}
+	assert.NoError(t, b.Reconcile(testContext(), &ReconcileOptions{Config: cfg, TgTargetsController: tc}))
+	assert.Len(t, tc.reconciled, 1)
+	assert.Equal(t, []string{Finalizer}, getFinalizers(t, cfg, resource))
+}
+
+func TestReconcileDeletingBinding(t *testing.T) {
+	tracked := &elbv2.TargetDescription{Id: aws.String("10.0.0.1"), Port: aws.Int64(8080)}
+	endpoint := &elbv2.TargetDescription{Id: aws.String("10.0.0.2"), Port: aws.Int64(8080)}
+	foreign := &elbv2.TargetDescription{Id: aws.String("10.0.0.3"), Port: aws.Int64(8080)}
+
+	for _, tc := range []struct {
+		name        string
+		existing    bool
+		resolver    *fakeEndpointResolver
+		deregisters []*elbv2.TargetDescription
+	}{
+		{
+			name:        "tracked targets and endpoints",
+			existing:    true,
+			resolver:    &fakeEndpointResolver{endpoints: []*elbv2.TargetDescription{endpoint}},
+			deregisters: []*elbv2.TargetDescription{tracked, endpoint},
+		},
+		{
+			// e.g. the controller was restarted after the binding was deleted
+			name:        "endpoints only",
+			resolver:    &fakeEndpointResolver{endpoints: []*elbv2.TargetDescription{endpoint}},
+			deregisters: []*elbv2.TargetDescription{endpoint},
+		},
+		{
+			name:        "service deleted",
+			existing:    true,
+			resolver:    &fakeEndpointResolver{err: fmt.Errorf("service not found")},
+			deregisters: []*elbv2.TargetDescription{tracked},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource := binding(tgArn, elbv2.TargetTypeEnumIp)
+			resource.Finalizers = []string{Finalizer}
+			cfg, _ := newFinalizerTestConfig(t, resource)
+
+			deleting := resource.DeepCopy()
+			now := metav1.Now()
+			deleting.DeletionTimestamp = &now
+			var existing *TargetGroupBinding
+			if tc.existing {
+				existing, _ = NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: resource})
+				existing.targets.Targets = []*elbv2.TargetDescription{tracked}
+			}
+			b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: deleting, ExistingBinding: existing})
+			assert.NoError(t, err)
+
+			elbv2svc := &mocks.ELBV2API{}
+			elbv2svc.On("DescribeTargetHealth", &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: tracked, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+					{Target: endpoint, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+					{Target: foreign, TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+				},
+			}, nil)
+			elbv2svc.On("DeregisterTargets", &elbv2.DeregisterTargetsInput{
+				TargetGroupArn: aws.String(tgArn),
+				Targets:        tc.deregisters,
+			}).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+
+			targets := &fakeTargetsController{}
+			assert.NoError(t, b.Reconcile(testContext(), &ReconcileOptions{Config: cfg, ELBV2: elbv2svc, TgTargetsController: targets, EndpointResolver: tc.resolver}))
+			assert.Empty(t, targets.reconciled)
+			assert.Empty(t, getFinalizers(t, cfg, resource))
+			elbv2svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcileDeletingBindingOfDeletedTargetGroup(t *testing.T) {
+	resource := binding(tgArn, "")
+	resource.Finalizers = []string{Finalizer}
+	cfg, _ := newFinalizerTestConfig(t, resource)
+
+	deleting := resource.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{Binding: deleting})
+	assert.NoError(t, err)
+
+	elbv2svc := &mocks.ELBV2API{}
+	elbv2svc.On("DescribeTargetGroups", &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: []*string{aws.String(tgArn)},
+	}).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+
+	// the finalizer doesn't keep the binding once its target group is gone
+	assert.NoError(t, b.Reconcile(testContext(), &ReconcileOptions{Config: cfg, ELBV2: elbv2svc, EndpointResolver: &fakeEndpointResolver{}}))
+	assert.Empty(t, getFinalizers(t, cfg, resource))
+	elbv2svc.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+package targetgroupbinding
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/golang/glog"
+	pool "gopkg.in/go-playground/pool.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+// TargetGroupBindings is a slice of TargetGroupBinding
+type TargetGroupBindings []*TargetGroupBinding
+
+// NewTargetGroupBindingsFromStoreOptions are the options to NewTargetGroupBindingsFromStore
+type NewTargetGroupBindingsFromStoreOptions struct {
+	Recorder            record.EventRecorder
+	Store               store.Storer
+	TargetGroupBindings TargetGroupBindings
+	Metric              metric.Collector
+}
+
+// NewTargetGroupBindingsFromStore returns a TargetGroupBinding for every TargetGroupBinding resource in the store
+func NewTargetGroupBindingsFromStore(o *NewTargetGroupBindingsFromStoreOptions) TargetGroupBindings {
+	var bindings TargetGroupBindings
+	for _, binding := range o.Store.ListTargetGroupBindings() {
+		_, existingBinding := o.TargetGroupBindings.FindByID(binding.Namespace + "/" + binding.Name)
+		b, err := NewTargetGroupBinding(&NewTargetGroupBindingOptions{
+			Binding:         binding,
+			ExistingBinding: existingBinding,
+			Recorder:        o.Recorder,
+		})
+		if err != nil {
+			b.Eventf(corev1.EventTypeWarning, "ERROR", err.Error())
+			b.logger.Errorf(err.Error())
+			o.Metric.IncReconcileErrorCount(b.ID())
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings
+}
+
+// FindByID locates the TargetGroupBinding by the id parameter and returns its position
+func (a TargetGroupBindings) FindByID(id string) (int, *TargetGroupBinding) {
+	for p, v := range a {
+		if v.id == id {
+			return p, v
+		}
+	}
+	return -1, nil
+}
+
+// RemovedBindings returns the TargetGroupBindings that are not in the newList parameter, or are bound to
+// another target group in it, flagged so their targets are deregistered.
+func (a TargetGroupBindings) RemovedBindings(newList TargetGroupBindings) TargetGroupBindings {
+	var removed TargetGroupBindings
+	for _, b := range a {
+		if b.targets == nil || b.deleted {
+			continue
+		}
+		if _, n := newList.FindByID(b.id); n != nil && n.targets != nil && n.targets.TgArn == b.targets.TgArn {
+			continue
+		}
+		b.deleted = true
+		removed = append(removed, b)
+	}
+	return removed
+}
+
+// Reconcile syncs the targets of every TargetGroupBinding
+func (a TargetGroupBindings) Reconcile(m metric.Collector, cfg *config.Configuration, elbv2svc elbv2iface.ELBV2API, tgTargetsController tg.TargetsController, endpointResolver backend.EndpointResolver) {
+	p := pool.NewLimited(20)
+	defer p.Close()
+
+	batch := p.Batch()
+
+	for _, b := range a {
+		batch.Queue(func(b *TargetGroupBinding) pool.WorkFunc {
+			return func(wu pool.WorkUnit) (interface{}, error) {
+				if wu.IsCancelled() {
+					return nil, nil
+				}
+
+				ctx := context.Background()
+				ctx = albctx.SetEventf(ctx, b.Eventf)
+				ctx = albctx.SetLogger(ctx, log.New(b.id))
+				err := b.Reconcile(ctx, &ReconcileOptions{
+					Config:              cfg,
+					ELBV2:               elbv2svc,
+					TgTargetsController: tgTargetsController,
+					EndpointResolver:    endpointResolver,
+				})
+				if err != nil {
+					b.logger.Errorf(err.Error())
+					m.IncReconcileErrorCount(b.ID())
+				}
+				return nil, err
+			}
+		}(b))
+	}
+
+	batch.QueueComplete()
+	for e := range batch.Results() {
+		err := e.Error()
+		if _, ok := err.(*pool.ErrRecovery); ok {
+			glog.Fatal(err.Error())
+		}
+	}
+}
@@ -168,6 +168,84 @@ func (_m *ELBV2API) AddTagsWithContext(_a0 aws.Context, _a1 *elbv2.AddTagsInput,
 	return r0, r1
 }
 
+// AddTrustStoreRevocations provides a mock function with given fields: _a0
+func (_m *ELBV2API) AddTrustStoreRevocations(_a0 *elbv2.AddTrustStoreRevocationsInput) (*elbv2.AddTrustStoreRevocationsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.AddTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.AddTrustStoreRevocationsInput) *elbv2.AddTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.AddTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.AddTrustStoreRevocationsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddTrustStoreRevocationsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) AddTrustStoreRevocationsRequest(_a0 *elbv2.AddTrustStoreRevocationsInput) (*request.Request, *elbv2.AddTrustStoreRevocationsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.AddTrustStoreRevocationsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.AddTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.AddTrustStoreRevocationsInput) *elbv2.AddTrustStoreRevocationsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.AddTrustStoreRevocationsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddTrustStoreRevocationsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) AddTrustStoreRevocationsWithContext(_a0 aws.Context, _a1 *elbv2.AddTrustStoreRevocationsInput, _a2 ...request.Option) (*elbv2.AddTrustStoreRevocationsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.AddTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.AddTrustStoreRevocationsInput, ...request.Option) *elbv2.AddTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.AddTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.AddTrustStoreRevocationsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ClusterLoadBalancers provides a mock function with given fields:
 func (_m *ELBV2API) ClusterLoadBalancers() ([]*elbv2.LoadBalancer, error) {
 	ret := _m.Called()
@@ -191,6 +269,29 @@ func (_m *ELBV2API) ClusterLoadBalancers() ([]*elbv2.LoadBalancer, error) {
 	return r0, r1
 }
 
+// ClusterNetworkLoadBalancers provides a mock function with given fields:
+func (_m *ELBV2API) ClusterNetworkLoadBalancers() ([]*elbv2.LoadBalancer, error) {
+	ret := _m.Called()
+
+	var r0 []*elbv2.LoadBalancer
+	if rf, ok := ret.Get(0).(func() []*elbv2.LoadBalancer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*elbv2.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ClusterTargetGroups provides a mock function with given fields:
 func (_m *ELBV2API) ClusterTargetGroups() (map[string][]*elbv2.TargetGroup, error) {
 	ret := _m.Called()
@@ -526,6 +627,84 @@ func (_m *ELBV2API) CreateTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.Cre
 	return r0, r1
 }
 
+// CreateTrustStore provides a mock function with given fields: _a0
+func (_m *ELBV2API) CreateTrustStore(_a0 *elbv2.CreateTrustStoreInput) (*elbv2.CreateTrustStoreOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.CreateTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.CreateTrustStoreInput) *elbv2.CreateTrustStoreOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.CreateTrustStoreOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.CreateTrustStoreInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTrustStoreRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) CreateTrustStoreRequest(_a0 *elbv2.CreateTrustStoreInput) (*request.Request, *elbv2.CreateTrustStoreOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.CreateTrustStoreInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.CreateTrustStoreOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.CreateTrustStoreInput) *elbv2.CreateTrustStoreOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.CreateTrustStoreOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateTrustStoreWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) CreateTrustStoreWithContext(_a0 aws.Context, _a1 *elbv2.CreateTrustStoreInput, _a2 ...request.Option) (*elbv2.CreateTrustStoreOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.CreateTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.CreateTrustStoreInput, ...request.Option) *elbv2.CreateTrustStoreOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.CreateTrustStoreOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.CreateTrustStoreInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteListener provides a mock function with given fields: _a0
 func (_m *ELBV2API) DeleteListener(_a0 *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
 	ret := _m.Called(_a0)
@@ -760,21 +939,21 @@ func (_m *ELBV2API) DeleteRuleWithContext(_a0 aws.Context, _a1 *elbv2.DeleteRule
 	return r0, r1
 }
 
-// DeleteTargetGroup provides a mock function with given fields: _a0
-func (_m *ELBV2API) DeleteTargetGroup(_a0 *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+// DeleteSharedTrustStoreAssociation provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteSharedTrustStoreAssociation(_a0 *elbv2.DeleteSharedTrustStoreAssociationInput) (*elbv2.DeleteSharedTrustStoreAssociationOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DeleteTargetGroupOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTargetGroupInput) *elbv2.DeleteTargetGroupOutput); ok {
+	var r0 *elbv2.DeleteSharedTrustStoreAssociationOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteSharedTrustStoreAssociationInput) *elbv2.DeleteSharedTrustStoreAssociationOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DeleteTargetGroupOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteSharedTrustStoreAssociationOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTargetGroupInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteSharedTrustStoreAssociationInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -783,12 +962,12 @@ func (_m *ELBV2API) DeleteTargetGroup(_a0 *elbv2.DeleteTargetGroupInput) (*elbv2
 	return r0, r1
 }
 
-// DeleteTargetGroupRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DeleteTargetGroupRequest(_a0 *elbv2.DeleteTargetGroupInput) (*request.Request, *elbv2.DeleteTargetGroupOutput) {
+// DeleteSharedTrustStoreAssociationRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteSharedTrustStoreAssociationRequest(_a0 *elbv2.DeleteSharedTrustStoreAssociationInput) (*request.Request, *elbv2.DeleteSharedTrustStoreAssociationOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTargetGroupInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteSharedTrustStoreAssociationInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -796,20 +975,20 @@ func (_m *ELBV2API) DeleteTargetGroupRequest(_a0 *elbv2.DeleteTargetGroupInput)
 		}
 	}
 
-	var r1 *elbv2.DeleteTargetGroupOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTargetGroupInput) *elbv2.DeleteTargetGroupOutput); ok {
+	var r1 *elbv2.DeleteSharedTrustStoreAssociationOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteSharedTrustStoreAssociationInput) *elbv2.DeleteSharedTrustStoreAssociationOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DeleteTargetGroupOutput)
+			r1 = ret.Get(1).(*elbv2.DeleteSharedTrustStoreAssociationOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DeleteTargetGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DeleteTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.DeleteTargetGroupInput, _a2 ...request.Option) (*elbv2.DeleteTargetGroupOutput, error) {
+// DeleteSharedTrustStoreAssociationWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DeleteSharedTrustStoreAssociationWithContext(_a0 aws.Context, _a1 *elbv2.DeleteSharedTrustStoreAssociationInput, _a2 ...request.Option) (*elbv2.DeleteSharedTrustStoreAssociationOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -819,17 +998,17 @@ func (_m *ELBV2API) DeleteTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.Del
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DeleteTargetGroupOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeleteTargetGroupInput, ...request.Option) *elbv2.DeleteTargetGroupOutput); ok {
+	var r0 *elbv2.DeleteSharedTrustStoreAssociationOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeleteSharedTrustStoreAssociationInput, ...request.Option) *elbv2.DeleteSharedTrustStoreAssociationOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DeleteTargetGroupOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteSharedTrustStoreAssociationOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeleteTargetGroupInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeleteSharedTrustStoreAssociationInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -838,21 +1017,21 @@ func (_m *ELBV2API) DeleteTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.Del
 	return r0, r1
 }
 
-// DeregisterTargets provides a mock function with given fields: _a0
-func (_m *ELBV2API) DeregisterTargets(_a0 *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+// DeleteTargetGroup provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteTargetGroup(_a0 *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DeregisterTargetsOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DeregisterTargetsInput) *elbv2.DeregisterTargetsOutput); ok {
+	var r0 *elbv2.DeleteTargetGroupOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTargetGroupInput) *elbv2.DeleteTargetGroupOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DeregisterTargetsOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteTargetGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DeregisterTargetsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTargetGroupInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -861,12 +1040,12 @@ func (_m *ELBV2API) DeregisterTargets(_a0 *elbv2.DeregisterTargetsInput) (*elbv2
 	return r0, r1
 }
 
-// DeregisterTargetsRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DeregisterTargetsRequest(_a0 *elbv2.DeregisterTargetsInput) (*request.Request, *elbv2.DeregisterTargetsOutput) {
+// DeleteTargetGroupRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteTargetGroupRequest(_a0 *elbv2.DeleteTargetGroupInput) (*request.Request, *elbv2.DeleteTargetGroupOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DeregisterTargetsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTargetGroupInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -874,20 +1053,20 @@ func (_m *ELBV2API) DeregisterTargetsRequest(_a0 *elbv2.DeregisterTargetsInput)
 		}
 	}
 
-	var r1 *elbv2.DeregisterTargetsOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DeregisterTargetsInput) *elbv2.DeregisterTargetsOutput); ok {
+	var r1 *elbv2.DeleteTargetGroupOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTargetGroupInput) *elbv2.DeleteTargetGroupOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DeregisterTargetsOutput)
+			r1 = ret.Get(1).(*elbv2.DeleteTargetGroupOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DeregisterTargetsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DeregisterTargetsWithContext(_a0 aws.Context, _a1 *elbv2.DeregisterTargetsInput, _a2 ...request.Option) (*elbv2.DeregisterTargetsOutput, error) {
+// DeleteTargetGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DeleteTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.DeleteTargetGroupInput, _a2 ...request.Option) (*elbv2.DeleteTargetGroupOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -897,17 +1076,17 @@ func (_m *ELBV2API) DeregisterTargetsWithContext(_a0 aws.Context, _a1 *elbv2.Der
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DeregisterTargetsOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeregisterTargetsInput, ...request.Option) *elbv2.DeregisterTargetsOutput); ok {
+	var r0 *elbv2.DeleteTargetGroupOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeleteTargetGroupInput, ...request.Option) *elbv2.DeleteTargetGroupOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DeregisterTargetsOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteTargetGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeregisterTargetsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeleteTargetGroupInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -916,21 +1095,21 @@ func (_m *ELBV2API) DeregisterTargetsWithContext(_a0 aws.Context, _a1 *elbv2.Der
 	return r0, r1
 }
 
-// DescribeAccountLimits provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeAccountLimits(_a0 *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
+// DeleteTrustStore provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteTrustStore(_a0 *elbv2.DeleteTrustStoreInput) (*elbv2.DeleteTrustStoreOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeAccountLimitsOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeAccountLimitsInput) *elbv2.DescribeAccountLimitsOutput); ok {
+	var r0 *elbv2.DeleteTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTrustStoreInput) *elbv2.DeleteTrustStoreOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeAccountLimitsOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteTrustStoreOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeAccountLimitsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTrustStoreInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -939,12 +1118,12 @@ func (_m *ELBV2API) DescribeAccountLimits(_a0 *elbv2.DescribeAccountLimitsInput)
 	return r0, r1
 }
 
-// DescribeAccountLimitsRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeAccountLimitsRequest(_a0 *elbv2.DescribeAccountLimitsInput) (*request.Request, *elbv2.DescribeAccountLimitsOutput) {
+// DeleteTrustStoreRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeleteTrustStoreRequest(_a0 *elbv2.DeleteTrustStoreInput) (*request.Request, *elbv2.DeleteTrustStoreOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeAccountLimitsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.DeleteTrustStoreInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -952,20 +1131,20 @@ func (_m *ELBV2API) DescribeAccountLimitsRequest(_a0 *elbv2.DescribeAccountLimit
 		}
 	}
 
-	var r1 *elbv2.DescribeAccountLimitsOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeAccountLimitsInput) *elbv2.DescribeAccountLimitsOutput); ok {
+	var r1 *elbv2.DeleteTrustStoreOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DeleteTrustStoreInput) *elbv2.DeleteTrustStoreOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DescribeAccountLimitsOutput)
+			r1 = ret.Get(1).(*elbv2.DeleteTrustStoreOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeAccountLimitsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DescribeAccountLimitsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeAccountLimitsInput, _a2 ...request.Option) (*elbv2.DescribeAccountLimitsOutput, error) {
+// DeleteTrustStoreWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DeleteTrustStoreWithContext(_a0 aws.Context, _a1 *elbv2.DeleteTrustStoreInput, _a2 ...request.Option) (*elbv2.DeleteTrustStoreOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -975,17 +1154,17 @@ func (_m *ELBV2API) DescribeAccountLimitsWithContext(_a0 aws.Context, _a1 *elbv2
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DescribeAccountLimitsOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeAccountLimitsInput, ...request.Option) *elbv2.DescribeAccountLimitsOutput); ok {
+	var r0 *elbv2.DeleteTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeleteTrustStoreInput, ...request.Option) *elbv2.DeleteTrustStoreOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeAccountLimitsOutput)
+			r0 = ret.Get(0).(*elbv2.DeleteTrustStoreOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeAccountLimitsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeleteTrustStoreInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -994,21 +1173,21 @@ func (_m *ELBV2API) DescribeAccountLimitsWithContext(_a0 aws.Context, _a1 *elbv2
 	return r0, r1
 }
 
-// DescribeListenerCertificates provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeListenerCertificates(_a0 *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error) {
+// DeregisterTargets provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeregisterTargets(_a0 *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeListenerCertificatesOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenerCertificatesInput) *elbv2.DescribeListenerCertificatesOutput); ok {
+	var r0 *elbv2.DeregisterTargetsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DeregisterTargetsInput) *elbv2.DeregisterTargetsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeListenerCertificatesOutput)
+			r0 = ret.Get(0).(*elbv2.DeregisterTargetsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeListenerCertificatesInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.DeregisterTargetsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1017,12 +1196,12 @@ func (_m *ELBV2API) DescribeListenerCertificates(_a0 *elbv2.DescribeListenerCert
 	return r0, r1
 }
 
-// DescribeListenerCertificatesRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeListenerCertificatesRequest(_a0 *elbv2.DescribeListenerCertificatesInput) (*request.Request, *elbv2.DescribeListenerCertificatesOutput) {
+// DeregisterTargetsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DeregisterTargetsRequest(_a0 *elbv2.DeregisterTargetsInput) (*request.Request, *elbv2.DeregisterTargetsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenerCertificatesInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.DeregisterTargetsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1030,20 +1209,20 @@ func (_m *ELBV2API) DescribeListenerCertificatesRequest(_a0 *elbv2.DescribeListe
 		}
 	}
 
-	var r1 *elbv2.DescribeListenerCertificatesOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeListenerCertificatesInput) *elbv2.DescribeListenerCertificatesOutput); ok {
+	var r1 *elbv2.DeregisterTargetsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DeregisterTargetsInput) *elbv2.DeregisterTargetsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DescribeListenerCertificatesOutput)
+			r1 = ret.Get(1).(*elbv2.DeregisterTargetsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeListenerCertificatesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DescribeListenerCertificatesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeListenerCertificatesInput, _a2 ...request.Option) (*elbv2.DescribeListenerCertificatesOutput, error) {
+// DeregisterTargetsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DeregisterTargetsWithContext(_a0 aws.Context, _a1 *elbv2.DeregisterTargetsInput, _a2 ...request.Option) (*elbv2.DeregisterTargetsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1053,17 +1232,17 @@ func (_m *ELBV2API) DescribeListenerCertificatesWithContext(_a0 aws.Context, _a1
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DescribeListenerCertificatesOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeListenerCertificatesInput, ...request.Option) *elbv2.DescribeListenerCertificatesOutput); ok {
+	var r0 *elbv2.DeregisterTargetsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DeregisterTargetsInput, ...request.Option) *elbv2.DeregisterTargetsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeListenerCertificatesOutput)
+			r0 = ret.Get(0).(*elbv2.DeregisterTargetsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeListenerCertificatesInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DeregisterTargetsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1072,13 +1251,192 @@ func (_m *ELBV2API) DescribeListenerCertificatesWithContext(_a0 aws.Context, _a1
 	return r0, r1
 }
 
-// DescribeListeners provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeListeners(_a0 *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error) {
+// DescribeAccountLimits provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeAccountLimits(_a0 *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeListenersOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenersInput) *elbv2.DescribeListenersOutput); ok {
-		r0 = rf(_a0)
+	var r0 *elbv2.DescribeAccountLimitsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeAccountLimitsInput) *elbv2.DescribeAccountLimitsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeAccountLimitsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeAccountLimitsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeAccountLimitsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeAccountLimitsRequest(_a0 *elbv2.DescribeAccountLimitsInput) (*request.Request, *elbv2.DescribeAccountLimitsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeAccountLimitsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeAccountLimitsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeAccountLimitsInput) *elbv2.DescribeAccountLimitsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeAccountLimitsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeAccountLimitsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeAccountLimitsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeAccountLimitsInput, _a2 ...request.Option) (*elbv2.DescribeAccountLimitsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeAccountLimitsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeAccountLimitsInput, ...request.Option) *elbv2.DescribeAccountLimitsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeAccountLimitsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeAccountLimitsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeListenerCertificates provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeListenerCertificates(_a0 *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeListenerCertificatesOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenerCertificatesInput) *elbv2.DescribeListenerCertificatesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeListenerCertificatesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeListenerCertificatesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeListenerCertificatesForListener provides a mock function with given fields: listenerArn
+func (_m *ELBV2API) DescribeListenerCertificatesForListener(listenerArn *string) ([]*elbv2.Certificate, error) {
+	ret := _m.Called(listenerArn)
+
+	var r0 []*elbv2.Certificate
+	if rf, ok := ret.Get(0).(func(*string) []*elbv2.Certificate); ok {
+		r0 = rf(listenerArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*elbv2.Certificate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*string) error); ok {
+		r1 = rf(listenerArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeListenerCertificatesRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeListenerCertificatesRequest(_a0 *elbv2.DescribeListenerCertificatesInput) (*request.Request, *elbv2.DescribeListenerCertificatesOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenerCertificatesInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeListenerCertificatesOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeListenerCertificatesInput) *elbv2.DescribeListenerCertificatesOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeListenerCertificatesOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeListenerCertificatesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeListenerCertificatesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeListenerCertificatesInput, _a2 ...request.Option) (*elbv2.DescribeListenerCertificatesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeListenerCertificatesOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeListenerCertificatesInput, ...request.Option) *elbv2.DescribeListenerCertificatesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeListenerCertificatesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeListenerCertificatesInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeListeners provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeListeners(_a0 *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeListenersOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeListenersInput) *elbv2.DescribeListenersOutput); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*elbv2.DescribeListenersOutput)
@@ -1609,23 +1967,654 @@ func (_m *ELBV2API) DescribeTagsWithContext(_a0 aws.Context, _a1 *elbv2.Describe
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
 	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTagsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTagsInput, ...request.Option) *elbv2.DescribeTagsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTagsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTagsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroupAttributes provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetGroupAttributes(_a0 *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTargetGroupAttributesOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupAttributesInput) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupAttributesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupAttributesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroupAttributesRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetGroupAttributesRequest(_a0 *elbv2.DescribeTargetGroupAttributesInput) (*request.Request, *elbv2.DescribeTargetGroupAttributesOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupAttributesInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTargetGroupAttributesOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupAttributesInput) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTargetGroupAttributesOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroupAttributesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTargetGroupAttributesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupAttributesInput, _a2 ...request.Option) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTargetGroupAttributesOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupAttributesInput, ...request.Option) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupAttributesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetGroupAttributesInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroups provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetGroups(_a0 *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTargetGroupsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput) *elbv2.DescribeTargetGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroupsPages provides a mock function with given fields: _a0, _a1
+func (_m *ELBV2API) DescribeTargetGroupsPages(_a0 *elbv2.DescribeTargetGroupsInput, _a1 func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput, func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTargetGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ELBV2API) DescribeTargetGroupsPagesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupsInput, _a2 func(*elbv2.DescribeTargetGroupsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, func(*elbv2.DescribeTargetGroupsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTargetGroupsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetGroupsRequest(_a0 *elbv2.DescribeTargetGroupsInput) (*request.Request, *elbv2.DescribeTargetGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTargetGroupsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupsInput) *elbv2.DescribeTargetGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTargetGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTargetGroupsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupsInput, _a2 ...request.Option) (*elbv2.DescribeTargetGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTargetGroupsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, ...request.Option) *elbv2.DescribeTargetGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetHealth provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetHealth(_a0 *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTargetHealthOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetHealthInput) *elbv2.DescribeTargetHealthOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetHealthOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetHealthInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetHealthRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTargetHealthRequest(_a0 *elbv2.DescribeTargetHealthInput) (*request.Request, *elbv2.DescribeTargetHealthOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetHealthInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTargetHealthOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetHealthInput) *elbv2.DescribeTargetHealthOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTargetHealthOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTargetHealthWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTargetHealthWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetHealthInput, _a2 ...request.Option) (*elbv2.DescribeTargetHealthOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTargetHealthOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetHealthInput, ...request.Option) *elbv2.DescribeTargetHealthOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTargetHealthOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetHealthInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreAssociations provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStoreAssociations(_a0 *elbv2.DescribeTrustStoreAssociationsInput) (*elbv2.DescribeTrustStoreAssociationsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTrustStoreAssociationsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreAssociationsInput) *elbv2.DescribeTrustStoreAssociationsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoreAssociationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoreAssociationsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreAssociationsPages provides a mock function with given fields: _a0, _a1
+func (_m *ELBV2API) DescribeTrustStoreAssociationsPages(_a0 *elbv2.DescribeTrustStoreAssociationsInput, _a1 func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreAssociationsInput, func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoreAssociationsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ELBV2API) DescribeTrustStoreAssociationsPagesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoreAssociationsInput, _a2 func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoreAssociationsInput, func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoreAssociationsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStoreAssociationsRequest(_a0 *elbv2.DescribeTrustStoreAssociationsInput) (*request.Request, *elbv2.DescribeTrustStoreAssociationsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreAssociationsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTrustStoreAssociationsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoreAssociationsInput) *elbv2.DescribeTrustStoreAssociationsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTrustStoreAssociationsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreAssociationsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTrustStoreAssociationsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoreAssociationsInput, _a2 ...request.Option) (*elbv2.DescribeTrustStoreAssociationsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTrustStoreAssociationsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoreAssociationsInput, ...request.Option) *elbv2.DescribeTrustStoreAssociationsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoreAssociationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTrustStoreAssociationsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreRevocations provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStoreRevocations(_a0 *elbv2.DescribeTrustStoreRevocationsInput) (*elbv2.DescribeTrustStoreRevocationsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreRevocationsInput) *elbv2.DescribeTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoreRevocationsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreRevocationsPages provides a mock function with given fields: _a0, _a1
+func (_m *ELBV2API) DescribeTrustStoreRevocationsPages(_a0 *elbv2.DescribeTrustStoreRevocationsInput, _a1 func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreRevocationsInput, func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoreRevocationsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ELBV2API) DescribeTrustStoreRevocationsPagesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoreRevocationsInput, _a2 func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoreRevocationsInput, func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoreRevocationsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStoreRevocationsRequest(_a0 *elbv2.DescribeTrustStoreRevocationsInput) (*request.Request, *elbv2.DescribeTrustStoreRevocationsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoreRevocationsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoreRevocationsInput) *elbv2.DescribeTrustStoreRevocationsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTrustStoreRevocationsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoreRevocationsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTrustStoreRevocationsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoreRevocationsInput, _a2 ...request.Option) (*elbv2.DescribeTrustStoreRevocationsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoreRevocationsInput, ...request.Option) *elbv2.DescribeTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTrustStoreRevocationsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStores provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStores(_a0 *elbv2.DescribeTrustStoresInput) (*elbv2.DescribeTrustStoresOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.DescribeTrustStoresOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoresInput) *elbv2.DescribeTrustStoresOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoresOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoresInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoresPages provides a mock function with given fields: _a0, _a1
+func (_m *ELBV2API) DescribeTrustStoresPages(_a0 *elbv2.DescribeTrustStoresInput, _a1 func(*elbv2.DescribeTrustStoresOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoresInput, func(*elbv2.DescribeTrustStoresOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoresPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ELBV2API) DescribeTrustStoresPagesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoresInput, _a2 func(*elbv2.DescribeTrustStoresOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoresInput, func(*elbv2.DescribeTrustStoresOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeTrustStoresRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) DescribeTrustStoresRequest(_a0 *elbv2.DescribeTrustStoresInput) (*request.Request, *elbv2.DescribeTrustStoresOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTrustStoresInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.DescribeTrustStoresOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTrustStoresInput) *elbv2.DescribeTrustStoresOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.DescribeTrustStoresOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTrustStoresWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) DescribeTrustStoresWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTrustStoresInput, _a2 ...request.Option) (*elbv2.DescribeTrustStoresOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.DescribeTrustStoresOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTrustStoresInput, ...request.Option) *elbv2.DescribeTrustStoresOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeTrustStoresOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTrustStoresInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
 
-	var r0 *elbv2.DescribeTagsOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTagsInput, ...request.Option) *elbv2.DescribeTagsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	return r0, r1
+}
+
+// GetLoadBalancerByArn provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetLoadBalancerByArn(_a0 string) (*elbv2.LoadBalancer, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.LoadBalancer
+	if rf, ok := ret.Get(0).(func(string) *elbv2.LoadBalancer); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTagsOutput)
+			r0 = ret.Get(0).(*elbv2.LoadBalancer)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTagsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1633,21 +2622,21 @@ func (_m *ELBV2API) DescribeTagsWithContext(_a0 aws.Context, _a1 *elbv2.Describe
 	return r0, r1
 }
 
-// DescribeTargetGroupAttributes provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetGroupAttributes(_a0 *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+// GetResourcePolicy provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetResourcePolicy(_a0 *elbv2.GetResourcePolicyInput) (*elbv2.GetResourcePolicyOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeTargetGroupAttributesOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupAttributesInput) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+	var r0 *elbv2.GetResourcePolicyOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.GetResourcePolicyInput) *elbv2.GetResourcePolicyOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupAttributesOutput)
+			r0 = ret.Get(0).(*elbv2.GetResourcePolicyOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupAttributesInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.GetResourcePolicyInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1656,12 +2645,12 @@ func (_m *ELBV2API) DescribeTargetGroupAttributes(_a0 *elbv2.DescribeTargetGroup
 	return r0, r1
 }
 
-// DescribeTargetGroupAttributesRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetGroupAttributesRequest(_a0 *elbv2.DescribeTargetGroupAttributesInput) (*request.Request, *elbv2.DescribeTargetGroupAttributesOutput) {
+// GetResourcePolicyRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetResourcePolicyRequest(_a0 *elbv2.GetResourcePolicyInput) (*request.Request, *elbv2.GetResourcePolicyOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupAttributesInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.GetResourcePolicyInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1669,20 +2658,20 @@ func (_m *ELBV2API) DescribeTargetGroupAttributesRequest(_a0 *elbv2.DescribeTarg
 		}
 	}
 
-	var r1 *elbv2.DescribeTargetGroupAttributesOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupAttributesInput) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+	var r1 *elbv2.GetResourcePolicyOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.GetResourcePolicyInput) *elbv2.GetResourcePolicyOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DescribeTargetGroupAttributesOutput)
+			r1 = ret.Get(1).(*elbv2.GetResourcePolicyOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeTargetGroupAttributesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DescribeTargetGroupAttributesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupAttributesInput, _a2 ...request.Option) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+// GetResourcePolicyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) GetResourcePolicyWithContext(_a0 aws.Context, _a1 *elbv2.GetResourcePolicyInput, _a2 ...request.Option) (*elbv2.GetResourcePolicyOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1692,17 +2681,17 @@ func (_m *ELBV2API) DescribeTargetGroupAttributesWithContext(_a0 aws.Context, _a
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DescribeTargetGroupAttributesOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupAttributesInput, ...request.Option) *elbv2.DescribeTargetGroupAttributesOutput); ok {
+	var r0 *elbv2.GetResourcePolicyOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.GetResourcePolicyInput, ...request.Option) *elbv2.GetResourcePolicyOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupAttributesOutput)
+			r0 = ret.Get(0).(*elbv2.GetResourcePolicyOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetGroupAttributesInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.GetResourcePolicyInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1711,21 +2700,21 @@ func (_m *ELBV2API) DescribeTargetGroupAttributesWithContext(_a0 aws.Context, _a
 	return r0, r1
 }
 
-// DescribeTargetGroups provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetGroups(_a0 *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+// GetTrustStoreCaCertificatesBundle provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetTrustStoreCaCertificatesBundle(_a0 *elbv2.GetTrustStoreCaCertificatesBundleInput) (*elbv2.GetTrustStoreCaCertificatesBundleOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeTargetGroupsOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput) *elbv2.DescribeTargetGroupsOutput); ok {
+	var r0 *elbv2.GetTrustStoreCaCertificatesBundleOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.GetTrustStoreCaCertificatesBundleInput) *elbv2.GetTrustStoreCaCertificatesBundleOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupsOutput)
+			r0 = ret.Get(0).(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.GetTrustStoreCaCertificatesBundleInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1734,47 +2723,12 @@ func (_m *ELBV2API) DescribeTargetGroups(_a0 *elbv2.DescribeTargetGroupsInput) (
 	return r0, r1
 }
 
-// DescribeTargetGroupsPages provides a mock function with given fields: _a0, _a1
-func (_m *ELBV2API) DescribeTargetGroupsPages(_a0 *elbv2.DescribeTargetGroupsInput, _a1 func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput, func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeTargetGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *ELBV2API) DescribeTargetGroupsPagesWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupsInput, _a2 func(*elbv2.DescribeTargetGroupsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, func(*elbv2.DescribeTargetGroupsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeTargetGroupsRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetGroupsRequest(_a0 *elbv2.DescribeTargetGroupsInput) (*request.Request, *elbv2.DescribeTargetGroupsOutput) {
+// GetTrustStoreCaCertificatesBundleRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetTrustStoreCaCertificatesBundleRequest(_a0 *elbv2.GetTrustStoreCaCertificatesBundleInput) (*request.Request, *elbv2.GetTrustStoreCaCertificatesBundleOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetGroupsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.GetTrustStoreCaCertificatesBundleInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1782,20 +2736,20 @@ func (_m *ELBV2API) DescribeTargetGroupsRequest(_a0 *elbv2.DescribeTargetGroupsI
 		}
 	}
 
-	var r1 *elbv2.DescribeTargetGroupsOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetGroupsInput) *elbv2.DescribeTargetGroupsOutput); ok {
+	var r1 *elbv2.GetTrustStoreCaCertificatesBundleOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.GetTrustStoreCaCertificatesBundleInput) *elbv2.GetTrustStoreCaCertificatesBundleOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DescribeTargetGroupsOutput)
+			r1 = ret.Get(1).(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeTargetGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DescribeTargetGroupsWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetGroupsInput, _a2 ...request.Option) (*elbv2.DescribeTargetGroupsOutput, error) {
+// GetTrustStoreCaCertificatesBundleWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) GetTrustStoreCaCertificatesBundleWithContext(_a0 aws.Context, _a1 *elbv2.GetTrustStoreCaCertificatesBundleInput, _a2 ...request.Option) (*elbv2.GetTrustStoreCaCertificatesBundleOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1805,17 +2759,17 @@ func (_m *ELBV2API) DescribeTargetGroupsWithContext(_a0 aws.Context, _a1 *elbv2.
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DescribeTargetGroupsOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, ...request.Option) *elbv2.DescribeTargetGroupsOutput); ok {
+	var r0 *elbv2.GetTrustStoreCaCertificatesBundleOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.GetTrustStoreCaCertificatesBundleInput, ...request.Option) *elbv2.GetTrustStoreCaCertificatesBundleOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetGroupsOutput)
+			r0 = ret.Get(0).(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetGroupsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.GetTrustStoreCaCertificatesBundleInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1824,21 +2778,21 @@ func (_m *ELBV2API) DescribeTargetGroupsWithContext(_a0 aws.Context, _a1 *elbv2.
 	return r0, r1
 }
 
-// DescribeTargetHealth provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetHealth(_a0 *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+// GetTrustStoreRevocationContent provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetTrustStoreRevocationContent(_a0 *elbv2.GetTrustStoreRevocationContentInput) (*elbv2.GetTrustStoreRevocationContentOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *elbv2.DescribeTargetHealthOutput
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetHealthInput) *elbv2.DescribeTargetHealthOutput); ok {
+	var r0 *elbv2.GetTrustStoreRevocationContentOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.GetTrustStoreRevocationContentInput) *elbv2.GetTrustStoreRevocationContentOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetHealthOutput)
+			r0 = ret.Get(0).(*elbv2.GetTrustStoreRevocationContentOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetHealthInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*elbv2.GetTrustStoreRevocationContentInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1847,12 +2801,12 @@ func (_m *ELBV2API) DescribeTargetHealth(_a0 *elbv2.DescribeTargetHealthInput) (
 	return r0, r1
 }
 
-// DescribeTargetHealthRequest provides a mock function with given fields: _a0
-func (_m *ELBV2API) DescribeTargetHealthRequest(_a0 *elbv2.DescribeTargetHealthInput) (*request.Request, *elbv2.DescribeTargetHealthOutput) {
+// GetTrustStoreRevocationContentRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) GetTrustStoreRevocationContentRequest(_a0 *elbv2.GetTrustStoreRevocationContentInput) (*request.Request, *elbv2.GetTrustStoreRevocationContentOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*elbv2.DescribeTargetHealthInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*elbv2.GetTrustStoreRevocationContentInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1860,20 +2814,20 @@ func (_m *ELBV2API) DescribeTargetHealthRequest(_a0 *elbv2.DescribeTargetHealthI
 		}
 	}
 
-	var r1 *elbv2.DescribeTargetHealthOutput
-	if rf, ok := ret.Get(1).(func(*elbv2.DescribeTargetHealthInput) *elbv2.DescribeTargetHealthOutput); ok {
+	var r1 *elbv2.GetTrustStoreRevocationContentOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.GetTrustStoreRevocationContentInput) *elbv2.GetTrustStoreRevocationContentOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*elbv2.DescribeTargetHealthOutput)
+			r1 = ret.Get(1).(*elbv2.GetTrustStoreRevocationContentOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeTargetHealthWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ELBV2API) DescribeTargetHealthWithContext(_a0 aws.Context, _a1 *elbv2.DescribeTargetHealthInput, _a2 ...request.Option) (*elbv2.DescribeTargetHealthOutput, error) {
+// GetTrustStoreRevocationContentWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) GetTrustStoreRevocationContentWithContext(_a0 aws.Context, _a1 *elbv2.GetTrustStoreRevocationContentInput, _a2 ...request.Option) (*elbv2.GetTrustStoreRevocationContentOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1883,17 +2837,17 @@ func (_m *ELBV2API) DescribeTargetHealthWithContext(_a0 aws.Context, _a1 *elbv2.
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *elbv2.DescribeTargetHealthOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.DescribeTargetHealthInput, ...request.Option) *elbv2.DescribeTargetHealthOutput); ok {
+	var r0 *elbv2.GetTrustStoreRevocationContentOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.GetTrustStoreRevocationContentInput, ...request.Option) *elbv2.GetTrustStoreRevocationContentOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.DescribeTargetHealthOutput)
+			r0 = ret.Get(0).(*elbv2.GetTrustStoreRevocationContentOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.DescribeTargetHealthInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.GetTrustStoreRevocationContentInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1902,29 +2856,6 @@ func (_m *ELBV2API) DescribeTargetHealthWithContext(_a0 aws.Context, _a1 *elbv2.
 	return r0, r1
 }
 
-// GetLoadBalancerByArn provides a mock function with given fields: _a0
-func (_m *ELBV2API) GetLoadBalancerByArn(_a0 string) (*elbv2.LoadBalancer, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *elbv2.LoadBalancer
-	if rf, ok := ret.Get(0).(func(string) *elbv2.LoadBalancer); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.LoadBalancer)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifyListener provides a mock function with given fields: _a0
 func (_m *ELBV2API) ModifyListener(_a0 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	ret := _m.Called(_a0)
@@ -2315,6 +3246,84 @@ func (_m *ELBV2API) ModifyTargetGroupWithContext(_a0 aws.Context, _a1 *elbv2.Mod
 	return r0, r1
 }
 
+// ModifyTrustStore provides a mock function with given fields: _a0
+func (_m *ELBV2API) ModifyTrustStore(_a0 *elbv2.ModifyTrustStoreInput) (*elbv2.ModifyTrustStoreOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.ModifyTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.ModifyTrustStoreInput) *elbv2.ModifyTrustStoreOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.ModifyTrustStoreOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.ModifyTrustStoreInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModifyTrustStoreRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) ModifyTrustStoreRequest(_a0 *elbv2.ModifyTrustStoreInput) (*request.Request, *elbv2.ModifyTrustStoreOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.ModifyTrustStoreInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.ModifyTrustStoreOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.ModifyTrustStoreInput) *elbv2.ModifyTrustStoreOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.ModifyTrustStoreOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ModifyTrustStoreWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) ModifyTrustStoreWithContext(_a0 aws.Context, _a1 *elbv2.ModifyTrustStoreInput, _a2 ...request.Option) (*elbv2.ModifyTrustStoreOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.ModifyTrustStoreOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.ModifyTrustStoreInput, ...request.Option) *elbv2.ModifyTrustStoreOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.ModifyTrustStoreOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.ModifyTrustStoreInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RegisterTargets provides a mock function with given fields: _a0
 func (_m *ELBV2API) RegisterTargets(_a0 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 	ret := _m.Called(_a0)
@@ -2577,6 +3586,84 @@ func (_m *ELBV2API) RemoveTargetGroup(arn *string) error {
 	return r0
 }
 
+// RemoveTrustStoreRevocations provides a mock function with given fields: _a0
+func (_m *ELBV2API) RemoveTrustStoreRevocations(_a0 *elbv2.RemoveTrustStoreRevocationsInput) (*elbv2.RemoveTrustStoreRevocationsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *elbv2.RemoveTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(*elbv2.RemoveTrustStoreRevocationsInput) *elbv2.RemoveTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.RemoveTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*elbv2.RemoveTrustStoreRevocationsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTrustStoreRevocationsRequest provides a mock function with given fields: _a0
+func (_m *ELBV2API) RemoveTrustStoreRevocationsRequest(_a0 *elbv2.RemoveTrustStoreRevocationsInput) (*request.Request, *elbv2.RemoveTrustStoreRevocationsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*elbv2.RemoveTrustStoreRevocationsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *elbv2.RemoveTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(1).(func(*elbv2.RemoveTrustStoreRevocationsInput) *elbv2.RemoveTrustStoreRevocationsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*elbv2.RemoveTrustStoreRevocationsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// RemoveTrustStoreRevocationsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ELBV2API) RemoveTrustStoreRevocationsWithContext(_a0 aws.Context, _a1 *elbv2.RemoveTrustStoreRevocationsInput, _a2 ...request.Option) (*elbv2.RemoveTrustStoreRevocationsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *elbv2.RemoveTrustStoreRevocationsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *elbv2.RemoveTrustStoreRevocationsInput, ...request.Option) *elbv2.RemoveTrustStoreRevocationsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.RemoveTrustStoreRevocationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *elbv2.RemoveTrustStoreRevocationsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetField provides a mock function with given fields: _a0, _a1
 func (_m *ELBV2API) SetField(_a0 string, _a1 interface{}) {
 	_m.Called(_a0, _a1)
@@ -7,6 +7,7 @@ import config "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/in
 import mock "github.com/stretchr/testify/mock"
 
 import v1 "k8s.io/api/core/v1"
+import v1alpha1 "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 import v1beta1 "k8s.io/api/extensions/v1beta1"
 
 // Storer is an autogenerated mock type for the Storer type
@@ -164,6 +165,29 @@ func (_m *Storer) GetNodeInstanceID(node *v1.Node) (string, error) {
 	return r0, r1
 }
 
+// GetSecret provides a mock function with given fields: key
+func (_m *Storer) GetSecret(key string) (*v1.Secret, error) {
+	ret := _m.Called(key)
+
+	var r0 *v1.Secret
+	if rf, ok := ret.Get(0).(func(string) *v1.Secret); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Secret)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetService provides a mock function with given fields: key
 func (_m *Storer) GetService(key string) (*v1.Service, error) {
 	ret := _m.Called(key)
@@ -249,6 +273,54 @@ func (_m *Storer) ListIngresses() []*v1beta1.Ingress {
 	return r0
 }
 
+// ListServices provides a mock function with given fields:
+func (_m *Storer) ListServices() []*v1.Service {
+	ret := _m.Called()
+
+	var r0 []*v1.Service
+	if rf, ok := ret.Get(0).(func() []*v1.Service); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Service)
+		}
+	}
+
+	return r0
+}
+
+// ListTargetGroupBindings provides a mock function with given fields:
+func (_m *Storer) ListTargetGroupBindings() []*v1alpha1.TargetGroupBinding {
+	ret := _m.Called()
+
+	var r0 []*v1alpha1.TargetGroupBinding
+	if rf, ok := ret.Get(0).(func() []*v1alpha1.TargetGroupBinding); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1alpha1.TargetGroupBinding)
+		}
+	}
+
+	return r0
+}
+
+// GetIngressClassParams provides a mock function with given fields: className
+func (_m *Storer) GetIngressClassParams(className string) *v1alpha1.IngressClassParams {
+	ret := _m.Called(className)
+
+	var r0 *v1alpha1.IngressClassParams
+	if rf, ok := ret.Get(0).(func(string) *v1alpha1.IngressClassParams); ok {
+		r0 = rf(className)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.IngressClassParams)
+		}
+	}
+
+	return r0
+}
+
 // ListNodes provides a mock function with given fields:
 func (_m *Storer) ListNodes() []*v1.Node {
 	ret := _m.Called()
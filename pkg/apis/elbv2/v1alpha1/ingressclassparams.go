@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IngressClassParamsResource is the resource of IngressClassParams, used with the dynamic client
+var IngressClassParamsResource = SchemeGroupVersion.WithResource("ingressclassparams")
+
+// IngressClassParams are the settings enforced on every Ingress of an ingress class. The cluster
+// scoped resource is referenced by the parameters of the IngressClass, or named after the ingress
+// class it applies to.
+type IngressClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassParamsSpec `json:"spec"`
+}
+
+// IngressClassParamsSpec is the specification of IngressClassParams. The settings left empty can
+// be set by each Ingress, the others are used by default and Ingresses setting a different value
+// are rejected.
+type IngressClassParamsSpec struct {
+	// Namespaces are the only namespaces allowed to use the ingress class. Every namespace is allowed when empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Scheme is the scheme of the load balancers, internal or internet-facing
+	Scheme string `json:"scheme,omitempty"`
+
+	// Subnets are the IDs or Name tags of the subnets of the load balancers
+	Subnets []string `json:"subnets,omitempty"`
+
+	// SecurityGroups are the IDs or Name tags of the security groups of the load balancers
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
+	// InboundCIDRs are the CIDRs allowed by the security groups managed by the controller
+	InboundCIDRs []string `json:"inboundCIDRs,omitempty"`
+
+	// SSLPolicy is the security policy of the HTTPS listeners
+	SSLPolicy string `json:"sslPolicy,omitempty"`
+
+	// Tags are added to the load balancers. Ingresses may add other tags, but not override these.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// IngressClassParamsFromUnstructured converts the object returned by the dynamic client into IngressClassParams
+func IngressClassParamsFromUnstructured(u *unstructured.Unstructured) (*IngressClassParams, error) {
+	params := &IngressClassParams{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *IngressClassParams) DeepCopyInto(out *IngressClassParams) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a copy of the IngressClassParams
+func (in *IngressClassParams) DeepCopy() *IngressClassParams {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object, so events can be recorded on IngressClassParams
+func (in *IngressClassParams) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *IngressClassParamsSpec) DeepCopyInto(out *IngressClassParamsSpec) {
+	*out = *in
+	out.Namespaces = copyStrings(in.Namespaces)
+	out.Subnets = copyStrings(in.Subnets)
+	out.SecurityGroups = copyStrings(in.SecurityGroups)
+	out.InboundCIDRs = copyStrings(in.InboundCIDRs)
+	if in.Tags != nil {
+		out.Tags = make(map[string]string, len(in.Tags))
+		for k, v := range in.Tags {
+			out.Tags[k] = v
+		}
+	}
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
@@ -0,0 +1,81 @@
+// Package v1alpha1 contains the custom resources of the elbv2.k8s.aws API group.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GroupName is the API group of the custom resources
+const GroupName = "elbv2.k8s.aws"
+
+// SchemeGroupVersion is the group version of the custom resources
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+// TargetGroupBindingResource is the resource of TargetGroupBindings, used with the dynamic client
+var TargetGroupBindingResource = SchemeGroupVersion.WithResource("targetgroupbindings")
+
+// TargetGroupBinding registers the endpoints of a Service port as the targets of a target group
+// managed outside of the controller, e.g. by Terraform or CloudFormation.
+type TargetGroupBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TargetGroupBindingSpec `json:"spec"`
+}
+
+// TargetGroupBindingSpec is the specification of a TargetGroupBinding
+type TargetGroupBindingSpec struct {
+	// TargetGroupARN is the ARN of the target group. Only its targets are modified by the controller.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// TargetType is the type of targets, either instance or ip. The target type of the target group is used when empty.
+	TargetType string `json:"targetType,omitempty"`
+
+	// ServiceRef is the Service port whose endpoints are registered, in the namespace of the TargetGroupBinding.
+	ServiceRef ServiceReference `json:"serviceRef"`
+}
+
+// ServiceReference references a port of a Service
+type ServiceReference struct {
+	// Name is the name of the Service
+	Name string `json:"name"`
+
+	// Port is the name or number of the Service port
+	Port intstr.IntOrString `json:"port"`
+}
+
+// TargetGroupBindingFromUnstructured converts the object returned by the dynamic client into a TargetGroupBinding
+func TargetGroupBindingFromUnstructured(u *unstructured.Unstructured) (*TargetGroupBinding, error) {
+	binding := &TargetGroupBinding{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), binding); err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *TargetGroupBinding) DeepCopyInto(out *TargetGroupBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy returns a copy of the TargetGroupBinding
+func (in *TargetGroupBinding) DeepCopy() *TargetGroupBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object, so events can be recorded on TargetGroupBindings
+func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestTargetGroupBindingFromUnstructured(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		port interface{}
+		want intstr.IntOrString
+	}{
+		{name: "port number", port: int64(80), want: intstr.FromInt(80)},
+		{name: "port name", port: "http", want: intstr.FromString("http")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": SchemeGroupVersion.String(),
+				"kind":       "TargetGroupBinding",
+				"metadata": map[string]interface{}{
+					"namespace": "default",
+					"name":      "web",
+				},
+				"spec": map[string]interface{}{
+					"targetGroupARN": "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/1234",
+					"serviceRef": map[string]interface{}{
+						"name": "web",
+						"port": tc.port,
+					},
+				},
+			}}
+
+			binding, err := TargetGroupBindingFromUnstructured(u)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if binding.Namespace != "default" || binding.Name != "web" {
+				t.Errorf("expected default/web, got %s/%s", binding.Namespace, binding.Name)
+			}
+			if binding.Kind != "TargetGroupBinding" {
+				t.Errorf("expected kind TargetGroupBinding, got %s", binding.Kind)
+			}
+			if binding.Spec.TargetGroupARN != "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/1234" {
+				t.Errorf("unexpected target group ARN %s", binding.Spec.TargetGroupARN)
+			}
+			if binding.Spec.ServiceRef.Name != "web" || binding.Spec.ServiceRef.Port != tc.want {
+				t.Errorf("unexpected service reference %v", binding.Spec.ServiceRef)
+			}
+		})
+	}
+}
+
+func TestIngressClassParamsFromUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": SchemeGroupVersion.String(),
+		"kind":       "IngressClassParams",
+		"metadata": map[string]interface{}{
+			"name": "alb",
+		},
+		"spec": map[string]interface{}{
+			"namespaces": []interface{}{"team-a", "team-b"},
+			"scheme":     "internal",
+			"subnets":    []interface{}{"subnet-1", "subnet-2"},
+			"sslPolicy":  "ELBSecurityPolicy-TLS-1-2-2017-01",
+			"tags":       map[string]interface{}{"team": "platform"},
+		},
+	}}
+
+	params, err := IngressClassParamsFromUnstructured(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Name != "alb" {
+		t.Errorf("expected alb, got %s", params.Name)
+	}
+	if len(params.Spec.Namespaces) != 2 || params.Spec.Namespaces[1] != "team-b" {
+		t.Errorf("unexpected namespaces %v", params.Spec.Namespaces)
+	}
+	if params.Spec.Scheme != "internal" || params.Spec.SSLPolicy != "ELBSecurityPolicy-TLS-1-2-2017-01" {
+		t.Errorf("unexpected spec %+v", params.Spec)
+	}
+	if params.Spec.Tags["team"] != "platform" {
+		t.Errorf("unexpected tags %v", params.Spec.Tags)
+	}
+
+	c := params.DeepCopy()
+	c.Spec.Subnets[0] = "subnet-3"
+	c.Spec.Tags["team"] = "other"
+	if params.Spec.Subnets[0] != "subnet-1" || params.Spec.Tags["team"] != "platform" {
+		t.Errorf("DeepCopy shares the spec of the original")
+	}
+}